@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/newm4n/swaggo/pkg/gen"
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// generators are the kinds of code generated by swaggo gen.
+var generators = map[string]func(flags *flag.FlagSet) func(openAPI *v303.OpenAPI, pkg string) ([]byte, error){
+	"server": func(flags *flag.FlagSet) func(openAPI *v303.OpenAPI, pkg string) ([]byte, error) {
+		chi := flags.Bool("chi", false, "also generate RegisterChi, registering the operations on a chi router")
+		return func(openAPI *v303.OpenAPI, pkg string) ([]byte, error) {
+			return gen.GenerateServer(openAPI, gen.ServerOptions{Package: pkg, Chi: *chi})
+		}
+	},
+	"client": func(flags *flag.FlagSet) func(openAPI *v303.OpenAPI, pkg string) ([]byte, error) {
+		return func(openAPI *v303.OpenAPI, pkg string) ([]byte, error) {
+			return gen.GenerateClient(openAPI, gen.ClientOptions{Package: pkg})
+		}
+	},
+	"models": func(flags *flag.FlagSet) func(openAPI *v303.OpenAPI, pkg string) ([]byte, error) {
+		return func(openAPI *v303.OpenAPI, pkg string) ([]byte, error) {
+			return gen.GenerateModels(openAPI, gen.ModelsOptions{Package: pkg})
+		}
+	},
+}
+
+// runGen generates Go code of the kind named by the first argument from an OpenAPI 3.0.3 document.
+func runGen(args []string) error {
+	if len(args) == 0 || generators[args[0]] == nil {
+		return fmt.Errorf("usage: swaggo gen server|client|models [flags]")
+	}
+	flags := flag.NewFlagSet("swaggo gen "+args[0], flag.ContinueOnError)
+	spec := flags.String("spec", "docs/openapi.json", "OpenAPI 3.0.3 document, in JSON or YAML")
+	pkg := flags.String("package", "api", "package name of the generated code")
+	output := flags.String("output", "", "file the code is written to, the standard output when empty")
+	generate := generators[args[0]](flags)
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	openAPI, err := v303.NewLoader(v303.Dir(filepath.Dir(*spec))).LoadFile(filepath.Base(*spec))
+	if err != nil {
+		return err
+	}
+	source, err := generate(openAPI, *pkg)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(*output), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(*output, source, 0644)
+}
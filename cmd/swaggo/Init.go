@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/annotation"
+	"github.com/newm4n/swaggo/pkg/openapi/convert"
+	"gopkg.in/yaml.v3"
+)
+
+// runInit parses the annotations of Go sources and writes the OpenAPI document they describe, and optionally its
+// Swagger 2.0 equivalent, to the output directory.
+func runInit(args []string) error {
+	flags := flag.NewFlagSet("swaggo init", flag.ContinueOnError)
+	dirs := flags.String("dir", ".", "comma separated directories searched recursively for annotated Go sources")
+	exclude := flags.String("exclude", "", "comma separated directories to skip")
+	output := flags.String("output", "docs", "directory the documents are written to")
+	formats := flags.String("format", "json,yaml", "comma separated formats of the documents, json and yaml")
+	swagger2 := flags.Bool("swagger2", false, "also write a Swagger 2.0 document")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	options := annotation.Options{Dirs: splitFlag(*dirs), Exclude: splitFlag(*exclude)}
+	openAPI, err := annotation.Parse(options)
+	if err != nil {
+		return err
+	}
+	for _, validationError := range openAPI.Validate(context.Background()) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", validationError)
+	}
+	documents := map[string]interface{}{"openapi": openAPI}
+	if *swagger2 {
+		swagger, warnings := convert.Downconvert(openAPI)
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "warning: swagger 2.0: %s\n", warning)
+		}
+		documents["swagger"] = swagger
+	}
+	if err := os.MkdirAll(*output, 0755); err != nil {
+		return err
+	}
+	for _, format := range splitFlag(*formats) {
+		for name, document := range documents {
+			var data []byte
+			switch format {
+			case "json":
+				data, err = json.MarshalIndent(document, "", "  ")
+			case "yaml":
+				data, err = yaml.Marshal(document)
+			default:
+				return fmt.Errorf("unknown format %q", format)
+			}
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(*output, name+"."+format), data, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// splitFlag splits a comma separated flag value.
+func splitFlag(value string) []string {
+	var values []string
+	for _, element := range strings.Split(value, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			values = append(values, element)
+		}
+	}
+	return values
+}
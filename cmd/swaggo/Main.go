@@ -0,0 +1,58 @@
+// Command swaggo generates and converts OpenAPI documents.
+//
+// Usage:
+//
+//	swaggo <command> [flags]
+//
+// The commands are:
+//
+//	init    generate an OpenAPI document from the annotations of Go sources
+//	gen     generate Go code from an OpenAPI document: swaggo gen server|client|models
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command runs a subcommand with the arguments following its name.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"init": {"generate an OpenAPI document from the annotations of Go sources", runInit},
+	"gen":  {"generate Go code from an OpenAPI document", runGen},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "swaggo: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err := command.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "swaggo %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: swaggo <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-8s%s\n", name, commands[name].summary)
+	}
+}
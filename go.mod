@@ -6,4 +6,5 @@ require (
 	github.com/grpc-ecosystem/grpc-gateway v1.15.2 // indirect
 	google.golang.org/genproto v0.0.0-20201022181438-0ff5f38871d5 // indirect
 	google.golang.org/grpc/security/advancedtls v0.0.0-20201022203757-eb7fc22e4562 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,161 @@
+package annotation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// general is the API information which has no place of its own in an OpenAPI 3 document.
+type general struct {
+	host     string
+	basePath string
+	schemes  []string
+}
+
+// parseGeneral reads the general API information of a comment. Lines following a @securityDefinitions
+// annotation, such as @in, @name, @tokenUrl or @scope.read, configure that security scheme.
+func (parser *parser) parseGeneral(lines []line) error {
+	openAPI := parser.openAPI
+	var scheme *v303.SecurityScheme
+	var flow *v303.OAuthFlow
+	var tag *v303.Tag
+	for _, line := range lines {
+		attribute, value := line.attribute, line.value
+		if strings.HasPrefix(attribute, "@securitydefinitions.") {
+			if value == "" {
+				return fmt.Errorf("%s requires a name", attribute)
+			}
+			var err error
+			if scheme, flow, err = securityScheme(strings.TrimPrefix(attribute, "@securitydefinitions.")); err != nil {
+				return err
+			}
+			if openAPI.Components == nil {
+				openAPI.Components = &v303.Components{}
+			}
+			if openAPI.Components.SecuritySchemes == nil {
+				openAPI.Components.SecuritySchemes = map[string]*v303.SecuritySchemeRef{}
+			}
+			openAPI.Components.SecuritySchemes[value] = &v303.SecuritySchemeRef{Value: scheme}
+			continue
+		}
+		if scheme != nil {
+			switch {
+			case attribute == "@in":
+				scheme.In = value
+				continue
+			case attribute == "@name":
+				scheme.Name = value
+				continue
+			case attribute == "@description":
+				scheme.Description = value
+				continue
+			case attribute == "@bearerformat":
+				scheme.BearerFormat = value
+				continue
+			case attribute == "@tokenurl" && flow != nil:
+				flow.TokenURL = value
+				continue
+			case attribute == "@authorizationurl" && flow != nil:
+				flow.AuthorizationURL = value
+				continue
+			case attribute == "@refreshurl" && flow != nil:
+				flow.RefreshURL = value
+				continue
+			case strings.HasPrefix(attribute, "@scope.") && flow != nil:
+				flow.Scopes[line.name[len("@scope."):]] = value
+				continue
+			}
+		}
+		switch attribute {
+		case "@title":
+			openAPI.Info.Title = value
+		case "@version":
+			openAPI.Info.Version = value
+		case "@description":
+			if tag != nil {
+				tag.Description = value
+			} else if openAPI.Info.Description == "" {
+				openAPI.Info.Description = value
+			} else {
+				openAPI.Info.Description += "\n" + value
+			}
+		case "@termsofservice":
+			openAPI.Info.TermsOfService = value
+		case "@contact.name", "@contact.url", "@contact.email":
+			if openAPI.Info.Contact == nil {
+				openAPI.Info.Contact = &v303.Contact{}
+			}
+			switch attribute {
+			case "@contact.name":
+				openAPI.Info.Contact.Name = value
+			case "@contact.url":
+				openAPI.Info.Contact.Url = value
+			default:
+				openAPI.Info.Contact.Email = value
+			}
+		case "@license.name", "@license.url":
+			if openAPI.Info.License == nil {
+				openAPI.Info.License = &v303.License{}
+			}
+			if attribute == "@license.name" {
+				openAPI.Info.License.Name = value
+			} else {
+				openAPI.Info.License.Url = value
+			}
+		case "@host":
+			parser.general.host = value
+		case "@basepath":
+			parser.general.basePath = value
+		case "@schemes":
+			parser.general.schemes = splitList(value)
+		case "@tag.name":
+			tag = &v303.Tag{Name: value}
+			openAPI.Tags = append(openAPI.Tags, tag)
+		case "@tag.description":
+			if tag == nil {
+				return fmt.Errorf("@tag.description must follow a @tag.name")
+			}
+			tag.Description = value
+		case "@externaldocs.url", "@externaldocs.description":
+			if openAPI.ExternalDocs == nil {
+				openAPI.ExternalDocs = &v303.ExternalDocumentation{}
+			}
+			if attribute == "@externaldocs.url" {
+				openAPI.ExternalDocs.URL = value
+			} else {
+				openAPI.ExternalDocs.Description = value
+			}
+		}
+	}
+	return nil
+}
+
+// securityScheme returns the security scheme of a @securityDefinitions kind, such as apikey or oauth2.password,
+// along with its OAuth2 flow.
+func securityScheme(kind string) (*v303.SecurityScheme, *v303.OAuthFlow, error) {
+	switch kind {
+	case "basic":
+		return &v303.SecurityScheme{Type: "http", Scheme: "basic"}, nil, nil
+	case "bearer":
+		return &v303.SecurityScheme{Type: "http", Scheme: "bearer"}, nil, nil
+	case "apikey":
+		return &v303.SecurityScheme{Type: "apiKey"}, nil, nil
+	}
+	flow := &v303.OAuthFlow{Scopes: map[string]string{}}
+	flows := &v303.OAuthFlows{}
+	switch kind {
+	case "oauth2.implicit":
+		flows.Implicit = flow
+	case "oauth2.password":
+		flows.Password = flow
+	case "oauth2.application":
+		flows.ClientCredentials = flow
+	case "oauth2.accesscode":
+		flows.AuthorizationCode = flow
+	default:
+		return nil, nil, fmt.Errorf("unknown security definition %q", kind)
+	}
+	return &v303.SecurityScheme{Type: "oauth2", Flows: flows}, flow, nil
+}
@@ -0,0 +1,552 @@
+package annotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// mediaTypeAliases are the short names of media types accepted by @Accept and @Produce.
+var mediaTypeAliases = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"json-api":              "application/vnd.api+json",
+	"json-stream":           "application/x-json-stream",
+	"octet-stream":          "application/octet-stream",
+	"png":                   "image/png",
+	"jpeg":                  "image/jpeg",
+	"gif":                   "image/gif",
+	"event-stream":          "text/event-stream",
+}
+
+// defaultMediaType is the media type of request and response bodies when @Accept or @Produce is missing.
+const defaultMediaType = "application/json"
+
+var (
+	// routePattern matches the value of @Router, such as "/pets/{id} [get]".
+	routePattern = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+	// attributePattern matches the attributes following the description of @Param, such as "default(10)".
+	attributePattern = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+	// colonParameter matches the chi and gin style path parameters, such as ":id".
+	colonParameter = regexp.MustCompile(`/:(\w+)`)
+)
+
+// operationParser builds the operation of a comment.
+type operationParser struct {
+	*parser
+	file      *sourceFile
+	operation *v303.Operation
+	accept    []string
+	produce   []string
+	// form is the object schema of the formData parameters, hasFile whether one of them is a file.
+	form    *v303.Schema
+	hasFile bool
+}
+
+// parseOperation reads the operation of a comment and adds it to the paths of its @Router annotations.
+func (parser *parser) parseOperation(lines []line, file *sourceFile) error {
+	operationParser := &operationParser{
+		parser:    parser,
+		file:      file,
+		operation: &v303.Operation{Responses: map[string]*v303.ResponseRef{}},
+	}
+	operation := operationParser.operation
+	var parameters, responses, headers, routes []line
+	var security []v303.SecurityRequirement
+	for _, line := range lines {
+		var err error
+		switch line.attribute {
+		case "@summary":
+			operation.Summary = line.value
+		case "@description":
+			if operation.Description != "" {
+				operation.Description += "\n"
+			}
+			operation.Description += line.value
+		case "@id":
+			operation.OperationID = line.value
+		case "@tags":
+			operation.Tags = append(operation.Tags, splitList(line.value)...)
+		case "@accept":
+			operationParser.accept, err = mediaTypes(line.value)
+		case "@produce":
+			operationParser.produce, err = mediaTypes(line.value)
+		case "@param":
+			parameters = append(parameters, line)
+		case "@success", "@failure", "@response":
+			responses = append(responses, line)
+		case "@header":
+			headers = append(headers, line)
+		case "@router":
+			routes = append(routes, line)
+		case "@security":
+			security = append(security, securityRequirements(line.value)...)
+		case "@deprecated":
+			operation.Deprecated = true
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", line.name, err)
+		}
+	}
+	if operationParser.accept == nil {
+		operationParser.accept = []string{defaultMediaType}
+	}
+	if operationParser.produce == nil {
+		operationParser.produce = []string{defaultMediaType}
+	}
+	if security != nil {
+		operation.Security = &security
+	}
+	for _, line := range parameters {
+		if err := operationParser.parameter(line.value); err != nil {
+			return fmt.Errorf("@Param %s: %w", line.value, err)
+		}
+	}
+	operationParser.formBody()
+	for _, line := range responses {
+		if err := operationParser.response(line.value); err != nil {
+			return fmt.Errorf("%s %s: %w", line.name, line.value, err)
+		}
+	}
+	for _, line := range headers {
+		if err := operationParser.header(line.value); err != nil {
+			return fmt.Errorf("@Header %s: %w", line.value, err)
+		}
+	}
+	for _, line := range routes {
+		if err := parser.route(line.value, operation); err != nil {
+			return fmt.Errorf("@Router %s: %w", line.value, err)
+		}
+	}
+	return nil
+}
+
+// mediaTypes returns the media types of the value of @Accept or @Produce.
+func mediaTypes(value string) ([]string, error) {
+	var mediaTypes []string
+	for _, name := range splitList(value) {
+		if alias, ok := mediaTypeAliases[name]; ok {
+			name = alias
+		} else if !strings.Contains(name, "/") {
+			return nil, fmt.Errorf("unknown media type %q", name)
+		}
+		mediaTypes = append(mediaTypes, name)
+	}
+	return mediaTypes, nil
+}
+
+// route adds operation to the path item of a @Router value.
+func (parser *parser) route(value string, operation *v303.Operation) error {
+	match := routePattern.FindStringSubmatch(value)
+	if match == nil {
+		return fmt.Errorf("expected a path followed by a method, such as /pets/{id} [get]")
+	}
+	path := colonParameter.ReplaceAllString(match[1], "/{$1}")
+	pathItem := parser.openAPI.Paths[path]
+	if pathItem == nil {
+		pathItem = &v303.PathItem{}
+		parser.openAPI.Paths[path] = pathItem
+	}
+	var field **v303.Operation
+	switch strings.ToLower(match[2]) {
+	case "get":
+		field = &pathItem.Get
+	case "put":
+		field = &pathItem.Put
+	case "post":
+		field = &pathItem.Post
+	case "delete":
+		field = &pathItem.Delete
+	case "options":
+		field = &pathItem.Options
+	case "head":
+		field = &pathItem.Head
+	case "patch":
+		field = &pathItem.Patch
+	case "trace":
+		field = &pathItem.Trace
+	default:
+		return fmt.Errorf("unknown method %q", match[2])
+	}
+	if *field != nil {
+		return fmt.Errorf("%s %s is already documented", strings.ToUpper(match[2]), path)
+	}
+	*field = operation
+	return nil
+}
+
+// securityRequirements returns the requirements of a @Security value, alternatives being separated by || and
+// schemes required together by &&, such as "OAuth2[read, write] && ApiKey || Basic".
+func securityRequirements(value string) []v303.SecurityRequirement {
+	var requirements []v303.SecurityRequirement
+	for _, alternative := range strings.Split(value, "||") {
+		requirement := v303.SecurityRequirement{}
+		for _, scheme := range strings.Split(alternative, "&&") {
+			scheme = strings.TrimSpace(scheme)
+			scopes := []string{}
+			if open := strings.Index(scheme, "["); open >= 0 {
+				scopes = append(scopes, splitList(strings.TrimSuffix(scheme[open+1:], "]"))...)
+				scheme = strings.TrimSpace(scheme[:open])
+			}
+			if scheme != "" {
+				requirement[scheme] = scopes
+			}
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements
+}
+
+// nextToken splits value at its first space.
+func nextToken(value string) (string, string) {
+	value = strings.TrimSpace(value)
+	if space := strings.IndexAny(value, " \t"); space >= 0 {
+		return value[:space], strings.TrimSpace(value[space+1:])
+	}
+	return value, ""
+}
+
+// quoted returns the double quoted text at the beginning of value and what follows it.
+func quoted(value string) (string, string) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, `"`) {
+		return "", value
+	}
+	end := strings.Index(value[1:], `"`)
+	if end < 0 {
+		return value[1:], ""
+	}
+	return value[1 : end+1], strings.TrimSpace(value[end+2:])
+}
+
+// parameter adds the parameter of a @Param value, "name in type required description attributes...", such as
+// `limit query int false "page size" default(10) maximum(100)`. Body and formData parameters make the request body.
+func (operationParser *operationParser) parameter(value string) error {
+	name, value := nextToken(value)
+	in, value := nextToken(value)
+	dataType, value := nextToken(value)
+	requiredToken, value := nextToken(value)
+	if requiredToken == "" {
+		return fmt.Errorf("expected a name, a location, a type and whether the parameter is required")
+	}
+	required, err := strconv.ParseBool(requiredToken)
+	if err != nil {
+		return fmt.Errorf("required must be true or false, not %q", requiredToken)
+	}
+	description, value := quoted(value)
+	attributes := map[string]string{}
+	for _, match := range attributePattern.FindAllStringSubmatch(value, -1) {
+		attributes[strings.ToLower(match[1])] = match[2]
+	}
+	schema, err := operationParser.typeSchema(dataType, operationParser.file)
+	if err != nil {
+		return err
+	}
+	operation := operationParser.operation
+	switch in {
+	case "path", "query", "header", "cookie":
+		parameter := &v303.Parameter{Name: name, In: in, Description: description, Required: required || in == "path"}
+		if example, ok := attributes["example"]; ok {
+			if parameter.Example, err = exampleValue(schema, example); err != nil {
+				return err
+			}
+		}
+		if collectionFormat, ok := attributes["collectionformat"]; ok {
+			if parameter.Style, parameter.Explode, err = style(in, collectionFormat); err != nil {
+				return err
+			}
+		}
+		if parameter.Schema, err = applyAttributes(schema, attributes); err != nil {
+			return err
+		}
+		operation.Parameters = append(operation.Parameters, &v303.ParameterRef{Value: parameter})
+	case "body":
+		if operation.RequestBody != nil || operationParser.form != nil {
+			return fmt.Errorf("an operation has a single body parameter and no formData parameters alongside it")
+		}
+		content := map[string]*v303.MediaType{}
+		for _, mediaType := range operationParser.accept {
+			content[mediaType] = &v303.MediaType{Schema: schema}
+		}
+		operation.RequestBody = &v303.RequestBodyRef{Value: &v303.RequestBody{Description: description, Content: content, Required: required}}
+	case "formData":
+		if operation.RequestBody != nil {
+			return fmt.Errorf("formData parameters cannot be used alongside a body parameter")
+		}
+		if operationParser.form == nil {
+			operationParser.form = &v303.Schema{Type: "object", Properties: map[string]*v303.SchemaRef{}}
+		}
+		if schema, err = applyAttributes(schema, attributes); err != nil {
+			return err
+		}
+		if schema.Value != nil {
+			schema.Value.Description = description
+			operationParser.hasFile = operationParser.hasFile || schema.Value.Format == "binary"
+		}
+		operationParser.form.Properties[name] = schema
+		if required {
+			operationParser.form.Required = append(operationParser.form.Required, name)
+		}
+	default:
+		return fmt.Errorf("unknown parameter location %q", in)
+	}
+	return nil
+}
+
+// formBody sets the request body of the formData parameters, multipart when one of them is a file or when the
+// operation accepts it, URL encoded otherwise.
+func (operationParser *operationParser) formBody() {
+	if operationParser.form == nil {
+		return
+	}
+	mediaType := "application/x-www-form-urlencoded"
+	for _, accepted := range operationParser.accept {
+		if accepted == "multipart/form-data" {
+			mediaType = accepted
+		}
+	}
+	if operationParser.hasFile {
+		mediaType = "multipart/form-data"
+	}
+	operationParser.operation.RequestBody = &v303.RequestBodyRef{Value: &v303.RequestBody{
+		Content:  map[string]*v303.MediaType{mediaType: {Schema: &v303.SchemaRef{Value: operationParser.form}}},
+		Required: len(operationParser.form.Required) > 0,
+	}}
+}
+
+// style returns the style and explode of a Swagger 2.0 collectionFormat.
+func style(in, collectionFormat string) (string, *bool, error) {
+	explode := false
+	switch collectionFormat {
+	case "csv":
+		if in == "query" || in == "cookie" {
+			return "form", &explode, nil
+		}
+		return "simple", nil, nil
+	case "multi":
+		if in != "query" {
+			return "", nil, fmt.Errorf("collectionFormat multi is only allowed on query parameters")
+		}
+		explode = true
+		return "form", &explode, nil
+	case "ssv":
+		if in == "query" {
+			return "spaceDelimited", &explode, nil
+		}
+	case "pipes":
+		if in == "query" {
+			return "pipeDelimited", &explode, nil
+		}
+	}
+	return "", nil, fmt.Errorf("collectionFormat %s has no equivalent for %s parameters", collectionFormat, in)
+}
+
+// exampleValue returns the JSON value of the example attribute of a @Param of schema, the items of arrays being
+// separated by commas.
+func exampleValue(schema *v303.SchemaRef, example string) (json.RawMessage, error) {
+	if schema.Value == nil {
+		return json.Marshal(example)
+	}
+	if schema.Value.Type != "array" || schema.Value.Items == nil || schema.Value.Items.Value == nil {
+		return convertValue(example, schema.Value.Type)
+	}
+	items := []json.RawMessage{}
+	for _, item := range strings.Split(example, ",") {
+		converted, err := convertValue(strings.TrimSpace(item), schema.Value.Items.Value.Type)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, converted)
+	}
+	return json.Marshal(items)
+}
+
+// applyAttributes returns a copy of schema constrained by the attributes of a @Param. The constraints of an array
+// apply to its items.
+func applyAttributes(schema *v303.SchemaRef, attributes map[string]string) (*v303.SchemaRef, error) {
+	constrained := false
+	for attribute := range attributes {
+		constrained = constrained || attribute != "collectionformat" && attribute != "example"
+	}
+	if !constrained {
+		return schema, nil
+	}
+	if schema.Value == nil {
+		return nil, fmt.Errorf("attributes cannot constrain the referenced schema %s", schema.Ref)
+	}
+	copied := *schema.Value
+	target := &copied
+	if copied.Type == "array" && copied.Items != nil && copied.Items.Value != nil {
+		items := *copied.Items.Value
+		copied.Items = &v303.SchemaRef{Value: &items}
+		target = &items
+	}
+	for attribute, value := range attributes {
+		var err error
+		switch attribute {
+		case "default":
+			if target.Default, err = convertValue(value, target.Type); err != nil {
+				return nil, err
+			}
+		case "format":
+			target.Format = value
+		case "enums":
+			for _, enum := range strings.Split(value, ",") {
+				converted, err := convertValue(strings.TrimSpace(enum), target.Type)
+				if err != nil {
+					return nil, err
+				}
+				target.Enum = append(target.Enum, converted)
+			}
+		case "minimum", "maximum":
+			var number json.Number
+			if err := json.Unmarshal([]byte(value), &number); err != nil || strings.HasPrefix(value, `"`) {
+				return nil, fmt.Errorf("%s(%s) must be a number", attribute, value)
+			}
+			if attribute == "minimum" {
+				target.Minimum = number
+			} else {
+				target.Maximum = number
+			}
+		case "minlength", "maxlength":
+			var length int
+			length, err = strconv.Atoi(value)
+			if attribute == "minlength" {
+				target.MinLength = &length
+			} else {
+				target.MaxLength = &length
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s(%s) must be an integer", attribute, value)
+		}
+	}
+	return &v303.SchemaRef{Value: &copied}, nil
+}
+
+// response adds the responses of a @Success, @Failure or @Response value, "codes {kind} type description", such
+// as `200 {array} model.Pet "the pets"`. Codes are separated by commas and the kind is object, array or a primitive.
+func (operationParser *operationParser) response(value string) error {
+	codes, value := nextToken(value)
+	var schema *v303.SchemaRef
+	if strings.HasPrefix(value, "{") {
+		end := strings.Index(value, "}")
+		if end < 0 {
+			return fmt.Errorf("unterminated kind")
+		}
+		kind, dataType := value[1:end], ""
+		value = strings.TrimSpace(value[end+1:])
+		if !strings.HasPrefix(value, `"`) {
+			dataType, value = nextToken(value)
+		}
+		var err error
+		if schema, err = operationParser.responseSchema(kind, dataType); err != nil {
+			return err
+		}
+	}
+	description, _ := quoted(value)
+	for _, code := range strings.Split(codes, ",") {
+		status, err := strconv.Atoi(code)
+		if code != "default" && (err != nil || status < 100 || status > 599) {
+			return fmt.Errorf("%q is not a status code", code)
+		}
+		if _, ok := operationParser.operation.Responses[code]; ok {
+			return fmt.Errorf("response %s is already documented", code)
+		}
+		response := &v303.Response{Description: description}
+		if response.Description == "" {
+			response.Description = http.StatusText(status)
+		}
+		if response.Description == "" {
+			response.Description = "Default response"
+		}
+		if schema != nil {
+			response.Content = map[string]*v303.MediaType{}
+			for _, mediaType := range operationParser.produce {
+				response.Content[mediaType] = &v303.MediaType{Schema: schema}
+			}
+		}
+		operationParser.operation.Responses[code] = &v303.ResponseRef{Value: response}
+	}
+	return nil
+}
+
+// responseSchema returns the schema of the kind and type of a response.
+func (operationParser *operationParser) responseSchema(kind, dataType string) (*v303.SchemaRef, error) {
+	switch kind {
+	case "object":
+		if dataType == "" {
+			dataType = "object"
+		}
+		return operationParser.typeSchema(dataType, operationParser.file)
+	case "array":
+		if dataType == "" {
+			return nil, fmt.Errorf("{array} requires the type of its items")
+		}
+		items, err := operationParser.typeSchema(dataType, operationParser.file)
+		if err != nil {
+			return nil, err
+		}
+		return &v303.SchemaRef{Value: &v303.Schema{Type: "array", Items: items}}, nil
+	case "string", "integer", "number", "boolean", "file":
+		return operationParser.typeSchema(kind, operationParser.file)
+	}
+	return nil, fmt.Errorf("unknown kind {%s}", kind)
+}
+
+// header adds the header of a @Header value, "codes {type} name description", to the responses with these codes,
+// or to every response when codes is "all".
+func (operationParser *operationParser) header(value string) error {
+	codes, value := nextToken(value)
+	kind, value := nextToken(value)
+	name, value := nextToken(value)
+	description, _ := quoted(value)
+	if !strings.HasPrefix(kind, "{") || !strings.HasSuffix(kind, "}") || name == "" {
+		return fmt.Errorf("expected response codes, a {type} and a name")
+	}
+	schema, err := operationParser.typeSchema(kind[1:len(kind)-1], operationParser.file)
+	if err != nil {
+		return err
+	}
+	responses := operationParser.operation.Responses
+	var targets []*v303.Response
+	if codes == "all" {
+		for _, code := range sortedCodes(responses) {
+			targets = append(targets, responses[code].Value)
+		}
+	} else {
+		for _, code := range strings.Split(codes, ",") {
+			response, ok := responses[code]
+			if !ok {
+				return fmt.Errorf("response %s is not documented", code)
+			}
+			targets = append(targets, response.Value)
+		}
+	}
+	for _, response := range targets {
+		if response.Headers == nil {
+			response.Headers = map[string]*v303.HeaderRef{}
+		}
+		response.Headers[name] = &v303.HeaderRef{Value: &v303.Header{Description: description, Schema: schema}}
+	}
+	return nil
+}
+
+func sortedCodes(responses map[string]*v303.ResponseRef) []string {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
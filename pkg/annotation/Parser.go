@@ -0,0 +1,255 @@
+// Package annotation builds OpenAPI documents from the comments of Go sources, in the style of swag.
+// The general API information is declared in any comment, usually the one of the main function:
+//
+//	// @title Pets API
+//	// @version 1.0
+//	// @host petstore.example.com
+//	// @BasePath /v1
+//	// @securityDefinitions.apikey ApiKeyAuth
+//	// @in header
+//	// @name Authorization
+//
+// and every handler documents its operation in its doc comment:
+//
+//	// @Summary Get a pet
+//	// @ID getPet
+//	// @Tags pets
+//	// @Param id path int true "Pet ID"
+//	// @Success 200 {object} model.Pet
+//	// @Failure 404 {object} model.Error "not found"
+//	// @Security ApiKeyAuth
+//	// @Router /pets/{id} [get]
+//
+// Go types named in the annotations are looked up in the parsed packages and registered as components of the
+// document.
+package annotation
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.0.3"
+
+// Options configures Parse.
+type Options struct {
+	// Dirs are the directories searched recursively for Go packages, the current directory when empty.
+	Dirs []string
+	// Exclude are directories skipped while searching.
+	Exclude []string
+}
+
+// Parse reads the annotations of the Go packages found in the directories of options and returns the document
+// they describe. Test files, vendor and testdata directories and directories starting with '.' or '_' are skipped.
+func Parse(options Options) (*v303.OpenAPI, error) {
+	parser := &parser{
+		fileSet: token.NewFileSet(),
+		types:   map[string]map[string]*typeSpec{},
+		owners:  map[string]string{},
+		openAPI: &v303.OpenAPI{OpenAPI: Version, Info: &v303.Info{}, Paths: map[string]*v303.PathItem{}},
+	}
+	dirs := options.Dirs
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	excluded := map[string]bool{}
+	for _, dir := range options.Exclude {
+		if abs, err := filepath.Abs(dir); err == nil {
+			excluded[abs] = true
+		}
+	}
+	for _, dir := range dirs {
+		if err := parser.walk(dir, excluded); err != nil {
+			return nil, err
+		}
+	}
+	if err := parser.parseComments(); err != nil {
+		return nil, err
+	}
+	parser.openAPI.Servers = servers(parser.general.host, parser.general.basePath, parser.general.schemes)
+	return parser.openAPI, nil
+}
+
+type parser struct {
+	fileSet *token.FileSet
+	files   []*sourceFile
+	// types are the type declarations keyed by absolute directory then by name.
+	types map[string]map[string]*typeSpec
+	// owners are the keys of the types registered as schema components, keyed by component name.
+	owners  map[string]string
+	general general
+	openAPI *v303.OpenAPI
+}
+
+// sourceFile is a parsed Go file along with its package.
+type sourceFile struct {
+	file    *ast.File
+	dir     string
+	pkgName string
+}
+
+// typeSpec is a type declaration along with the file declaring it.
+type typeSpec struct {
+	spec *ast.TypeSpec
+	doc  *ast.CommentGroup
+	file *sourceFile
+}
+
+// walk parses the packages of dir and of its subdirectories.
+func (parser *parser) walk(root string, excluded map[string]bool) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if path != root && (excluded[path] || name == "vendor" || name == "testdata" ||
+			strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return filepath.SkipDir
+		}
+		return parser.parseDir(path)
+	})
+}
+
+func (parser *parser) parseDir(dir string) error {
+	if _, ok := parser.types[dir]; ok {
+		return nil
+	}
+	packages, err := goparser.ParseDir(parser.fileSet, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, goparser.ParseComments)
+	if err != nil {
+		return err
+	}
+	types := map[string]*typeSpec{}
+	parser.types[dir] = types
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fileNames := make([]string, 0, len(packages[name].Files))
+		for fileName := range packages[name].Files {
+			fileNames = append(fileNames, fileName)
+		}
+		sort.Strings(fileNames)
+		for _, fileName := range fileNames {
+			file := &sourceFile{file: packages[name].Files[fileName], dir: dir, pkgName: name}
+			parser.files = append(parser.files, file)
+			for _, decl := range file.file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					doc := spec.Doc
+					if doc == nil && len(genDecl.Specs) == 1 {
+						doc = genDecl.Doc
+					}
+					types[spec.Name.Name] = &typeSpec{spec: spec, doc: doc, file: file}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseComments reads the annotations of every comment, a comment with a @Router annotation declaring an
+// operation and any other one possibly declaring general API information.
+func (parser *parser) parseComments() error {
+	for _, file := range parser.files {
+		for _, group := range file.file.Comments {
+			lines := annotations(group)
+			if len(lines) == 0 {
+				continue
+			}
+			var err error
+			if hasAttribute(lines, "@router") {
+				err = parser.parseOperation(lines, file)
+			} else {
+				err = parser.parseGeneral(lines)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", parser.fileSet.Position(group.Pos()), err)
+			}
+		}
+	}
+	return nil
+}
+
+// line is an annotation, such as "@Param id path int true", split into its attribute and its value.
+type line struct {
+	// name is the attribute as written, attribute is in lower case since attributes are case insensitive.
+	name      string
+	attribute string
+	value     string
+}
+
+// annotations returns the annotations of a comment.
+func annotations(group *ast.CommentGroup) []line {
+	var lines []line
+	for _, text := range strings.Split(group.Text(), "\n") {
+		text = strings.TrimSpace(text)
+		if !strings.HasPrefix(text, "@") {
+			continue
+		}
+		fields := strings.SplitN(text, " ", 2)
+		value := ""
+		if len(fields) == 2 {
+			value = strings.TrimSpace(fields[1])
+		}
+		lines = append(lines, line{name: fields[0], attribute: strings.ToLower(fields[0]), value: value})
+	}
+	return lines
+}
+
+func hasAttribute(lines []line, attribute string) bool {
+	for _, line := range lines {
+		if line.attribute == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// servers returns the servers of a host, base path and schemes, as the Swagger 2.0 converter does.
+func servers(host, basePath string, schemes []string) []*v303.Server {
+	if host == "" {
+		if basePath == "" {
+			return nil
+		}
+		return []*v303.Server{{Url: basePath}}
+	}
+	if len(schemes) == 0 {
+		return []*v303.Server{{Url: "//" + host + basePath}}
+	}
+	servers := make([]*v303.Server, len(schemes))
+	for i, scheme := range schemes {
+		servers[i] = &v303.Server{Url: scheme + "://" + host + basePath}
+	}
+	return servers
+}
+
+// splitList splits a list separated by commas or spaces, such as the value of @Tags or @Accept.
+func splitList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
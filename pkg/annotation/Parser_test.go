@@ -0,0 +1,39 @@
+package annotation
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the tests")
+
+// TestParseGolden parses the fixture package testdata/petstore and compares the document with
+// testdata/petstore.golden.json, which go test -update rewrites.
+func TestParseGolden(t *testing.T) {
+	openAPI, err := Parse(Options{Dirs: []string{filepath.Join("testdata", "petstore")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.MarshalIndent(openAPI, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+	golden := filepath.Join("testdata", "petstore.golden.json")
+	if *update {
+		if err := ioutil.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("the document differs from %s, run go test -update to accept it:\n%s", golden, got)
+	}
+}
@@ -0,0 +1,393 @@
+package annotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/schema"
+)
+
+// primitives are the schemas of the predeclared Go types and of the type names of the annotations.
+var primitives = map[string]v303.Schema{
+	"string":  {Type: "string"},
+	"bool":    {Type: "boolean"},
+	"boolean": {Type: "boolean"},
+	"int":     {Type: "integer"},
+	"int8":    {Type: "integer", Format: "int32"},
+	"int16":   {Type: "integer", Format: "int32"},
+	"int32":   {Type: "integer", Format: "int32"},
+	"int64":   {Type: "integer", Format: "int64"},
+	"uint":    {Type: "integer"},
+	"uint8":   {Type: "integer", Format: "int32"},
+	"uint16":  {Type: "integer", Format: "int32"},
+	"uint32":  {Type: "integer", Format: "int64"},
+	"uint64":  {Type: "integer", Format: "int64"},
+	"byte":    {Type: "integer", Format: "int32"},
+	"rune":    {Type: "integer", Format: "int32"},
+	"integer": {Type: "integer"},
+	"float32": {Type: "number", Format: "float"},
+	"float64": {Type: "number", Format: "double"},
+	"number":  {Type: "number"},
+	"error":   {Type: "string"},
+	"object":  {Type: "object"},
+	"file":    {Type: "string", Format: "binary"},
+}
+
+// wellKnown are the schemas of the types of the standard library, keyed by import path and name.
+var wellKnown = map[string]v303.Schema{
+	"time.Time":                   {Type: "string", Format: "date-time"},
+	"time.Duration":               {Type: "integer", Format: "int64"},
+	"encoding/json.RawMessage":    {},
+	"encoding/json.Number":        {Type: "number"},
+	"net/url.URL":                 {Type: "string", Format: "uri"},
+	"mime/multipart.FileHeader":   {Type: "string", Format: "binary"},
+	"database/sql.NullString":     {Type: "string", Nullable: true},
+	"database/sql.NullBool":       {Type: "boolean", Nullable: true},
+	"database/sql.NullInt64":      {Type: "integer", Format: "int64", Nullable: true},
+	"database/sql.NullInt32":      {Type: "integer", Format: "int32", Nullable: true},
+	"database/sql.NullFloat64":    {Type: "number", Format: "double", Nullable: true},
+	"database/sql.NullTime":       {Type: "string", Format: "date-time", Nullable: true},
+	"github.com/google/uuid.UUID": {Type: "string", Format: "uuid"},
+}
+
+// typeSchema returns the schema of a type written in an annotation, either one of the primitives or a Go type
+// expression such as model.Pet or []string, resolved in file.
+func (parser *parser) typeSchema(expression string, file *sourceFile) (*v303.SchemaRef, error) {
+	if primitive, ok := primitives[expression]; ok {
+		return &v303.SchemaRef{Value: &primitive}, nil
+	}
+	expr, err := goparser.ParseExpr(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q", expression)
+	}
+	return parser.schema(expr, file)
+}
+
+// schema returns the schema of a Go type expression of file. Named types are registered as components and
+// referred to.
+func (parser *parser) schema(expr ast.Expr, file *sourceFile) (*v303.SchemaRef, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if primitive, ok := primitives[expr.Name]; ok && !parser.declares(file.dir, expr.Name) {
+			return &v303.SchemaRef{Value: &primitive}, nil
+		}
+		return parser.component(file.dir, file.pkgName, expr.Name)
+	case *ast.ParenExpr:
+		return parser.schema(expr.X, file)
+	case *ast.StarExpr:
+		return parser.schema(expr.X, file)
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &v303.SchemaRef{Value: &v303.Schema{Type: "string", Format: "byte"}}, nil
+		}
+		items, err := parser.schema(expr.Elt, file)
+		if err != nil {
+			return nil, err
+		}
+		return &v303.SchemaRef{Value: &v303.Schema{Type: "array", Items: items}}, nil
+	case *ast.MapType:
+		values, err := parser.schema(expr.Value, file)
+		if err != nil {
+			return nil, err
+		}
+		return &v303.SchemaRef{Value: &v303.Schema{Type: "object", AdditionalProperties: &v303.AdditionalProperties{Allowed: true, Schema: values}}}, nil
+	case *ast.InterfaceType:
+		return &v303.SchemaRef{Value: &v303.Schema{}}, nil
+	case *ast.StructType:
+		return parser.structSchema(expr, file)
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+		}
+		importPath := importPath(file.file, pkg.Name)
+		if importPath == "" {
+			return nil, fmt.Errorf("package %s of %s is not imported", pkg.Name, exprString(expr))
+		}
+		if known, ok := wellKnown[importPath+"."+expr.Sel.Name]; ok {
+			return &v303.SchemaRef{Value: &known}, nil
+		}
+		dir := parser.packageDir(importPath)
+		if dir == "" {
+			return nil, fmt.Errorf("cannot find package %q of %s", importPath, exprString(expr))
+		}
+		return parser.component(dir, pathBase(importPath), expr.Sel.Name)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+	}
+}
+
+// declares reports whether the package of dir declares a type named name, shadowing a predeclared type.
+func (parser *parser) declares(dir, name string) bool {
+	_, ok := parser.types[dir][name]
+	return ok
+}
+
+// component registers the named type of the package in dir as a schema component and returns a reference to it.
+// The component is registered before its type is described so that recursive types refer to themselves.
+func (parser *parser) component(dir, pkgName, name string) (*v303.SchemaRef, error) {
+	spec, ok := parser.types[dir][name]
+	if !ok {
+		return nil, fmt.Errorf("cannot find type %s.%s", pkgName, name)
+	}
+	// The package name of the declaring file wins over the guess made from the import path.
+	componentName := spec.file.pkgName + "." + name
+	ref := &v303.SchemaRef{Ref: "#/components/schemas/" + componentName}
+	key := dir + "." + name
+	if owner, ok := parser.owners[componentName]; ok {
+		if owner != key {
+			return nil, fmt.Errorf("types %s and %s are both named %s", owner, key, componentName)
+		}
+		return ref, nil
+	}
+	parser.owners[componentName] = key
+	components := parser.openAPI.Components
+	if components == nil {
+		components = &v303.Components{}
+		parser.openAPI.Components = components
+	}
+	if components.Schema == nil {
+		components.Schema = map[string]*v303.SchemaRef{}
+	}
+	component := &v303.SchemaRef{}
+	components.Schema[componentName] = component
+	schema, err := parser.schema(spec.spec.Type, spec.file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", componentName, err)
+	}
+	*component = *schema
+	if component.Value != nil && spec.doc != nil {
+		// A copy keeps the primitives and well known schemas unchanged.
+		value := *component.Value
+		value.Description = strings.TrimSpace(spec.doc.Text())
+		component.Value = &value
+	}
+	return ref, nil
+}
+
+// structSchema returns the object schema of a struct. Fields are named after their json tag and are required
+// unless tagged omitempty or pointers, or when a validate or binding tag says so. Their description, format,
+// default, example and validate tags add keywords as they do for schema.FromType. Embedded structs without a json
+// name are combined with allOf.
+func (parser *parser) structSchema(structType *ast.StructType, file *sourceFile) (*v303.SchemaRef, error) {
+	object := &v303.Schema{Type: "object"}
+	var allOf []*v303.SchemaRef
+	for _, field := range structType.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			if unquoted, err := strconv.Unquote(field.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+		if tag.Get("swaggerignore") == "true" {
+			continue
+		}
+		jsonTag := strings.Split(tag.Get("json"), ",")
+		jsonName, options := jsonTag[0], jsonTag[1:]
+		if jsonName == "-" && len(options) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		if len(field.Names) == 0 {
+			if jsonName == "" {
+				embedded, err := parser.schema(field.Type, file)
+				if err != nil {
+					return nil, err
+				}
+				allOf = append(allOf, embedded)
+				continue
+			}
+			names = append(names, embeddedName(field.Type))
+		}
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+			property, err := parser.schema(field.Type, file)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			if err := applyFieldTags(property, field, tag, options); err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			_, pointer := field.Type.(*ast.StarExpr)
+			required := !hasOption(options, "omitempty") && !pointer || hasOption(strings.Split(tag.Get("binding"), ","), "required")
+			property, required = schema.ApplyTags(property, tag, required)
+			propertyName := name
+			if jsonName != "" {
+				propertyName = jsonName
+			}
+			if object.Properties == nil {
+				object.Properties = map[string]*v303.SchemaRef{}
+			}
+			object.Properties[propertyName] = property
+			if required {
+				object.Required = append(object.Required, propertyName)
+			}
+		}
+	}
+	if len(allOf) == 0 {
+		return &v303.SchemaRef{Value: object}, nil
+	}
+	if len(object.Properties) > 0 {
+		allOf = append(allOf, &v303.SchemaRef{Value: object})
+	}
+	return &v303.SchemaRef{Value: &v303.Schema{AllOf: allOf}}, nil
+}
+
+// applyFieldTags sets the description and enum of the property of a field from its comments and enums tag. A
+// reference cannot carry them and is left unchanged.
+func applyFieldTags(property *v303.SchemaRef, field *ast.Field, tag reflect.StructTag, options []string) error {
+	if property.Value == nil {
+		return nil
+	}
+	// A copy keeps the primitives and well known schemas unchanged.
+	value := *property.Value
+	property.Value = &value
+	if hasOption(options, "string") && value.Type != "" {
+		value.Type, value.Format = "string", ""
+	}
+	if field.Doc != nil {
+		value.Description = strings.TrimSpace(field.Doc.Text())
+	} else if field.Comment != nil {
+		value.Description = strings.TrimSpace(field.Comment.Text())
+	}
+	if enums := tag.Get("enums"); enums != "" {
+		for _, enum := range strings.Split(enums, ",") {
+			converted, err := convertValue(strings.TrimSpace(enum), value.Type)
+			if err != nil {
+				return err
+			}
+			value.Enum = append(value.Enum, converted)
+		}
+	}
+	return nil
+}
+
+// convertValue returns the JSON value of a value written in an annotation or a tag for a schema of schemaType,
+// numbers being kept as written.
+func convertValue(value, schemaType string) (json.RawMessage, error) {
+	switch schemaType {
+	case "integer":
+		converted, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return json.RawMessage(strconv.FormatInt(converted, 10)), nil
+	case "number":
+		var number json.Number
+		if err := json.Unmarshal([]byte(value), &number); err != nil || strings.HasPrefix(value, `"`) {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return json.RawMessage(number), nil
+	case "boolean":
+		converted, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return json.RawMessage(strconv.FormatBool(converted)), nil
+	}
+	return json.Marshal(value)
+}
+
+func hasOption(options []string, option string) bool {
+	for _, candidate := range options {
+		if strings.TrimSpace(candidate) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedName returns the field name of an embedded type, its type name.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
+
+// importPath returns the path of the package imported by file under name.
+func importPath(file *ast.File, name string) string {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return path
+			}
+			continue
+		}
+		if base := pathBase(path); base == name || strings.TrimPrefix(strings.TrimPrefix(base, "go-"), "go") == name {
+			return path
+		}
+	}
+	return ""
+}
+
+// pathBase returns the last element of an import path, skipping a major version suffix such as v2.
+func pathBase(path string) string {
+	elements := strings.Split(path, "/")
+	base := elements[len(elements)-1]
+	if len(elements) > 1 && len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = elements[len(elements)-2]
+	}
+	return base
+}
+
+// packageDir returns the parsed directory of the package with importPath, the one sharing the most trailing path
+// elements with it.
+func (parser *parser) packageDir(importPath string) string {
+	elements := strings.Split(importPath, "/")
+	best, bestLength := "", 0
+	for dir := range parser.types {
+		dirElements := strings.Split(filepath.ToSlash(dir), "/")
+		length := 0
+		for length < len(elements) && length < len(dirElements) &&
+			elements[len(elements)-1-length] == dirElements[len(dirElements)-1-length] {
+			length++
+		}
+		if length > bestLength || length == bestLength && length > 0 && dir < best {
+			best, bestLength = dir, length
+		}
+	}
+	return best
+}
+
+// exprString returns the source of a type expression for error messages.
+func exprString(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return exprString(expr.X) + "." + expr.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(expr.X)
+	case *ast.ArrayType:
+		return "[]" + exprString(expr.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(expr.Key) + "]" + exprString(expr.Value)
+	case *ast.ChanType:
+		return "chan " + exprString(expr.Value)
+	case *ast.FuncType:
+		return "func"
+	}
+	return fmt.Sprintf("%T", expr)
+}
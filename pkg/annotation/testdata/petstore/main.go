@@ -0,0 +1,53 @@
+// Command petstore is the fixture of the golden test of the annotation package.
+package main
+
+import (
+	"net/http"
+
+	"github.com/newm4n/swaggo/pkg/annotation/testdata/petstore/model"
+)
+
+// @title Pets API
+// @version 1.0
+// @description The pets of the store.
+// @license.name MIT
+// @host petstore.example.com
+// @BasePath /v1
+// @schemes https
+// @tag.name pets
+// @tag.description Everything about the pets.
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+func main() {
+	http.HandleFunc("/v1/pets", listPets)
+	_ = http.ListenAndServe(":8080", nil)
+}
+
+// listPets lists the pets.
+// @Summary List the pets
+// @ID listPets
+// @Tags pets
+// @Produce json
+// @Param limit query int false "The number of pets" minimum(1) maximum(100) default(20)
+// @Param tag query []string false "The tags of the pets" collectionFormat(multi)
+// @Success 200 {array} model.Pet
+// @Header 200 {integer} X-Total "The number of pets"
+// @Failure 400 {object} model.Error "Invalid parameters"
+// @Router /pets [get]
+func listPets(w http.ResponseWriter, r *http.Request) {}
+
+// addPet adds a pet.
+// @Summary Add a pet
+// @ID addPet
+// @Tags pets
+// @Accept json
+// @Produce json
+// @Param pet body model.NewPet true "The pet"
+// @Success 201 {object} model.Pet
+// @Failure default {object} model.Error
+// @Security ApiKeyAuth
+// @Router /pets [post]
+func addPet(w http.ResponseWriter, r *http.Request) {
+	var _ model.Pet
+}
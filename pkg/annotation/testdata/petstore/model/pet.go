@@ -0,0 +1,38 @@
+// Package model holds the types of the pets API.
+package model
+
+import "time"
+
+// Pet is a pet of the store.
+type Pet struct {
+	NewPet
+	// ID identifies the pet.
+	ID int64 `json:"id" example:"42"`
+	// Born is when the pet was born.
+	Born *time.Time `json:"born,omitempty"`
+}
+
+// NewPet is a pet to add to the store.
+type NewPet struct {
+	// Name is the name of the pet.
+	Name string `json:"name" validate:"required,min=1,max=20" example:"rex"`
+	// Kind is the kind of pet.
+	Kind string `json:"kind" validate:"oneof=cat dog" default:"dog"`
+	// Age is the age of the pet, in years.
+	Age int `json:"age,omitempty" validate:"gte=0,lt=30"`
+	// Tags are the tags of the pet.
+	Tags []string `json:"tags,omitempty" validate:"max=5"`
+	// Email is the email of the owner.
+	Email string `json:"email" validate:"omitempty,email"`
+	// Size is the size of the pet.
+	Size string `json:"size" binding:"required" enums:"small,large"`
+	// Weight is the weight of the pet, in kilograms.
+	Weight   float64 `json:"weight,omitempty" format:"float" validate:"gt=0"`
+	internal string
+}
+
+// Error is the error of a request.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,367 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// ClientOptions configures GenerateClient.
+type ClientOptions struct {
+	// Package is the name of the package of the generated code.
+	Package string
+}
+
+// pathVariable matches the variables of a path template.
+var pathVariable = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// GenerateClient generates the Go source of a client of a document: the types of its schema components, a Client
+// with a method per operation taking a typed request and returning the response decoded according to its status, and
+// a RequestEditor constructor per security scheme. Requests are sent by an HTTPDoer, so that a client can be pointed
+// at an httptest.Server or given an instrumented http.Client.
+func GenerateClient(openAPI *v303.OpenAPI, options ClientOptions) ([]byte, error) {
+	generator := newGenerator(openAPI)
+	generator.components()
+	operations := generator.operations()
+	for _, path := range []string{"context", "io", "io/ioutil", "net/http", "net/url", "strings"} {
+		generator.imports[path] = true
+	}
+	generator.clientType()
+	generator.securityEditors()
+	for _, operation := range operations {
+		generator.clientRequest(operation)
+		generator.clientResponse(operation)
+		generator.clientMethod(operation)
+	}
+	return generator.source(options.Package, "swaggo gen client")
+}
+
+// clientType declares the Client, its options and the functions sending its requests.
+func (generator *generator) clientType() {
+	server := ""
+	if len(generator.openAPI.Servers) > 0 && generator.openAPI.Servers[0] != nil {
+		server = generator.openAPI.Servers[0].Url
+	}
+	fmt.Fprintf(&generator.decls, `// DefaultServer is the first server of the document.
+const DefaultServer = %q
+
+// HTTPDoer sends HTTP requests, *http.Client implements it.
+type HTTPDoer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// RequestEditor edits a request before it is sent, to authenticate it for instance.
+type RequestEditor func(ctx context.Context, request *http.Request) error
+
+// Client calls the operations of the API.
+type Client struct {
+	// Server is the base URL of the API, the paths of the operations are appended to it.
+	Server string
+	// Doer sends the requests.
+	Doer HTTPDoer
+	// RequestEditors edit every request, before the editors given to an operation.
+	RequestEditors []RequestEditor
+}
+
+// ClientOption configures a Client.
+type ClientOption func(client *Client) error
+
+// NewClient returns a Client of the API at server, DefaultServer when empty, sending its requests with
+// http.DefaultClient unless configured otherwise.
+func NewClient(server string, options ...ClientOption) (*Client, error) {
+	if server == "" {
+		server = DefaultServer
+	}
+	client := &Client{Server: strings.TrimSuffix(server, "/"), Doer: http.DefaultClient}
+	for _, option := range options {
+		if err := option(client); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// WithHTTPDoer sends the requests of a Client with doer.
+func WithHTTPDoer(doer HTTPDoer) ClientOption {
+	return func(client *Client) error {
+		client.Doer = doer
+		return nil
+	}
+}
+
+// WithRequestEditor adds an editor of every request of a Client.
+func WithRequestEditor(editor RequestEditor) ClientOption {
+	return func(client *Client) error {
+		client.RequestEditors = append(client.RequestEditors, editor)
+		return nil
+	}
+}
+
+// newRequest returns a request of path relative to the server of the client.
+func (client *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := client.Server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	return http.NewRequestWithContext(ctx, method, target, body)
+}
+
+// do edits a request with the editors of the client and then editors, sends it and reads the body of the response.
+func (client *Client) do(ctx context.Context, request *http.Request, editors []RequestEditor) (*http.Response, []byte, error) {
+	for _, editors := range [][]RequestEditor{client.RequestEditors, editors} {
+		for _, editor := range editors {
+			if err := editor(ctx, request); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	doer := client.Doer
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+	response, err := doer.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, body, nil
+}
+
+`, server)
+}
+
+// securityEditors declares a RequestEditor constructor per security scheme of the document.
+// http://spec.openapis.org/oas/v3.0.3#security-scheme-object
+func (generator *generator) securityEditors() {
+	if generator.openAPI.Components == nil {
+		return
+	}
+	schemes := generator.openAPI.Components.SecuritySchemes
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	decls := &generator.decls
+	for _, name := range names {
+		schemeRef := schemes[name]
+		if schemeRef == nil {
+			continue
+		}
+		scheme := schemeRef.Value
+		if scheme == nil {
+			generator.fail("$ref %q is not resolved, load the document with a v303.Loader", schemeRef.Ref)
+			continue
+		}
+		function := goName(name) + "RequestEditor"
+		if !generator.declare(function, "#/components/securitySchemes/"+name) {
+			continue
+		}
+		switch {
+		case scheme.Type == "apiKey":
+			fmt.Fprintf(decls, "// %s authenticates requests with an API key sent as the %s %s.\n", function, scheme.In, scheme.Name)
+			fmt.Fprintf(decls, "func %s(key string) RequestEditor {\nreturn func(ctx context.Context, request *http.Request) error {\n", function)
+			switch scheme.In {
+			case "query":
+				fmt.Fprintf(decls, "query := request.URL.Query()\nquery.Set(%q, key)\nrequest.URL.RawQuery = query.Encode()\n", scheme.Name)
+			case "cookie":
+				fmt.Fprintf(decls, "request.AddCookie(&http.Cookie{Name: %q, Value: key})\n", scheme.Name)
+			default:
+				fmt.Fprintf(decls, "request.Header.Set(%q, key)\n", scheme.Name)
+			}
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			fmt.Fprintf(decls, "// %s authenticates requests with HTTP basic authentication.\n", function)
+			fmt.Fprintf(decls, "func %s(username, password string) RequestEditor {\nreturn func(ctx context.Context, request *http.Request) error {\n", function)
+			fmt.Fprintf(decls, "request.SetBasicAuth(username, password)\n")
+		default:
+			// Bearer tokens, other HTTP schemes and the access tokens of OAuth2 and OpenID Connect.
+			authScheme := "Bearer"
+			if scheme.Type == "http" && scheme.Scheme != "" && !strings.EqualFold(scheme.Scheme, "bearer") {
+				authScheme = scheme.Scheme
+			}
+			fmt.Fprintf(decls, "// %s authenticates requests with a token sent in the Authorization header.\n", function)
+			fmt.Fprintf(decls, "func %s(token string) RequestEditor {\nreturn func(ctx context.Context, request *http.Request) error {\n", function)
+			fmt.Fprintf(decls, "request.Header.Set(\"Authorization\", %q+token)\n", authScheme+" ")
+		}
+		fmt.Fprintf(decls, "return nil\n}\n}\n\n")
+	}
+}
+
+// clientRequest declares the request of an operation, holding its parameters and body.
+func (generator *generator) clientRequest(operation *operation) {
+	decls := &generator.decls
+	fmt.Fprintf(decls, "// %sRequest holds the parameters and the body of %s %s.\n", operation.name, operation.method, operation.path)
+	var fields strings.Builder
+	for _, parameter := range operation.parameters {
+		fmt.Fprintf(&fields, "%s %s\n", parameter.field, parameter.goType)
+	}
+	switch {
+	case operation.body == nil:
+	case operation.body.json:
+		fmt.Fprintf(&fields, "// Body is encoded as %s, no body is sent when it is nil.\nBody *%s\n", operation.body.mediaType, operation.body.goType)
+	default:
+		fmt.Fprintf(&fields, "// Body is sent as %s, no body is sent when it is nil.\nBody io.Reader\n", operation.body.mediaType)
+		if strings.Contains(operation.body.mediaType, "*") {
+			fmt.Fprintf(&fields, "// ContentType is the media type of the body, matching %s.\nContentType string\n", operation.body.mediaType)
+		}
+	}
+	if fields.Len() == 0 {
+		fmt.Fprintf(decls, "type %sRequest struct{}\n\n", operation.name)
+		return
+	}
+	fmt.Fprintf(decls, "type %sRequest struct {\n%s}\n\n", operation.name, fields.String())
+}
+
+// clientResponse declares the response of an operation, with a field per documented status holding the decoded body
+// and headers of a response with that status.
+func (generator *generator) clientResponse(operation *operation) {
+	decls := &generator.decls
+	var fields strings.Builder
+	for _, response := range operation.responses {
+		if len(response.headers) > 0 {
+			headersName := operation.name + response.name() + "ResponseHeaders"
+			fmt.Fprintf(decls, "// %s are the headers of the %s response of %s.\ntype %[1]s struct {\n", headersName, response.code, operation.name)
+			for _, header := range response.headers {
+				fmt.Fprintf(decls, "%s %s\n", header.field, header.goType)
+			}
+			fmt.Fprintf(decls, "}\n\n")
+			fmt.Fprintf(&fields, "Headers%s *%s\n", response.name(), headersName)
+		}
+		if response.body != nil && response.body.json {
+			fields.WriteString(comment("", response.description))
+			fmt.Fprintf(&fields, "JSON%s *%s\n", response.name(), response.body.goType)
+		}
+	}
+	fmt.Fprintf(decls, `// %[1]sResponse is the response of %[2]s %[3]s. The fields of the status it matches, the status code
+// itself, then a range of codes or the default response, are set.
+type %[1]sResponse struct {
+	// HTTPResponse is the response received, its body is already read.
+	HTTPResponse *http.Response
+	// Body is the body of the response.
+	Body []byte
+	%[4]s}
+
+// StatusCode returns the status code of the response.
+func (response *%[1]sResponse) StatusCode() int {
+	return response.HTTPResponse.StatusCode
+}
+
+`, operation.name, operation.method, operation.path, fields.String())
+}
+
+// clientMethod declares the method of the Client calling an operation.
+func (generator *generator) clientMethod(operation *operation) {
+	decls := &generator.decls
+	decls.WriteString(operationComment(operation))
+	fmt.Fprintf(decls, "func (client *Client) %s(ctx context.Context, request %[1]sRequest, editors ...RequestEditor) (*%[1]sResponse, error) {\n",
+		operation.name)
+	// The path template is built from its literal parts and the serialized path parameters.
+	pathParameters := map[string]parameter{}
+	for _, parameter := range operation.parameters {
+		if parameter.in == "path" {
+			pathParameters[parameter.name] = parameter
+		}
+	}
+	var path []string
+	last := 0
+	for i, match := range pathVariable.FindAllStringSubmatchIndex(operation.path, -1) {
+		name := operation.path[match[2]:match[3]]
+		parameter, ok := pathParameters[name]
+		if !ok {
+			generator.fail("path parameter %q of %s %s is not declared", name, operation.method, operation.path)
+			continue
+		}
+		variable := fmt.Sprintf("path%d", i)
+		generator.imports["github.com/newm4n/swaggo/pkg/runtime"] = true
+		fmt.Fprintf(decls, "%s, err := runtime.StylePath(%q, %q, %t, request.%s)\nif err != nil {\nreturn nil, err\n}\n",
+			variable, parameter.name, parameter.style, parameter.explode, parameter.field)
+		path = append(path, fmt.Sprintf("%q", operation.path[last:match[0]]), variable)
+		last = match[1]
+	}
+	if last < len(operation.path) || len(path) == 0 {
+		path = append(path, fmt.Sprintf("%q", operation.path[last:]))
+	}
+	fmt.Fprintf(decls, "query := url.Values{}\n")
+	for _, parameter := range operation.parameters {
+		if parameter.in == "query" {
+			generator.imports["github.com/newm4n/swaggo/pkg/runtime"] = true
+			fmt.Fprintf(decls, "if err := runtime.StyleQuery(%q, %q, %t, request.%s, query); err != nil {\nreturn nil, err\n}\n",
+				parameter.name, parameter.style, parameter.explode, parameter.field)
+		}
+	}
+	fmt.Fprintf(decls, "var body io.Reader\n")
+	contentType := ""
+	switch {
+	case operation.body == nil:
+	case operation.body.json:
+		generator.imports["bytes"] = true
+		generator.imports["encoding/json"] = true
+		fmt.Fprintf(decls, "if request.Body != nil {\ndata, err := json.Marshal(request.Body)\nif err != nil {\nreturn nil, err\n}\nbody = bytes.NewReader(data)\n}\n")
+		contentType = fmt.Sprintf("%q", operation.body.mediaType)
+	default:
+		fmt.Fprintf(decls, "if request.Body != nil {\nbody = request.Body\n}\n")
+		contentType = fmt.Sprintf("%q", operation.body.mediaType)
+		if strings.Contains(operation.body.mediaType, "*") {
+			contentType = "request.ContentType"
+		}
+	}
+	fmt.Fprintf(decls, "httpRequest, err := client.newRequest(ctx, %q, %s, query, body)\nif err != nil {\nreturn nil, err\n}\n",
+		operation.method, strings.Join(path, "+"))
+	if contentType != "" {
+		fmt.Fprintf(decls, "if body != nil {\nhttpRequest.Header.Set(\"Content-Type\", %s)\n}\n", contentType)
+	}
+	for _, parameter := range operation.parameters {
+		if parameter.in == "header" || parameter.in == "cookie" {
+			generator.imports["github.com/newm4n/swaggo/pkg/runtime"] = true
+		}
+		switch parameter.in {
+		case "header":
+			fmt.Fprintf(decls, "if err := runtime.SetHeader(httpRequest.Header, %q, %t, request.%s); err != nil {\nreturn nil, err\n}\n",
+				parameter.name, parameter.explode, parameter.field)
+		case "cookie":
+			fmt.Fprintf(decls, "if err := runtime.AddCookie(httpRequest, %q, %t, request.%s); err != nil {\nreturn nil, err\n}\n",
+				parameter.name, parameter.explode, parameter.field)
+		}
+	}
+	fmt.Fprintf(decls, "httpResponse, responseBody, err := client.do(ctx, httpRequest, editors)\nif err != nil {\nreturn nil, err\n}\n")
+	fmt.Fprintf(decls, "response := &%sResponse{HTTPResponse: httpResponse, Body: responseBody}\n", operation.name)
+	var cases strings.Builder
+	decoded := false
+	for _, response := range operation.responses {
+		// Responses without anything to decode still have a case, so that they do not match a range or the default.
+		switch status := response.status(); {
+		case status != 0:
+			fmt.Fprintf(&cases, "case httpResponse.StatusCode == %d:\n", status)
+		case response.code == "default":
+			cases.WriteString("default:\n")
+		default:
+			fmt.Fprintf(&cases, "case httpResponse.StatusCode/100 == %c:\n", response.code[0])
+		}
+		if len(response.headers) > 0 {
+			decoded = true
+			generator.imports["github.com/newm4n/swaggo/pkg/runtime"] = true
+			fmt.Fprintf(&cases, "response.Headers%s = &%s%[1]sResponseHeaders{}\n", response.name(), operation.name)
+			for _, header := range response.headers {
+				fmt.Fprintf(&cases, "if err := runtime.BindHeader(%q, %t, %t, httpResponse.Header, &response.Headers%s.%s); err != nil {\nreturn response, err\n}\n",
+					header.name, header.explode, header.required, response.name(), header.field)
+			}
+		}
+		if response.body != nil && response.body.json {
+			decoded = true
+			generator.imports["encoding/json"] = true
+			fmt.Fprintf(&cases, "response.JSON%s = new(%s)\nif err := json.Unmarshal(responseBody, response.JSON%[1]s); err != nil {\nreturn response, err\n}\n",
+				response.name(), response.body.goType)
+		}
+	}
+	if decoded {
+		fmt.Fprintf(decls, "switch {\n%s}\n", cases.String())
+	}
+	fmt.Fprintf(decls, "return response, nil\n}\n\n")
+}
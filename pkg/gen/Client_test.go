@@ -0,0 +1,118 @@
+package gen
+
+import (
+	"testing"
+)
+
+// TestGenerateClient calls the generated server of the petstore example with its generated client.
+func TestGenerateClient(t *testing.T) {
+	openAPI := loadExample(t, "petstore.yaml")
+	server, err := GenerateServer(openAPI, ServerOptions{Package: "server"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := GenerateClient(openAPI, ClientOptions{Package: "client"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := runGenerated(t, map[string]string{
+		"server/server.go": string(server),
+		"client/client.go": string(client),
+		"main.go": `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"$PKG/client"
+	"$PKG/server"
+)
+
+type pets struct{}
+
+func (pets) ListPets(ctx context.Context, request server.ListPetsRequest) (server.ListPetsResponse, error) {
+	if request.Limit != nil && *request.Limit > 100 {
+		code, message := int32(400), "too many pets"
+		return server.ListPetsDefaultJSONResponse{StatusCode: 400, Body: server.Error{Code: &code, Message: &message}}, nil
+	}
+	id, name := int64(1), "rex"
+	next := "/pets?limit=" + request.HTTPRequest.URL.Query().Get("limit") + "&trace=" + request.HTTPRequest.Header.Get("X-Trace")
+	return server.ListPets200JSONResponse{
+		Body:    server.Pets{{ID: &id, Name: &name}},
+		Headers: server.ListPets200ResponseHeaders{XNext: &next},
+	}, nil
+}
+
+func (pets) CreatePets(ctx context.Context, request server.CreatePetsRequest) (server.CreatePetsResponse, error) {
+	return server.CreatePets201Response{}, nil
+}
+
+func (pets) ShowPetByID(ctx context.Context, request server.ShowPetByIDRequest) (server.ShowPetByIDResponse, error) {
+	id := int64(2)
+	return server.ShowPetByID200JSONResponse{Body: server.Pet{ID: &id, Name: &request.PetID}}, nil
+}
+
+func main() {
+	mux := http.NewServeMux()
+	server.RegisterServeMux(mux, pets{}, server.ServerOptions{})
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	trace := func(ctx context.Context, request *http.Request) error {
+		request.Header.Set("X-Trace", "abc")
+		return nil
+	}
+	c, err := client.NewClient(httpServer.URL+"/", client.WithHTTPDoer(httpServer.Client()), client.WithRequestEditor(trace))
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+
+	limit := int32(10)
+	list, err := c.ListPets(ctx, client.ListPetsRequest{Limit: &limit})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(list.StatusCode(), *list.Headers200.XNext, *(*list.JSON200)[0].Name, list.JSONDefault == nil)
+
+	limit = 1000
+	list, err = c.ListPets(ctx, client.ListPetsRequest{Limit: &limit})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(list.StatusCode(), list.JSON200 == nil, *list.JSONDefault.Code, *list.JSONDefault.Message)
+
+	list, err = c.ListPets(ctx, client.ListPetsRequest{}, func(ctx context.Context, request *http.Request) error {
+		request.Header.Set("X-Trace", "def")
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(list.StatusCode(), *list.Headers200.XNext)
+
+	created, err := c.CreatePets(ctx, client.CreatePetsRequest{})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(created.StatusCode(), created.JSONDefault == nil)
+
+	pet, err := c.ShowPetByID(ctx, client.ShowPetByIDRequest{PetID: "fido dido"})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(pet.StatusCode(), *pet.JSON200.ID, *pet.JSON200.Name)
+}
+`,
+	})
+	want := `200 /pets?limit=10&trace=abc rex true
+400 true 400 too many pets
+200 /pets?limit=&trace=def
+201 true
+200 2 fido dido
+`
+	if output != want {
+		t.Errorf("responses:\n%s\nwant:\n%s", output, want)
+	}
+}
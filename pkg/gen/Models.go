@@ -0,0 +1,22 @@
+package gen
+
+import (
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// ModelsOptions configures GenerateModels.
+type ModelsOptions struct {
+	// Package is the name of the package of the generated code.
+	Package string
+}
+
+// GenerateModels generates the Go source of the types of the schema components of a document, each with a Validate
+// method checking the constraints of its schema. Enums are defined types with a constant per value, oneOf schemas
+// with a discriminator are unions holding a sealed interface, allOf schemas embed their components and properties
+// are pointers, so that Validate reports the required ones which are absent.
+func GenerateModels(openAPI *v303.OpenAPI, options ModelsOptions) ([]byte, error) {
+	generator := newGenerator(openAPI)
+	generator.validate = true
+	generator.components()
+	return generator.source(options.Package, "swaggo gen models")
+}
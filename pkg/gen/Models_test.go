@@ -0,0 +1,117 @@
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// modulePath is the import path of this package, under which the generated code is compiled.
+const modulePath = "github.com/newm4n/swaggo/pkg/gen"
+
+// runGenerated writes files, keyed by slash separated path, into a temporary package under testdata, runs its main
+// package and returns its output. Packages under testdata belong to this module without being part of ./...
+func runGenerated(t *testing.T, files map[string]string) string {
+	t.Helper()
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go tool is needed to compile the generated code")
+	}
+	if err := os.MkdirAll("testdata", 0755); err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("testdata", "generated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, source := range files {
+		source = strings.Replace(source, "$PKG", modulePath+"/"+filepath.ToSlash(dir), -1)
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	command := exec.Command(goTool, "run", ".")
+	command.Dir = dir
+	output, err := command.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run: %v\n%s", err, output)
+	}
+	return string(output)
+}
+
+func TestGenerateModelsRequired(t *testing.T) {
+	openAPI := &v303.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    &v303.Info{Title: "Pets", Version: "1.0.0"},
+		Components: &v303.Components{Schema: map[string]*v303.SchemaRef{
+			"Owner": {Value: &v303.Schema{
+				Type:       "object",
+				Required:   []string{"name"},
+				Properties: map[string]*v303.SchemaRef{"name": {Value: &v303.Schema{Type: "string"}}},
+			}},
+			"Pet": {Value: &v303.Schema{
+				Type:     "object",
+				Required: []string{"age", "name", "owner", "tags", "vaccinated", "nickname"},
+				Properties: map[string]*v303.SchemaRef{
+					"age":        {Value: &v303.Schema{Type: "integer", Format: "int32"}},
+					"name":       {Value: &v303.Schema{Type: "string"}},
+					"nickname":   {Value: &v303.Schema{Type: "string", Nullable: true}},
+					"owner":      {Ref: "#/components/schemas/Owner"},
+					"tags":       {Value: &v303.Schema{Type: "array", Items: &v303.SchemaRef{Value: &v303.Schema{Type: "string"}}}},
+					"vaccinated": {Value: &v303.Schema{Type: "boolean"}},
+				},
+			}},
+		}},
+	}
+	openAPI.Components.Schema["Pet"].Value.Properties["owner"].Value = openAPI.Components.Schema["Owner"].Value
+	source, err := GenerateModels(openAPI, ModelsOptions{Package: "model"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := runGenerated(t, map[string]string{
+		"model/model.go": string(source),
+		"main.go": `package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"$PKG/model"
+)
+
+func main() {
+	for _, data := range []string{
+		"{}",
+		` + "`" + `{"age": 0, "name": "", "owner": {"name": ""}, "tags": [], "vaccinated": false}` + "`" + `,
+		` + "`" + `{"age": 0, "name": "", "owner": {}, "tags": [], "vaccinated": false}` + "`" + `,
+		` + "`" + `{"age": 0, "name": "", "owner": {"name": ""}, "tags": [], "vaccinated": null}` + "`" + `,
+	} {
+		var pet model.Pet
+		if err := json.Unmarshal([]byte(data), &pet); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(pet.Validate())
+	}
+}
+`,
+	})
+	want := `age: is required
+<nil>
+owner: name: is required
+vaccinated: is required
+`
+	if output != want {
+		t.Errorf("validation errors:\n%s\nwant:\n%s", output, want)
+	}
+}
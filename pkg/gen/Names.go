@@ -0,0 +1,76 @@
+package gen
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words written in upper case in Go identifiers.
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "SQL": true,
+	"URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName returns an exported Go identifier made of the words of name, such as GetPet for getPet, ModelPet for
+// model.Pet or PetID for pet_id.
+func goName(name string) string {
+	var builder strings.Builder
+	for _, word := range words(name) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			builder.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		builder.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	identifier := builder.String()
+	if identifier == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "N" + identifier
+	}
+	return identifier
+}
+
+// localName returns an unexported Go identifier for name, suffixed with an underscore when it is a keyword.
+func localName(name string) string {
+	exported := goName(name)
+	// A leading initialism is lower cased as a whole, id rather than iD.
+	first := words(exported)[0]
+	if !initialisms[first] {
+		first = string([]rune(first)[:1])
+	}
+	local := strings.ToLower(first) + exported[len(first):]
+	if token.Lookup(local).IsKeyword() {
+		local += "_"
+	}
+	return local
+}
+
+// words splits name at the characters which are neither letters nor digits and before the upper case letters
+// following a lower case one.
+func words(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		}
+		if len(word) > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
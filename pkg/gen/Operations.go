@@ -0,0 +1,280 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// methods are the HTTP methods of the operations of a path item, in the order they are generated.
+var methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// operation is an operation of the document along with the Go declarations describing it.
+type operation struct {
+	// name is the Go name of the operation, from its operationId or its method and path.
+	name        string
+	method      string
+	path        string
+	summary     string
+	description string
+	deprecated  bool
+	parameters  []parameter
+	// body is nil when the operation has no request body.
+	body      *body
+	responses []response
+}
+
+// parameter is a parameter or a header and the field of a Go struct which holds its value.
+type parameter struct {
+	name     string
+	in       string
+	style    string
+	explode  bool
+	required bool
+	field    string
+	goType   string
+}
+
+// body is a request or a response body. A JSON body is described by the Go type of its schema, any other one is read
+// as a stream.
+type body struct {
+	mediaType string
+	json      bool
+	goType    string
+	required  bool
+}
+
+// response is a response of an operation.
+type response struct {
+	// code is a status code, a range of codes such as 2XX or default.
+	code        string
+	description string
+	headers     []parameter
+	body        *body
+}
+
+// status returns the status code of a response, 0 for ranges and the default response.
+func (response response) status() int {
+	status, err := strconv.Atoi(response.code)
+	if err != nil {
+		return 0
+	}
+	return status
+}
+
+// name returns the code of a response as part of a Go identifier, 200, 2XX or Default.
+func (response response) name() string {
+	if response.code == "default" {
+		return "Default"
+	}
+	return response.code
+}
+
+// operations returns the operations of the document sorted by path and method, declaring the types of their
+// parameters and bodies.
+func (generator *generator) operations() []*operation {
+	paths := make([]string, 0, len(generator.openAPI.Paths))
+	for path := range generator.openAPI.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var operations []*operation
+	names := map[string]string{}
+	for _, path := range paths {
+		pathItem := generator.openAPI.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		pathOperations := pathItem.Operations()
+		for _, method := range methods {
+			op, ok := pathOperations[method]
+			if !ok {
+				continue
+			}
+			name := op.OperationID
+			if name == "" {
+				name = strings.ToLower(method) + " " + path
+			}
+			operation := &operation{name: goName(name), method: method, path: path, summary: op.Summary,
+				description: op.Description, deprecated: op.Deprecated}
+			if previous, ok := names[operation.name]; ok {
+				generator.fail("operations %s and %s %s are both named %s in Go", previous, method, path, operation.name)
+				continue
+			}
+			names[operation.name] = method + " " + path
+			operation.parameters = generator.parameters(operation.name, pathItem.Parameters, op.Parameters)
+			if op.RequestBody != nil {
+				if op.RequestBody.Value == nil {
+					generator.fail("$ref %q is not resolved, load the document with a v303.Loader", op.RequestBody.Ref)
+				} else if len(op.RequestBody.Value.Content) > 0 {
+					operation.body = generator.body(op.RequestBody.Value.Content, operation.name+"RequestBody")
+					operation.body.required = op.RequestBody.Value.Required
+				}
+			}
+			operation.responses = generator.responses(operation.name, op.Responses)
+			operations = append(operations, operation)
+		}
+	}
+	return operations
+}
+
+// parameters returns the parameters of an operation, which override the parameters of its path item with the same
+// name and location. Their fields are named after them, suffixed with their location when names collide.
+func (generator *generator) parameters(operationName string, pathParameters, operationParameters []*v303.ParameterRef) []parameter {
+	var merged []*v303.Parameter
+	index := map[string]int{}
+	for _, parameters := range [][]*v303.ParameterRef{pathParameters, operationParameters} {
+		for _, parameterRef := range parameters {
+			if parameterRef == nil {
+				continue
+			}
+			if parameterRef.Value == nil {
+				generator.fail("$ref %q is not resolved, load the document with a v303.Loader", parameterRef.Ref)
+				continue
+			}
+			key := parameterRef.Value.In + " " + parameterRef.Value.Name
+			if i, ok := index[key]; ok {
+				merged[i] = parameterRef.Value
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, parameterRef.Value)
+		}
+	}
+	// The fields of a request also hold the raw request and its body.
+	fields := map[string]bool{"HTTPRequest": true, "Body": true}
+	var parameters []parameter
+	for _, value := range merged {
+		field := goName(value.Name)
+		if fields[field] {
+			field += goName(value.In)
+		}
+		for i := 2; fields[field]; i++ {
+			field = fmt.Sprintf("%s%s%d", goName(value.Name), goName(value.In), i)
+		}
+		fields[field] = true
+		style := value.Style
+		if style == "" {
+			style = "simple"
+			if value.In == "query" || value.In == "cookie" {
+				style = "form"
+			}
+		}
+		explode := style == "form"
+		if value.Explode != nil {
+			explode = *value.Explode
+		}
+		// Parameters described by a content rather than a schema are kept as they are sent.
+		goType := "string"
+		if value.Schema != nil {
+			goType = generator.goType(value.Schema, operationName+field)
+		}
+		required := value.Required || value.In == "path"
+		if !required {
+			goType = pointer(goType)
+		}
+		parameters = append(parameters, parameter{name: value.Name, in: value.In, style: style, explode: explode,
+			required: required, field: field, goType: goType})
+	}
+	return parameters
+}
+
+// isJSON reports whether a media type is JSON, such as application/json or application/problem+json.
+func isJSON(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// body returns the body of a content, preferring JSON media types and declaring the types of their schemas under
+// name.
+func (generator *generator) body(content map[string]*v303.MediaType, name string) *body {
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		if isJSON(mediaType) {
+			var schema *v303.SchemaRef
+			if content[mediaType] != nil {
+				schema = content[mediaType].Schema
+			}
+			return &body{mediaType: mediaType, json: true, goType: generator.goType(schema, name)}
+		}
+	}
+	return &body{mediaType: mediaTypes[0]}
+}
+
+// responses returns the responses of an operation: the status codes in order, then the ranges and the default
+// response.
+func (generator *generator) responses(operationName string, responses map[string]*v303.ResponseRef) []response {
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if (codes[i] == "default") != (codes[j] == "default") {
+			return codes[j] == "default"
+		}
+		return codes[i] < codes[j]
+	})
+	var result []response
+	for _, code := range codes {
+		responseRef := responses[code]
+		if responseRef == nil {
+			continue
+		}
+		if responseRef.Value == nil {
+			generator.fail("$ref %q is not resolved, load the document with a v303.Loader", responseRef.Ref)
+			continue
+		}
+		value := responseRef.Value
+		response := response{code: strings.ToUpper(code), description: value.Description}
+		if code == "default" {
+			response.code = code
+		}
+		prefix := operationName + response.name()
+		if len(value.Content) > 0 {
+			response.body = generator.body(value.Content, prefix+"ResponseBody")
+		}
+		names := make([]string, 0, len(value.Headers))
+		for name := range value.Headers {
+			// Content-Type is described by the content of the response.
+			if !strings.EqualFold(name, "Content-Type") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		fields := map[string]bool{}
+		for _, name := range names {
+			headerRef := value.Headers[name]
+			if headerRef == nil {
+				continue
+			}
+			if headerRef.Value == nil {
+				generator.fail("$ref %q is not resolved, load the document with a v303.Loader", headerRef.Ref)
+				continue
+			}
+			field := goName(name)
+			for i := 2; fields[field]; i++ {
+				field = fmt.Sprintf("%s%d", goName(name), i)
+			}
+			fields[field] = true
+			goType := "string"
+			if headerRef.Value.Schema != nil {
+				goType = generator.goType(headerRef.Value.Schema, prefix+field+"Header")
+			}
+			if !headerRef.Value.Required {
+				goType = pointer(goType)
+			}
+			explode := headerRef.Value.Explode != nil && *headerRef.Value.Explode
+			response.headers = append(response.headers, parameter{name: name, in: "header", style: "simple",
+				explode: explode, required: headerRef.Value.Required, field: field, goType: goType})
+		}
+		result = append(result, response)
+	}
+	return result
+}
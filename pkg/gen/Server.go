@@ -0,0 +1,305 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// ServerOptions configures GenerateServer.
+type ServerOptions struct {
+	// Package is the name of the package of the generated code.
+	Package string
+	// Chi adds RegisterChi, which registers the operations on a chi router.
+	Chi bool
+}
+
+// GenerateServer generates the Go source of a server of a document: the types of its schema components, a
+// ServerInterface with a method per operation taking a typed request and returning a typed response, and the
+// functions registering the operations on a ServeMux and optionally a chi router. Parameters are bound according to
+// their style and explode keywords.
+func GenerateServer(openAPI *v303.OpenAPI, options ServerOptions) ([]byte, error) {
+	generator := newGenerator(openAPI)
+	generator.components()
+	operations := generator.operations()
+	if len(operations) > 0 {
+		generator.imports["context"] = true
+	}
+	generator.imports["log"] = true
+	generator.imports["net/http"] = true
+	generator.imports["github.com/newm4n/swaggo/pkg/runtime"] = true
+	for _, operation := range operations {
+		generator.serverRequest(operation)
+		generator.serverResponses(operation)
+	}
+	generator.serverInterface(operations)
+	generator.serverHandlers(operations, options.Chi)
+	return generator.source(options.Package, "swaggo gen server")
+}
+
+// operationComment returns the doc comment of the method of an operation.
+func operationComment(operation *operation) string {
+	text := operation.summary
+	if text == "" {
+		text = operation.description
+	}
+	if text == "" {
+		text = fmt.Sprintf("handles %s %s.", operation.method, operation.path)
+	}
+	doc := comment(operation.name, text)
+	if operation.deprecated {
+		doc += "//\n// Deprecated: the operation is deprecated by the document.\n"
+	}
+	return doc
+}
+
+// serverRequest declares the request of an operation, holding its parameters and body.
+func (generator *generator) serverRequest(operation *operation) {
+	decls := &generator.decls
+	fmt.Fprintf(decls, "// %sRequest holds the parameters and the body of %s %s.\n", operation.name, operation.method, operation.path)
+	fmt.Fprintf(decls, "type %sRequest struct {\n", operation.name)
+	fmt.Fprintf(decls, "// HTTPRequest is the request being served.\nHTTPRequest *http.Request\n")
+	for _, parameter := range operation.parameters {
+		fmt.Fprintf(decls, "%s %s\n", parameter.field, parameter.goType)
+	}
+	switch {
+	case operation.body == nil:
+	case operation.body.json:
+		fmt.Fprintf(decls, "// Body is the decoded %s body, nil when it is empty.\nBody *%s\n", operation.body.mediaType, operation.body.goType)
+	default:
+		generator.imports["io"] = true
+		fmt.Fprintf(decls, "// Body is the %s body.\nBody io.Reader\n", operation.body.mediaType)
+	}
+	fmt.Fprintf(decls, "}\n\n")
+}
+
+// serverResponses declares the response interface of an operation and a type implementing it per response.
+func (generator *generator) serverResponses(operation *operation) {
+	decls := &generator.decls
+	fmt.Fprintf(decls, "// %sResponse is implemented by the responses of %s %s.\n", operation.name, operation.method, operation.path)
+	fmt.Fprintf(decls, "type %sResponse interface {\n", operation.name)
+	fmt.Fprintf(decls, "// write%sResponse writes the response, and reports whether its status was sent, after which the error\n", operation.name)
+	fmt.Fprintf(decls, "// can only be logged.\nwrite%sResponse(w http.ResponseWriter) (bool, error)\n}\n\n", operation.name)
+	for _, response := range operation.responses {
+		typeName := operation.name + response.name() + "Response"
+		if response.body != nil && response.body.json {
+			typeName = operation.name + response.name() + "JSONResponse"
+		}
+		headersName := operation.name + response.name() + "ResponseHeaders"
+		if len(response.headers) > 0 {
+			fmt.Fprintf(decls, "// %s are the headers of %s.\ntype %[1]s struct {\n", headersName, typeName)
+			for _, header := range response.headers {
+				fmt.Fprintf(decls, "%s %s\n", header.field, header.goType)
+			}
+			fmt.Fprintf(decls, "}\n\n")
+		}
+		fmt.Fprintf(decls, "// %s is the %s response of %s.\n", typeName, response.code, operation.name)
+		if response.description != "" {
+			decls.WriteString(comment("", response.description))
+		}
+		var fields strings.Builder
+		status := response.status()
+		defaultStatus := 500
+		if status == 0 && response.code != "default" {
+			defaultStatus = int(response.code[0]-'0') * 100
+		}
+		if status == 0 {
+			fmt.Fprintf(&fields, "// StatusCode is the status of the response, %d when zero.\nStatusCode int\n", defaultStatus)
+		}
+		wildcard := response.body != nil && strings.Contains(response.body.mediaType, "*")
+		switch {
+		case response.body == nil:
+		case response.body.json:
+			fmt.Fprintf(&fields, "Body %s\n", response.body.goType)
+		default:
+			generator.imports["io"] = true
+			fmt.Fprintf(&fields, "Body io.Reader\n")
+			if wildcard {
+				fmt.Fprintf(&fields, "// ContentType is the media type of the body, matching %s.\nContentType string\n", response.body.mediaType)
+			}
+		}
+		if len(response.headers) > 0 {
+			fmt.Fprintf(&fields, "Headers %s\n", headersName)
+		}
+		if fields.Len() == 0 {
+			fmt.Fprintf(decls, "type %s struct{}\n\n", typeName)
+		} else {
+			fmt.Fprintf(decls, "type %s struct {\n%s}\n\n", typeName, fields.String())
+		}
+
+		fmt.Fprintf(decls, "func (response %s) write%sResponse(w http.ResponseWriter) (bool, error) {\n", typeName, operation.name)
+		for _, header := range response.headers {
+			fmt.Fprintf(decls, "if err := runtime.SetHeader(w.Header(), %q, %t, response.Headers.%s); err != nil {\nreturn false, err\n}\n",
+				header.name, header.explode, header.field)
+		}
+		// The body is encoded before the status is sent, so that an error can still be answered.
+		if response.body != nil && response.body.json {
+			generator.imports["encoding/json"] = true
+			fmt.Fprintf(decls, "body, err := json.Marshal(response.Body)\nif err != nil {\nreturn false, err\n}\n")
+		}
+		if response.body != nil {
+			if wildcard && !response.body.json {
+				fmt.Fprintf(decls, "w.Header().Set(\"Content-Type\", response.ContentType)\n")
+			} else {
+				fmt.Fprintf(decls, "w.Header().Set(\"Content-Type\", %q)\n", response.body.mediaType)
+			}
+		}
+		if status == 0 {
+			fmt.Fprintf(decls, "status := response.StatusCode\nif status == 0 {\nstatus = %d\n}\nw.WriteHeader(status)\n", defaultStatus)
+		} else {
+			fmt.Fprintf(decls, "w.WriteHeader(%d)\n", status)
+		}
+		switch {
+		case response.body == nil:
+			fmt.Fprintf(decls, "return true, nil\n")
+		case response.body.json:
+			fmt.Fprintf(decls, "_, err = w.Write(append(body, '\\n'))\nreturn true, err\n")
+		default:
+			fmt.Fprintf(decls, "if response.Body == nil {\nreturn true, nil\n}\n_, err := io.Copy(w, response.Body)\nreturn true, err\n")
+		}
+		fmt.Fprintf(decls, "}\n\n")
+	}
+}
+
+// serverInterface declares the interface implemented by the handlers of the operations.
+func (generator *generator) serverInterface(operations []*operation) {
+	decls := &generator.decls
+	fmt.Fprintf(decls, "// ServerInterface is implemented by the handlers of the operations. An error returned by a handler is\n")
+	fmt.Fprintf(decls, "// answered by the ErrorHandler of the ServerOptions.\ntype ServerInterface interface {\n")
+	for _, operation := range operations {
+		decls.WriteString(operationComment(operation))
+		fmt.Fprintf(decls, "%s(ctx context.Context, request %[1]sRequest) (%[1]sResponse, error)\n", operation.name)
+	}
+	fmt.Fprintf(decls, "}\n\n")
+}
+
+// serverHandlers declares the HTTP handlers binding the requests of the operations, and the functions registering
+// them on a ServeMux and optionally a chi router.
+func (generator *generator) serverHandlers(operations []*operation, chi bool) {
+	decls := &generator.decls
+	decls.WriteString(`// ServerOptions configures the handlers of a ServerInterface.
+type ServerOptions struct {
+	// ErrorHandler answers the requests which cannot be bound and whose handler fails, runtime.DefaultErrorHandler
+	// when nil.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+	// ErrorLog logs the errors of the responses whose status is sent, such as a client gone while the body is
+	// written, the standard logger when nil.
+	ErrorLog *log.Logger
+}
+
+// serverWrapper binds the requests of the operations and writes their responses.
+type serverWrapper struct {
+	server        ServerInterface
+	errorHandler  func(w http.ResponseWriter, r *http.Request, err error)
+	errorLog      *log.Logger
+	pathParameter func(r *http.Request, name string) string
+}
+
+func newServerWrapper(server ServerInterface, options ServerOptions, pathParameter func(r *http.Request, name string) string) *serverWrapper {
+	wrapper := &serverWrapper{server: server, errorHandler: options.ErrorHandler, errorLog: options.ErrorLog, pathParameter: pathParameter}
+	if wrapper.errorHandler == nil {
+		wrapper.errorHandler = runtime.DefaultErrorHandler
+	}
+	return wrapper
+}
+
+// writeResponse writes the response of r with write, answering its error with the errorHandler until the status
+// is sent, and logging it afterwards.
+func (wrapper *serverWrapper) writeResponse(w http.ResponseWriter, r *http.Request, write func(w http.ResponseWriter) (bool, error)) {
+	sent, err := write(w)
+	switch {
+	case err == nil:
+	case !sent:
+		wrapper.errorHandler(w, r, err)
+	case wrapper.errorLog != nil:
+		wrapper.errorLog.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	default:
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	}
+}
+
+// routes returns the routes of the operations.
+func (wrapper *serverWrapper) routes() []runtime.Route {
+	return []runtime.Route{
+`)
+	for _, operation := range operations {
+		fmt.Fprintf(decls, "{Method: %q, Path: %q, Handler: http.HandlerFunc(wrapper.%s)},\n", operation.method, operation.path, localName(operation.name))
+	}
+	decls.WriteString(`}
+}
+
+// RegisterServeMux registers the operations of server on mux.
+func RegisterServeMux(mux *http.ServeMux, server ServerInterface, options ServerOptions) {
+	runtime.RegisterServeMux(mux, newServerWrapper(server, options, runtime.PathParameter).routes())
+}
+
+`)
+	if chi {
+		generator.imports["github.com/go-chi/chi/v5"] = true
+		decls.WriteString(`// RegisterChi registers the operations of server on router.
+func RegisterChi(router chi.Router, server ServerInterface, options ServerOptions) {
+	for _, route := range newServerWrapper(server, options, chi.URLParam).routes() {
+		router.Method(route.Method, route.Path, route.Handler)
+	}
+}
+
+`)
+	}
+	for _, operation := range operations {
+		generator.serverHandler(operation)
+	}
+}
+
+// serverHandler declares the HTTP handler of an operation.
+func (generator *generator) serverHandler(operation *operation) {
+	decls := &generator.decls
+	fmt.Fprintf(decls, "func (wrapper *serverWrapper) %s(w http.ResponseWriter, r *http.Request) {\n", localName(operation.name))
+	fmt.Fprintf(decls, "request := %sRequest{HTTPRequest: r}\n", operation.name)
+	query := false
+	for _, parameter := range operation.parameters {
+		var bind string
+		switch parameter.in {
+		case "path":
+			bind = fmt.Sprintf("runtime.BindPath(%[1]q, %[2]q, %[3]t, wrapper.pathParameter(r, %[1]q), &request.%[4]s)",
+				parameter.name, parameter.style, parameter.explode, parameter.field)
+		case "query":
+			if !query {
+				fmt.Fprintf(decls, "query := r.URL.Query()\n")
+				query = true
+			}
+			bind = fmt.Sprintf("runtime.BindQuery(%q, %q, %t, %t, query, &request.%s)",
+				parameter.name, parameter.style, parameter.explode, parameter.required, parameter.field)
+		case "header":
+			bind = fmt.Sprintf("runtime.BindHeader(%q, %t, %t, r.Header, &request.%s)",
+				parameter.name, parameter.explode, parameter.required, parameter.field)
+		case "cookie":
+			bind = fmt.Sprintf("runtime.BindCookie(%q, %t, %t, r, &request.%s)",
+				parameter.name, parameter.explode, parameter.required, parameter.field)
+		default:
+			continue
+		}
+		fmt.Fprintf(decls, "if err := %s; err != nil {\nwrapper.errorHandler(w, r, err)\nreturn\n}\n", bind)
+	}
+	switch {
+	case operation.body == nil:
+	case operation.body.json:
+		fmt.Fprintf(decls, "if err := runtime.BindJSON(r, %t, &request.Body); err != nil {\nwrapper.errorHandler(w, r, err)\nreturn\n}\n",
+			operation.body.required)
+	default:
+		fmt.Fprintf(decls, "request.Body = r.Body\n")
+	}
+	generator.imports["fmt"] = true
+	fmt.Fprintf(decls, `response, err := wrapper.server.%s(r.Context(), request)
+if err == nil && response == nil {
+	err = fmt.Errorf("%[1]s returned no response")
+}
+if err != nil {
+	wrapper.errorHandler(w, r, err)
+	return
+}
+wrapper.writeResponse(w, r, response.write%[1]sResponse)
+}
+
+`, operation.name)
+}
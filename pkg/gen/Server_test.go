@@ -0,0 +1,200 @@
+package gen
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// examples are the example documents of the testdata of the v303 package.
+var examples = []string{"petstore.yaml", "uspto.yaml", "callback-example.yaml", "link-example.yaml"}
+
+// loadExample loads an example document.
+func loadExample(t *testing.T, name string) *v303.OpenAPI {
+	t.Helper()
+	openAPI, err := v303.NewLoader(v303.Dir(filepath.Join("..", "openapi", "v303", "testdata"))).LoadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return openAPI
+}
+
+// TestGenerateExamples compiles the server and the client of every example document.
+func TestGenerateExamples(t *testing.T) {
+	files := map[string]string{}
+	var imports []string
+	for _, example := range examples {
+		openAPI := loadExample(t, example)
+		dir := strings.Replace(strings.TrimSuffix(example, ".yaml"), "-", "", -1)
+		server, err := GenerateServer(openAPI, ServerOptions{Package: "server"})
+		if err != nil {
+			t.Fatalf("%s: %v", example, err)
+		}
+		client, err := GenerateClient(openAPI, ClientOptions{Package: "client"})
+		if err != nil {
+			t.Fatalf("%s: %v", example, err)
+		}
+		files[dir+"/server/server.go"] = string(server)
+		files[dir+"/client/client.go"] = string(client)
+		imports = append(imports, "\t_ \"$PKG/"+dir+"/server\"\n", "\t_ \"$PKG/"+dir+"/client\"\n")
+	}
+	sort.Strings(imports)
+	files["main.go"] = "package main\n\nimport (\n" + strings.Join(imports, "") + ")\n\nfunc main() {}\n"
+	runGenerated(t, files)
+}
+
+func TestGenerateServer(t *testing.T) {
+	source, err := GenerateServer(loadExample(t, "petstore.yaml"), ServerOptions{Package: "server"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := runGenerated(t, map[string]string{
+		"server/server.go": string(source),
+		"main.go": `package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"$PKG/server"
+)
+
+type pets struct{}
+
+func (pets) ListPets(ctx context.Context, request server.ListPetsRequest) (server.ListPetsResponse, error) {
+	if request.Limit != nil && *request.Limit > 100 {
+		code, message := int32(400), "too many pets"
+		return server.ListPetsDefaultJSONResponse{StatusCode: 400, Body: server.Error{Code: &code, Message: &message}}, nil
+	}
+	id, name, next := int64(1), "rex", "/pets?page=2"
+	return server.ListPets200JSONResponse{
+		Body:    server.Pets{{ID: &id, Name: &name}},
+		Headers: server.ListPets200ResponseHeaders{XNext: &next},
+	}, nil
+}
+
+func (pets) CreatePets(ctx context.Context, request server.CreatePetsRequest) (server.CreatePetsResponse, error) {
+	if request.HTTPRequest.URL.Query().Get("created") != "" {
+		return server.CreatePets201Response{}, nil
+	}
+	return nil, nil
+}
+
+func (pets) ShowPetByID(ctx context.Context, request server.ShowPetByIDRequest) (server.ShowPetByIDResponse, error) {
+	if request.PetID == "lost" {
+		return nil, errors.New("the pet is lost")
+	}
+	id := int64(1)
+	return server.ShowPetByID200JSONResponse{Body: server.Pet{ID: &id, Name: &request.PetID}}, nil
+}
+
+// failingWriter fails to write bodies, as when the client is gone, and counts the statuses written.
+type failingWriter struct {
+	header   http.Header
+	statuses []int
+}
+
+func (w *failingWriter) Header() http.Header        { return w.header }
+func (w *failingWriter) WriteHeader(status int)      { w.statuses = append(w.statuses, status) }
+func (w *failingWriter) Write(data []byte) (int, error) { return 0, errors.New("connection reset") }
+
+func main() {
+	mux := http.NewServeMux()
+	server.RegisterServeMux(mux, pets{}, server.ServerOptions{ErrorLog: log.New(os.Stdout, "log: ", 0)})
+	for _, request := range []struct{ method, target string }{
+		{"GET", "/pets?limit=10"},
+		{"GET", "/pets?limit=1000"},
+		{"GET", "/pets?limit=ten"},
+		{"POST", "/pets?created=1"},
+		{"POST", "/pets"},
+		{"GET", "/pets/fido"},
+		{"GET", "/pets/lost"},
+		{"DELETE", "/pets"},
+		{"GET", "/pets/fido/toys"},
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(request.method, request.target, nil))
+		fmt.Printf("%s %s: %d %q %q %q\n", request.method, request.target, w.Code,
+			w.Header().Get("Content-Type"), w.Header().Get("X-Next"), w.Body.String())
+	}
+	w := &failingWriter{header: http.Header{}}
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/pets", nil))
+	fmt.Println("statuses:", w.statuses)
+}
+`,
+	})
+	want := `GET /pets?limit=10: 200 "application/json" "/pets?page=2" "[{\"id\":1,\"name\":\"rex\"}]\n"
+GET /pets?limit=1000: 400 "application/json" "" "{\"code\":400,\"message\":\"too many pets\"}\n"
+GET /pets?limit=ten: 400 "text/plain; charset=utf-8" "" "query parameter \"limit\": \"ten\" is not an integer of 32 bits\n"
+POST /pets?created=1: 201 "" "" ""
+POST /pets: 500 "text/plain; charset=utf-8" "" "CreatePets returned no response\n"
+GET /pets/fido: 200 "application/json" "" "{\"id\":1,\"name\":\"fido\"}\n"
+GET /pets/lost: 500 "text/plain; charset=utf-8" "" "the pet is lost\n"
+DELETE /pets: 405 "text/plain; charset=utf-8" "" "Method Not Allowed\n"
+GET /pets/fido/toys: 404 "text/plain; charset=utf-8" "" "404 page not found\n"
+log: GET /pets: connection reset
+statuses: [200]
+`
+	if output != want {
+		t.Errorf("responses:\n%s\nwant:\n%s", output, want)
+	}
+}
+
+// TestGenerateServerEncodingError checks that a body which cannot be encoded is answered by the ErrorHandler, since
+// no status is sent yet.
+func TestGenerateServerEncodingError(t *testing.T) {
+	openAPI := &v303.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    &v303.Info{Title: "Any", Version: "1.0.0"},
+		Paths: map[string]*v303.PathItem{"/value": {Get: &v303.Operation{
+			OperationID: "getValue",
+			Responses: map[string]*v303.ResponseRef{"200": {Value: &v303.Response{
+				Description: "The value",
+				Content:     map[string]*v303.MediaType{"application/json": {Schema: &v303.SchemaRef{Value: &v303.Schema{}}}},
+			}}},
+		}}},
+	}
+	source, err := GenerateServer(openAPI, ServerOptions{Package: "server"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := runGenerated(t, map[string]string{
+		"server/server.go": string(source),
+		"main.go": `package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"$PKG/server"
+)
+
+type values struct{}
+
+func (values) GetValue(ctx context.Context, request server.GetValueRequest) (server.GetValueResponse, error) {
+	return server.GetValue200JSONResponse{Body: make(chan int)}, nil
+}
+
+func main() {
+	mux := http.NewServeMux()
+	server.RegisterServeMux(mux, values{}, server.ServerOptions{})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/value", nil))
+	fmt.Printf("%d %q %q\n", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+}
+`,
+	})
+	if want := "500 \"text/plain; charset=utf-8\" \"json: unsupported type: chan int\\n\"\n"; output != want {
+		t.Errorf("response = %s, want %s", output, want)
+	}
+}
@@ -0,0 +1,577 @@
+// Package gen generates Go code from OpenAPI 3.0.3 documents: servers, clients and models.
+// Documents are expected to be loaded by a v303.Loader, so that the values of their refs are resolved.
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// schemaComponents is the prefix of the refs to schema components, which become named Go types.
+const schemaComponents = "#/components/schemas/"
+
+// generator accumulates the Go declarations of a document along with the imports they need.
+type generator struct {
+	openAPI  *v303.OpenAPI
+	imports  map[string]bool
+	decls    bytes.Buffer
+	declared map[string]string
+	// inline are the schemas being described by an inline type, to stop recursion through refs which do not point
+	// to schema components.
+	inline map[*v303.Schema]bool
+	// validate adds a Validate method to the declared types.
+	validate bool
+	// patterns counts the regular expressions of the Validate methods.
+	patterns int
+	err      error
+}
+
+func newGenerator(openAPI *v303.OpenAPI) *generator {
+	return &generator{openAPI: openAPI, imports: map[string]bool{}, declared: map[string]string{}, inline: map[*v303.Schema]bool{}}
+}
+
+// fail records the first error of the generation.
+func (generator *generator) fail(format string, args ...interface{}) {
+	if generator.err == nil {
+		generator.err = fmt.Errorf(format, args...)
+	}
+}
+
+// declare reserves a Go type name for source, reporting whether it was still available. A name reserved twice for
+// different sources is an error.
+func (generator *generator) declare(name, source string) bool {
+	if previous, ok := generator.declared[name]; ok {
+		if previous != source {
+			generator.fail("%s and %s are both named %s in Go", previous, source, name)
+		}
+		return false
+	}
+	generator.declared[name] = source
+	return true
+}
+
+// source returns the formatted Go file of the declarations, generated by command in package pkg.
+func (generator *generator) source(pkg, command string) ([]byte, error) {
+	if generator.err != nil {
+		return nil, generator.err
+	}
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by %s. DO NOT EDIT.\n\npackage %s\n\n", command, pkg)
+	imports := make([]string, 0, len(generator.imports))
+	for path := range generator.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	if len(imports) > 0 {
+		// The standard library comes first, apart from the modules.
+		file.WriteString("import (\n")
+		for _, standard := range []bool{true, false} {
+			for _, path := range imports {
+				if !strings.Contains(strings.Split(path, "/")[0], ".") == standard {
+					fmt.Fprintf(&file, "%q\n", path)
+				}
+			}
+			file.WriteString("\n")
+		}
+		file.WriteString(")\n\n")
+	}
+	file.Write(generator.decls.Bytes())
+	formatted, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w\n%s", err, file.Bytes())
+	}
+	return formatted, nil
+}
+
+// comment returns the doc comment of a declaration made of its description. A description starting in lower case,
+// such as "is a pet", follows the name of the declaration.
+func comment(name, description string) string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return ""
+	}
+	if first := []rune(description)[0]; name != "" && unicode.IsLower(first) {
+		description = name + " " + description
+	}
+	var builder strings.Builder
+	for _, line := range strings.Split(description, "\n") {
+		builder.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+	return builder.String()
+}
+
+// components declares a Go type for every schema component.
+func (generator *generator) components() {
+	if generator.openAPI.Components == nil {
+		return
+	}
+	schemas := generator.openAPI.Components.Schema
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if schema := schemas[name]; schema != nil {
+			generator.declareType(goName(name), schemaComponents+name, schema)
+		}
+	}
+}
+
+// declareType declares the named Go type of a schema: a struct for an object, an enum, a union, otherwise a defined
+// type.
+func (generator *generator) declareType(name, source string, schema *v303.SchemaRef) {
+	if schema.Ref == "" && schema.Value != nil {
+		switch {
+		case isStruct(schema.Value):
+			generator.declareStruct(name, source, schema.Value)
+			return
+		case enumType(schema.Value) != "":
+			generator.declareEnum(name, source, schema.Value)
+			return
+		case generator.isUnion(schema.Value):
+			generator.declareUnion(name, source, schema.Value)
+			return
+		}
+	}
+	if !generator.declare(name, source) {
+		return
+	}
+	description := ""
+	if schema.Value != nil {
+		description = schema.Value.Description
+	}
+	goType := generator.goType(schema, name)
+	fmt.Fprintf(&generator.decls, "%stype %s %s\n\n", comment(name, description), name, goType)
+	if generator.validate {
+		generator.validateType(name, goType, schema)
+	}
+}
+
+// isStruct reports whether a schema is described by a Go struct: an object with properties or without additional
+// properties, or a combination of schemas with allOf.
+func isStruct(schema *v303.Schema) bool {
+	return len(schema.AllOf) > 0 || (len(schema.Properties) > 0 || schema.AdditionalProperties.Forbidden()) &&
+		(schema.Type == "" || schema.Type == "object")
+}
+
+// goType returns the Go type of a schema. Schema components are referred to by their name and the structs of inline
+// objects are declared under name.
+func (generator *generator) goType(schema *v303.SchemaRef, name string) string {
+	if schema == nil {
+		return "interface{}"
+	}
+	if strings.HasPrefix(schema.Ref, schemaComponents) {
+		return goName(unescape(schema.Ref[len(schemaComponents):]))
+	}
+	value := schema.Value
+	if value == nil {
+		if schema.Ref != "" {
+			generator.fail("$ref %q is not resolved, load the document with a v303.Loader", schema.Ref)
+		}
+		return "interface{}"
+	}
+	if generator.inline[value] {
+		// A recursive schema which is not a component cannot be named.
+		return "interface{}"
+	}
+	generator.inline[value] = true
+	defer delete(generator.inline, value)
+	switch {
+	case isStruct(value):
+		generator.declareStruct(name, name, value)
+		return name
+	case enumType(value) != "":
+		generator.declareEnum(name, name, value)
+		return name
+	case generator.isUnion(value):
+		generator.declareUnion(name, name, value)
+		return name
+	}
+	if len(value.OneOf) > 0 || len(value.AnyOf) > 0 {
+		generator.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+	switch value.Type {
+	case "string":
+		switch value.Format {
+		case "date-time":
+			generator.imports["time"] = true
+			return "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		switch value.Format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		}
+		return "int"
+	case "number":
+		if value.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + generator.goType(value.Items, name+"Item")
+	case "object":
+		if value.AdditionalProperties != nil && value.AdditionalProperties.Schema != nil {
+			return "map[string]" + generator.goType(value.AdditionalProperties.Schema, name+"Value")
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// declareStruct declares the struct of an object. Schema components combined with allOf are embedded, the properties
+// of inline ones are merged. Properties are pointers unless their type has a nil value, so that a required property
+// which is absent is told from its zero value, and optional and nullable ones are omitted when nil. The struct of an
+// object without additional properties rejects the members it does not describe when decoded.
+func (generator *generator) declareStruct(name, source string, schema *v303.Schema) {
+	if !generator.declare(name, source) {
+		return
+	}
+	var fields strings.Builder
+	var embedded []string
+	properties := map[string]*v303.SchemaRef{}
+	required := map[string]bool{}
+	var merge func(schema *v303.Schema)
+	merge = func(schema *v303.Schema) {
+		for _, member := range schema.AllOf {
+			switch {
+			case member == nil:
+			case strings.HasPrefix(member.Ref, schemaComponents):
+				if component := generator.component(member.Ref); component != nil && component.AdditionalProperties.Forbidden() {
+					// The UnmarshalJSON method of the component would be promoted and decode it alone.
+					generator.fail("%s forbids additional properties and cannot be combined with allOf", member.Ref)
+				}
+				embedded = append(embedded, generator.goType(member, ""))
+				fmt.Fprintf(&fields, "%s\n", embedded[len(embedded)-1])
+			case member.Value != nil:
+				merge(member.Value)
+			default:
+				generator.fail("$ref %q is not resolved, load the document with a v303.Loader", member.Ref)
+			}
+		}
+		for property, propertySchema := range schema.Properties {
+			properties[property] = propertySchema
+		}
+		for _, property := range schema.Required {
+			required[property] = true
+		}
+	}
+	merge(schema)
+	names := make([]string, 0, len(properties))
+	for property := range properties {
+		names = append(names, property)
+	}
+	sort.Strings(names)
+	// A field cannot be named after the Validate method.
+	fieldNames := map[string]bool{"Validate": generator.validate}
+	var structFields []structField
+	for _, property := range names {
+		fieldName := goName(property)
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", goName(property), i)
+		}
+		fieldNames[fieldName] = true
+		propertySchema := properties[property]
+		fieldType := pointer(generator.goType(propertySchema, name+fieldName))
+		optional := !required[property] || propertySchema != nil && propertySchema.Value != nil && propertySchema.Value.Nullable
+		tag := property
+		if optional {
+			tag += ",omitempty"
+		}
+		if propertySchema != nil && propertySchema.Ref == "" && propertySchema.Value != nil {
+			fields.WriteString(comment(fieldName, propertySchema.Value.Description))
+		}
+		fmt.Fprintf(&fields, "%s %s `json:%q`\n", fieldName, fieldType, tag)
+		structFields = append(structFields, structField{property: property, name: fieldName, goType: fieldType,
+			schema: propertySchema, required: required[property]})
+	}
+	if fields.Len() == 0 {
+		fmt.Fprintf(&generator.decls, "%stype %s struct{}\n\n", comment(name, schema.Description), name)
+	} else {
+		fmt.Fprintf(&generator.decls, "%stype %s struct {\n%s}\n\n", comment(name, schema.Description), name, fields.String())
+	}
+	if schema.AdditionalProperties.Forbidden() {
+		properties := map[string]bool{}
+		generator.propertyNames(schema, properties, map[*v303.Schema]bool{})
+		generator.declareClosed(name, properties)
+	}
+	if generator.validate {
+		generator.validateStruct(name, embedded, structFields)
+	}
+}
+
+// component returns the schema component a ref points at, or nil.
+func (generator *generator) component(ref string) *v303.Schema {
+	if generator.openAPI.Components == nil || !strings.HasPrefix(ref, schemaComponents) {
+		return nil
+	}
+	schema := generator.openAPI.Components.Schema[unescape(ref[len(schemaComponents):])]
+	if schema == nil {
+		return nil
+	}
+	return schema.Value
+}
+
+// propertyNames adds the names of the properties of a schema and of the schemas it combines with allOf to names.
+func (generator *generator) propertyNames(schema *v303.Schema, names map[string]bool, visited map[*v303.Schema]bool) {
+	if schema == nil || visited[schema] {
+		return
+	}
+	visited[schema] = true
+	for property := range schema.Properties {
+		names[property] = true
+	}
+	for _, member := range schema.AllOf {
+		switch {
+		case member == nil:
+		case member.Value != nil:
+			generator.propertyNames(member.Value, names, visited)
+		default:
+			generator.propertyNames(generator.component(member.Ref), names, visited)
+		}
+	}
+}
+
+// declareClosed declares the UnmarshalJSON method of the struct of an object without additional properties, which
+// rejects the members other than the properties named.
+func (generator *generator) declareClosed(name string, properties map[string]bool) {
+	generator.imports["encoding/json"] = true
+	generator.imports["fmt"] = true
+	quoted := make([]string, 0, len(properties))
+	for property := range properties {
+		quoted = append(quoted, fmt.Sprintf("%q", property))
+	}
+	sort.Strings(quoted)
+	cases := ""
+	if len(quoted) > 0 {
+		cases = "case " + strings.Join(quoted, ", ") + ":\n"
+	}
+	fmt.Fprintf(&generator.decls, `// UnmarshalJSON decodes the value, rejecting the members the schema of %[1]s does not describe.
+func (value *%[1]s) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for member := range members {
+		switch member {
+		%[2]sdefault:
+			return fmt.Errorf("%%q is not a property of %[1]s", member)
+		}
+	}
+	type alias %[1]s
+	return json.Unmarshal(data, (*alias)(value))
+}
+
+`, name, cases)
+}
+
+// structField is a field of a struct declared for the property of an object.
+type structField struct {
+	property string
+	name     string
+	goType   string
+	schema   *v303.SchemaRef
+	required bool
+}
+
+// enumType returns the Go type of the constants of an enum of strings or integers, empty for any other schema.
+func enumType(schema *v303.Schema) string {
+	if len(schema.Enum) == 0 {
+		return ""
+	}
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer":
+		switch schema.Format {
+		case "int32", "int64":
+			return schema.Format
+		}
+		return "int"
+	}
+	return ""
+}
+
+// enumText returns the text of a value of an enum of goType. null, which is the value of a nullable enum described
+// by a nil pointer, and the values of other types, which v303.OpenAPI.Validate reports, have none.
+func enumText(raw json.RawMessage, goType string) (string, bool) {
+	if len(raw) == 0 || string(raw) == "null" || goType == "string" && raw[0] != '"' || goType != "string" && raw[0] == '"' {
+		return "", false
+	}
+	if goType == "string" {
+		var text string
+		return text, json.Unmarshal(raw, &text) == nil
+	}
+	var number json.Number
+	if json.Unmarshal(raw, &number) != nil || strings.ContainsAny(string(number), ".eE") {
+		return "", false
+	}
+	return string(number), true
+}
+
+// declareEnum declares the defined type of an enum, and a constant per value.
+func (generator *generator) declareEnum(name, source string, schema *v303.Schema) {
+	if !generator.declare(name, source) {
+		return
+	}
+	goType := enumType(schema)
+	decls := &generator.decls
+	fmt.Fprintf(decls, "%stype %s %s\n\n", comment(name, schema.Description), name, goType)
+	fmt.Fprintf(decls, "// The values of %s.\nconst (\n", name)
+	var constants []string
+	for _, raw := range schema.Enum {
+		text, ok := enumText(bytes.TrimSpace(raw), goType)
+		if !ok {
+			continue
+		}
+		suffix := goName(text)
+		if text != "" && unicode.IsDigit([]rune(text)[0]) {
+			suffix = strings.TrimPrefix(suffix, "N")
+		}
+		if text == "" {
+			suffix = "Empty"
+		}
+		constant := name + suffix
+		for i := 2; generator.declared[constant] != ""; i++ {
+			constant = fmt.Sprintf("%s%s%d", name, suffix, i)
+		}
+		generator.declare(constant, source+" "+text)
+		literal := text
+		if goType == "string" {
+			literal = fmt.Sprintf("%q", text)
+		}
+		fmt.Fprintf(decls, "%s %s = %s\n", constant, name, literal)
+		constants = append(constants, constant)
+	}
+	fmt.Fprintf(decls, ")\n\n")
+	if generator.validate {
+		generator.imports["fmt"] = true
+		format := "%v"
+		if goType == "string" {
+			format = "%q"
+		}
+		fmt.Fprintf(decls, "// Validate checks that the value is one of the values of %s.\nfunc (value %[1]s) Validate() error {\n", name)
+		if len(constants) > 0 {
+			fmt.Fprintf(decls, "switch value {\ncase %s:\nreturn nil\n}\n", strings.Join(constants, ", "))
+		}
+		fmt.Fprintf(decls, "return fmt.Errorf(\"%s is not one of the values of %s\", value)\n}\n\n", format, name)
+	}
+}
+
+// isUnion reports whether a schema is one of schema components told apart by a discriminator, which is described by a
+// sealed interface.
+func (generator *generator) isUnion(schema *v303.Schema) bool {
+	if len(schema.OneOf) == 0 || schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+		return false
+	}
+	for _, member := range schema.OneOf {
+		if member == nil || !strings.HasPrefix(member.Ref, schemaComponents) {
+			return false
+		}
+	}
+	return true
+}
+
+// declareUnion declares the struct of a union holding one of its members, the sealed interface the members
+// implement, and the JSON methods choosing the member according to the discriminator.
+// http://spec.openapis.org/oas/v3.0.3#discriminator-object
+func (generator *generator) declareUnion(name, source string, schema *v303.Schema) {
+	if !generator.declare(name, source) || !generator.declare(name+"Value", source) {
+		return
+	}
+	generator.imports["encoding/json"] = true
+	generator.imports["fmt"] = true
+	property := schema.Discriminator.PropertyName
+	var members []string
+	values := map[string][]string{}
+	for _, member := range schema.OneOf {
+		component := unescape(member.Ref[len(schemaComponents):])
+		memberType := goName(component)
+		members = append(members, memberType)
+		for value, ref := range schema.Discriminator.Mapping {
+			if ref == member.Ref || ref == component {
+				values[memberType] = append(values[memberType], value)
+			}
+		}
+		if len(values[memberType]) == 0 {
+			values[memberType] = []string{component}
+		}
+		sort.Strings(values[memberType])
+	}
+	decls := &generator.decls
+	doc := comment(name, schema.Description)
+	if doc != "" {
+		doc += "//\n"
+	}
+	fmt.Fprintf(decls, "%s// %s holds one of %s, told apart by their %s property.\ntype %[2]s struct {\nValue %[2]sValue\n}\n\n",
+		doc, name, strings.Join(members, ", "), property)
+	fmt.Fprintf(decls, "// %sValue is implemented by the members of %s.\ntype %[1]sValue interface {\nis%[2]s()\n}\n\n", name, name)
+	for _, member := range members {
+		fmt.Fprintf(decls, "func (%s) is%s() {}\n\n", member, name)
+	}
+	fmt.Fprintf(decls, `// MarshalJSON encodes the value of the union.
+func (union %[1]s) MarshalJSON() ([]byte, error) {
+	return json.Marshal(union.Value)
+}
+
+// UnmarshalJSON decodes the member of the union named by the %[2]s property.
+func (union *%[1]s) UnmarshalJSON(data []byte) error {
+	var discriminator struct {
+		Value string `+"`json:%[2]q`"+`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+	switch discriminator.Value {
+`, name, property)
+	for _, member := range members {
+		quoted := make([]string, len(values[member]))
+		for i, value := range values[member] {
+			quoted[i] = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(decls, "case %s:\nvar value %s\nif err := json.Unmarshal(data, &value); err != nil {\nreturn err\n}\nunion.Value = value\n",
+			strings.Join(quoted, ", "), member)
+	}
+	fmt.Fprintf(decls, "default:\nreturn fmt.Errorf(\"%%q is not a %s of %s\", discriminator.Value)\n}\nreturn nil\n}\n\n", property, name)
+	if generator.validate {
+		fmt.Fprintf(decls, `// Validate checks the value of the union.
+func (union %s) Validate() error {
+	if union.Value == nil {
+		return fmt.Errorf("no value")
+	}
+	return union.Value.(interface{ Validate() error }).Validate()
+}
+
+`, name)
+	}
+}
+
+// pointer returns a pointer to goType, or goType itself when its zero value is nil already.
+func pointer(goType string) string {
+	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") || strings.HasPrefix(goType, "*") ||
+		goType == "interface{}" || goType == "json.RawMessage" {
+		return goType
+	}
+	return "*" + goType
+}
+
+// unescape unescapes a JSON pointer reference token.
+func unescape(token string) string {
+	return strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+}
@@ -0,0 +1,238 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// builtins are the predeclared Go types of primitive schemas.
+var builtins = map[string]bool{
+	"string": true, "bool": true, "int": true, "int32": true, "int64": true, "float32": true, "float64": true,
+}
+
+// isNamed reports whether goType is declared by the generator, and so has a Validate method.
+func isNamed(goType string) bool {
+	return goType != "" && !builtins[goType] && !strings.ContainsAny(goType, ".[]*{ ")
+}
+
+// validateStruct declares the Validate method of a struct, checking its embedded structs, the presence of its
+// required properties which are not nullable, and the constraints of the schemas of its fields.
+func (generator *generator) validateStruct(name string, embedded []string, fields []structField) {
+	checker := &checker{generator: generator}
+	var body strings.Builder
+	for _, embeddedType := range embedded {
+		fmt.Fprintf(&body, "if err := value.%s.Validate(); err != nil {\nreturn err\n}\n", embeddedType)
+	}
+	for _, field := range fields {
+		path := fmt.Sprintf("%q", field.property)
+		expr := "value." + field.name
+		nullable := field.schema != nil && field.schema.Ref == "" && field.schema.Value != nil && field.schema.Value.Nullable
+		// Fields are pointers unless their type has a nil value, an absent property leaves them nil.
+		if field.required && !nullable {
+			body.WriteString("if " + expr + " == nil {\n" + checker.errorf(path, "is required") + "}\n")
+		}
+		body.WriteString(checker.checks(expr, path, field.schema, field.goType, 0))
+	}
+	generator.writeValidate(name, "the constraints of the schema of "+name, body.String(), checker)
+}
+
+// validateType declares the Validate method of a defined type.
+func (generator *generator) validateType(name, goType string, schema *v303.SchemaRef) {
+	checker := &checker{generator: generator}
+	expr := "value"
+	if isNamed(goType) {
+		expr = goType + "(value)"
+	}
+	generator.writeValidate(name, "the constraints of the schema of "+name, checker.checks(expr, "", schema, goType, 0), checker)
+}
+
+// writeValidate writes a Validate method and the regular expressions it uses.
+func (generator *generator) writeValidate(name, checked, body string, checker *checker) {
+	fmt.Fprintf(&generator.decls, "// Validate checks %s.\nfunc (value %s) Validate() error {\n%sreturn nil\n}\n\n", checked, name, body)
+	if checker.patterns.Len() > 0 {
+		generator.imports["regexp"] = true
+		fmt.Fprintf(&generator.decls, "var (\n%s)\n\n", checker.patterns.String())
+	}
+}
+
+// checker writes the statements of a Validate method.
+type checker struct {
+	generator *generator
+	// patterns are the declarations of the regular expressions of the method.
+	patterns strings.Builder
+}
+
+// errorf returns the statement returning an error located at path, a Go expression which is empty at the root of the
+// value.
+func (checker *checker) errorf(path, message string) string {
+	checker.generator.imports["fmt"] = true
+	message = strings.Replace(message, "%", "%%", -1)
+	if path == "" {
+		return fmt.Sprintf("return fmt.Errorf(%q)\n", message)
+	}
+	return fmt.Sprintf("return fmt.Errorf(%q, %s)\n", "%s: "+message, path)
+}
+
+// checks returns the statements checking the constraints of schema on expr, a Go expression of type goType.
+// http://spec.openapis.org/oas/v3.0.3#properties
+func (checker *checker) checks(expr, path string, schema *v303.SchemaRef, goType string, depth int) string {
+	var checks strings.Builder
+	check := func(condition, message string) {
+		checks.WriteString("if " + condition + " {\n" + checker.errorf(path, message) + "}\n")
+	}
+	if strings.HasPrefix(goType, "*") {
+		inner := checker.checks("*"+expr, path, schema, goType[1:], depth)
+		if isNamed(goType[1:]) {
+			inner = checker.checks(expr, path, schema, goType[1:], depth)
+		}
+		if inner == "" {
+			return ""
+		}
+		return "if " + expr + " != nil {\n" + inner + "}\n"
+	}
+	if isNamed(goType) {
+		checker.generator.imports["fmt"] = true
+		wrap := "return err\n"
+		if path != "" {
+			wrap = fmt.Sprintf("return fmt.Errorf(\"%%s: %%w\", %s, err)\n", path)
+		}
+		return "if err := " + expr + ".Validate(); err != nil {\n" + wrap + "}\n"
+	}
+	if schema == nil || schema.Value == nil || schema.Ref != "" {
+		return ""
+	}
+	value := schema.Value
+	switch {
+	case goType == "string":
+		// A minimum of 0 holds for every string.
+		if value.MinLength != nil && *value.MinLength > 0 || value.MaxLength != nil {
+			checker.generator.imports["unicode/utf8"] = true
+		}
+		if value.MinLength != nil && *value.MinLength > 0 {
+			check(fmt.Sprintf("utf8.RuneCountInString(string(%s)) < %d", expr, *value.MinLength),
+				fmt.Sprintf("length must be at least %d", *value.MinLength))
+		}
+		if value.MaxLength != nil {
+			check(fmt.Sprintf("utf8.RuneCountInString(string(%s)) > %d", expr, *value.MaxLength),
+				fmt.Sprintf("length must be at most %d", *value.MaxLength))
+		}
+		if value.Pattern != "" {
+			if _, err := regexp.Compile(value.Pattern); err != nil {
+				fmt.Fprintf(&checks, "// The pattern %q is not supported by the regexp package.\n", value.Pattern)
+				break
+			}
+			checker.generator.patterns++
+			variable := fmt.Sprintf("pattern%d", checker.generator.patterns)
+			fmt.Fprintf(&checker.patterns, "%s = regexp.MustCompile(%q)\n", variable, value.Pattern)
+			check(fmt.Sprintf("!%s.MatchString(string(%s))", variable, expr), "must match "+value.Pattern)
+		}
+	case builtins[goType] && goType != "bool":
+		checkBound := func(bound json.Number, operator, message string) {
+			if bound == "" {
+				return
+			}
+			literal, converted, ok := numberLiteral(bound, goType)
+			switch {
+			case !ok:
+				fmt.Fprintf(&checks, "// The bound %q is not a number.\n", bound)
+			case converted:
+				check(fmt.Sprintf("float64(%s) %s %s", expr, operator, literal), fmt.Sprintf(message, bound))
+			default:
+				check(fmt.Sprintf("%s %s %s", expr, operator, literal), fmt.Sprintf(message, bound))
+			}
+		}
+		if value.ExclusiveMinimum {
+			checkBound(value.Minimum, "<=", "must be greater than %s")
+		} else {
+			checkBound(value.Minimum, "<", "must be at least %s")
+		}
+		if value.ExclusiveMaximum {
+			checkBound(value.Maximum, ">=", "must be less than %s")
+		} else {
+			checkBound(value.Maximum, ">", "must be at most %s")
+		}
+		if value.MultipleOf != "" {
+			literal, converted, ok := numberLiteral(value.MultipleOf, goType)
+			switch {
+			case !ok || !isPositive(value.MultipleOf):
+				fmt.Fprintf(&checks, "// The multipleOf %q is not a positive number.\n", value.MultipleOf)
+			case strings.HasPrefix(goType, "int") && !converted:
+				check(fmt.Sprintf("%s%%%s != 0", expr, literal), fmt.Sprintf("must be a multiple of %s", value.MultipleOf))
+			default:
+				// Floating point remainders drift for decimal multiples, such as 0.29 and 0.01.
+				checker.generator.imports["github.com/newm4n/swaggo/pkg/runtime"] = true
+				check(fmt.Sprintf("!runtime.IsMultipleOf(float64(%s), %q)", expr, value.MultipleOf),
+					fmt.Sprintf("must be a multiple of %s", value.MultipleOf))
+			}
+		}
+	case strings.HasPrefix(goType, "[]") && goType != "[]byte":
+		if value.MinItems != nil && *value.MinItems > 0 {
+			check(fmt.Sprintf("len(%s) < %d", expr, *value.MinItems), fmt.Sprintf("must have at least %d items", *value.MinItems))
+		}
+		if value.MaxItems != nil {
+			check(fmt.Sprintf("len(%s) > %d", expr, *value.MaxItems), fmt.Sprintf("must have at most %d items", *value.MaxItems))
+		}
+		index, element := fmt.Sprintf("i%d", depth), fmt.Sprintf("element%d", depth)
+		elementPath := fmt.Sprintf("\"[\" + strconv.Itoa(%s) + \"]\"", index)
+		if path != "" {
+			elementPath = path + " + " + elementPath
+		}
+		if inner := checker.checks(element, elementPath, value.Items, goType[2:], depth+1); inner != "" {
+			checker.generator.imports["strconv"] = true
+			fmt.Fprintf(&checks, "for %s, %s := range %s {\n%s}\n", index, element, expr, inner)
+		}
+	case strings.HasPrefix(goType, "map["):
+		if value.MinProperties != nil && *value.MinProperties > 0 {
+			check(fmt.Sprintf("len(%s) < %d", expr, *value.MinProperties), fmt.Sprintf("must have at least %d properties", *value.MinProperties))
+		}
+		if value.MaxProperties != nil {
+			check(fmt.Sprintf("len(%s) > %d", expr, *value.MaxProperties), fmt.Sprintf("must have at most %d properties", *value.MaxProperties))
+		}
+		if value.AdditionalProperties == nil || value.AdditionalProperties.Schema == nil {
+			break
+		}
+		key, element := fmt.Sprintf("key%d", depth), fmt.Sprintf("element%d", depth)
+		elementPath := fmt.Sprintf("\"[\" + strconv.Quote(%s) + \"]\"", key)
+		if path != "" {
+			elementPath = path + " + " + elementPath
+		}
+		valueType := strings.TrimPrefix(goType, "map[string]")
+		if inner := checker.checks(element, elementPath, value.AdditionalProperties.Schema, valueType, depth+1); inner != "" {
+			checker.generator.imports["strconv"] = true
+			fmt.Fprintf(&checks, "for %s, %s := range %s {\n%s}\n", key, element, expr, inner)
+		}
+	}
+	return checks.String()
+}
+
+// numberLiteral returns the Go literal of a JSON number compared with a value of goType. converted is true when the
+// value must be converted to float64 first, an integer type not holding the number, and ok is false when number is
+// not a JSON number.
+func numberLiteral(number json.Number, goType string) (literal string, converted bool, ok bool) {
+	rat, ok := new(big.Rat).SetString(string(number))
+	if !ok || strings.ContainsRune(string(number), '/') {
+		return "", false, false
+	}
+	if !strings.HasPrefix(goType, "int") {
+		return string(number), false, true
+	}
+	bits := 64
+	if goType == "int32" {
+		bits = 32
+	}
+	if rat.IsInt() && rat.Num().BitLen() < bits {
+		return rat.Num().String(), false, true
+	}
+	return string(number), true, true
+}
+
+// isPositive reports whether number is greater than zero.
+func isPositive(number json.Number) bool {
+	rat, ok := new(big.Rat).SetString(string(number))
+	return ok && rat.Sign() > 0
+}
@@ -0,0 +1,67 @@
+// Package middleware validates the requests served by net/http handlers, and their responses, against the operations
+// of an OpenAPI 3.0.3 document.
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of problem details.
+// https://tools.ietf.org/html/rfc7807#section-6.1
+const ProblemContentType = "application/problem+json"
+
+// Problem is the problem details answered to an invalid request, or replacing an invalid response.
+// https://tools.ietf.org/html/rfc7807#section-3
+type Problem struct {
+	Type   string `json:"type,omitempty"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Errors are the violations of the operation found in the request or the response.
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is a violation located by a JSON pointer into the request or the response, whose first token is the
+// location of the value: /path/id, /query/limit, /header/X-Request-Id, /cookie/session, /body/pets/0/name or the
+// /status of a response.
+type ProblemError struct {
+	Pointer string `json:"pointer"`
+	Detail  string `json:"detail"`
+}
+
+func (problem *Problem) Error() string {
+	details := make([]string, len(problem.Errors))
+	for i, problemError := range problem.Errors {
+		details[i] = problemError.Pointer + ": " + problemError.Detail
+	}
+	if len(details) == 0 {
+		return problem.Title
+	}
+	return problem.Title + ": " + strings.Join(details, "; ")
+}
+
+// newProblem returns the problem of status.
+func newProblem(status int, detail string, errors []ProblemError) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Errors: errors}
+}
+
+// WriteProblem answers problem.
+func WriteProblem(w http.ResponseWriter, problem *Problem) {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(data)
+}
+
+// pointerToken escapes a token of a JSON pointer.
+// https://tools.ietf.org/html/rfc6901#section-3
+func pointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
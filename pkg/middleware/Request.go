@@ -0,0 +1,414 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/runtime"
+)
+
+// ValidateRequest returns the middleware answering the problem details of a request which violates the operation of
+// spec it is matched to, and passing the other requests to the next handler.
+//
+// Requests are matched to a path of spec, below the path of one of its servers, and then to the operation of their
+// method: 404 Not Found and 405 Method Not Allowed are answered otherwise. Their parameters are decoded according to
+// their style and explode fields and checked against their schema, which are the constraints of the JSON types of
+// their values. The content type of their body must be one of the operation, 415 Unsupported Media Type is answered
+// otherwise, and JSON and form bodies are checked against the schema of their media type. The violations are reported
+// with 400 Bad Request, located by JSON pointers. Bodies are read up to MaxBodySize, 413 Request Entity Too Large is
+// answered for longer ones.
+//
+// The refs of spec are either resolved by a v303.Loader or local to its components, and spec must not be modified
+// while the middleware is in use.
+func ValidateRequest(spec *v303.OpenAPI, options ...RequestOption) func(http.Handler) http.Handler {
+	routes := newRoutes(spec)
+	requestOptions := requestOptions{maxBodySize: DefaultMaxBodySize}
+	for _, option := range options {
+		option(&requestOptions)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if problem := routes.validateRequest(w, r, requestOptions); problem != nil {
+				WriteProblem(w, problem)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// DefaultMaxBodySize is the size of the longest request body ValidateRequest reads, unless MaxBodySize sets another.
+const DefaultMaxBodySize = 10 << 20
+
+// RequestOption configures ValidateRequest.
+type RequestOption func(options *requestOptions)
+
+type requestOptions struct {
+	maxBodySize int64
+}
+
+// MaxBodySize sets the size in bytes of the longest request body which is read to be validated, longer ones being
+// answered with 413 Request Entity Too Large.
+func MaxBodySize(size int64) RequestOption {
+	return func(options *requestOptions) { options.maxBodySize = size }
+}
+
+// validateRequest returns the problem of r, nil when it is valid, setting the Allow header of the response header of
+// a method which is not allowed. The body of r is replaced with a copy.
+func (routes *routes) validateRequest(w http.ResponseWriter, r *http.Request, options requestOptions) *Problem {
+	header := w.Header()
+	route, variables := routes.match(r)
+	if route == nil {
+		return newProblem(http.StatusNotFound, fmt.Sprintf("no path of the document matches %s", r.URL.Path), nil)
+	}
+	operation := operation(route.pathItem, r.Method)
+	if operation == nil {
+		header.Set("Allow", strings.Join(allowed(route.pathItem), ", "))
+		return newProblem(http.StatusMethodNotAllowed, fmt.Sprintf("%s has no %s operation", route.path, r.Method), nil)
+	}
+	checker := &checker{routes: routes, request: true}
+	for _, parameter := range routes.parameters(route.pathItem, operation) {
+		checker.checkParameter(parameter, r, variables)
+	}
+	if problem := checker.checkBody(routes.resolveRequestBody(operation.RequestBody), w, r, options.maxBodySize); problem != nil {
+		return problem
+	}
+	if len(checker.errors) > 0 {
+		return newProblem(http.StatusBadRequest, "the request violates the operation "+operationName(operation, r.Method, route.path), checker.errors)
+	}
+	return nil
+}
+
+// operationName returns the operationId of an operation, its method and path when it has none.
+func operationName(operation *v303.Operation, method, path string) string {
+	if operation.OperationID != "" {
+		return operation.OperationID
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+// jsonNumber matches the numbers of JSON.
+// https://tools.ietf.org/html/rfc8259#section-6
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// ignoredHeaders are the header parameters which are ignored.
+// http://spec.openapis.org/oas/v3.0.3#fixed-fields-10
+var ignoredHeaders = map[string]bool{"Accept": true, "Content-Type": true, "Authorization": true}
+
+// checkParameter reports the violations of parameter by r.
+// http://spec.openapis.org/oas/v3.0.3#parameter-object
+func (checker *checker) checkParameter(parameter *v303.Parameter, r *http.Request, variables map[string]string) {
+	if parameter.In == "header" && ignoredHeaders[http.CanonicalHeaderKey(parameter.Name)] {
+		return
+	}
+	pointer := "/" + parameter.In + "/" + pointerToken(parameter.Name)
+	var value interface{}
+	var err error
+	var schemaRef *v303.SchemaRef
+	if parameter.Schema != nil {
+		schemaRef = parameter.Schema
+		value, err = checker.bindParameter(parameter, r, variables)
+	} else {
+		for mediaType, content := range parameter.Content {
+			schemaRef = content.Schema
+			value, err = rawParameter(parameter, r, variables)
+			if err == nil && isJSON(mediaType) {
+				if value, err = decodeJSON([]byte(value.(string))); err != nil {
+					checker.errorf(pointer, "is not valid JSON: %v", err)
+					return
+				}
+			}
+			break
+		}
+	}
+	if errors.Is(err, runtime.ErrMissing) {
+		if parameter.Required || parameter.In == "path" {
+			checker.errorf(pointer, "is required")
+		}
+		return
+	}
+	if err != nil {
+		var parameterError *runtime.ParameterError
+		if errors.As(err, &parameterError) {
+			err = parameterError.Err
+		}
+		checker.errorf(pointer, "%v", err)
+		return
+	}
+	if value == "" && parameter.In == "query" && !parameter.AllowEmptyValue {
+		checker.errorf(pointer, "must not be empty")
+		return
+	}
+	checker.check(schemaRef, value, pointer)
+}
+
+// bindParameter returns the value of a parameter described by a schema, decoded according to its style and converted
+// to the types of its schema.
+func (checker *checker) bindParameter(parameter *v303.Parameter, r *http.Request, variables map[string]string) (interface{}, error) {
+	style := parameter.Style
+	if style == "" {
+		style = "simple"
+		if parameter.In == "query" || parameter.In == "cookie" {
+			style = "form"
+		}
+	}
+	explode := style == "form"
+	if parameter.Explode != nil {
+		explode = *parameter.Explode
+	}
+	schema := checker.routes.resolveSchema(parameter.Schema)
+	var dest interface{}
+	switch {
+	case schema != nil && schema.Type == "array":
+		dest = &[]string{}
+	case schema != nil && (schema.Type == "object" || schema.Type == "" && len(schema.Properties) > 0):
+		dest = &map[string]string{}
+	default:
+		dest = new(string)
+	}
+	var err error
+	switch parameter.In {
+	case "path":
+		var value string
+		if value, err = url.PathUnescape(variables[parameter.Name]); err == nil {
+			err = runtime.BindPath(parameter.Name, style, explode, value, dest)
+		}
+	case "query":
+		err = runtime.BindQuery(parameter.Name, style, explode, true, r.URL.Query(), dest)
+	case "header":
+		err = runtime.BindHeader(parameter.Name, explode, true, r.Header, dest)
+	case "cookie":
+		err = runtime.BindCookie(parameter.Name, explode, true, r, dest)
+	default:
+		return nil, runtime.ErrMissing
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch dest := dest.(type) {
+	case *[]string:
+		values := make([]interface{}, len(*dest))
+		for i, value := range *dest {
+			values[i] = checker.convert(schema.Items, value)
+		}
+		return values, nil
+	case *map[string]string:
+		members := map[string]interface{}{}
+		for name, value := range *dest {
+			propertyRef, ok := schema.Properties[name]
+			// The members of an exploded form object are the query parameters, which other parameters share.
+			if !ok && parameter.In == "query" && explode && style == "form" && len(schema.Properties) > 0 {
+				continue
+			}
+			if !ok {
+				propertyRef = additionalSchema(schema)
+			}
+			members[name] = checker.convert(propertyRef, value)
+		}
+		return members, nil
+	}
+	return checker.convert(parameter.Schema, *dest.(*string)), nil
+}
+
+// convert returns value converted to the type of schemaRef, which is optional. A value which cannot
+// be converted is left as a string, the schema reporting its type.
+func (checker *checker) convert(schemaRef *v303.SchemaRef, value string) interface{} {
+	schema := checker.routes.resolveSchema(schemaRef)
+	if schema == nil {
+		return value
+	}
+	switch schema.Type {
+	case "integer", "number":
+		if jsonNumber.MatchString(value) {
+			return json.Number(value)
+		}
+	case "boolean":
+		switch value {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	}
+	return value
+}
+
+// rawParameter returns the value of a parameter described by a content, as it is sent.
+func rawParameter(parameter *v303.Parameter, r *http.Request, variables map[string]string) (interface{}, error) {
+	switch parameter.In {
+	case "path":
+		if value, ok := variables[parameter.Name]; ok && value != "" {
+			return url.PathUnescape(value)
+		}
+	case "query":
+		if values, ok := r.URL.Query()[parameter.Name]; ok && len(values) > 0 {
+			return values[0], nil
+		}
+	case "header":
+		if values := r.Header.Values(parameter.Name); len(values) > 0 {
+			return values[0], nil
+		}
+	case "cookie":
+		if cookie, err := r.Cookie(parameter.Name); err == nil {
+			return url.QueryUnescape(cookie.Value)
+		}
+	}
+	return nil, runtime.ErrMissing
+}
+
+// checkBody reports the violations of requestBody by the body of r, or returns the problem of a body whose content
+// type is not one of requestBody or which is longer than maxBodySize.
+// http://spec.openapis.org/oas/v3.0.3#request-body-object
+func (checker *checker) checkBody(requestBody *v303.RequestBody, w http.ResponseWriter, r *http.Request, maxBodySize int64) *Problem {
+	if requestBody == nil {
+		return nil
+	}
+	tooLarge := newProblem(http.StatusRequestEntityTooLarge,
+		fmt.Sprintf("the request body is longer than %d bytes", maxBodySize), nil)
+	if r.ContentLength > maxBodySize {
+		return tooLarge
+	}
+	var data []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		if data, err = ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize)); err != nil {
+			// http.MaxBytesReader fails once it has read maxBodySize bytes.
+			if int64(len(data)) >= maxBodySize {
+				return tooLarge
+			}
+			return newProblem(http.StatusBadRequest, "the request body cannot be read: "+err.Error(), nil)
+		}
+		_ = r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+	if len(data) == 0 {
+		if requestBody.Required {
+			checker.errorf("/body", "is required")
+		}
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	mediaType, mediaTypeParameters, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return newProblem(http.StatusUnsupportedMediaType, fmt.Sprintf("the content type %q is invalid", contentType), nil)
+	}
+	content := matchMediaType(requestBody.Content, mediaType)
+	if content == nil {
+		return newProblem(http.StatusUnsupportedMediaType,
+			fmt.Sprintf("the content type %s is not one of %s", mediaType, strings.Join(mediaTypes(requestBody.Content), ", ")), nil)
+	}
+	if content.Schema == nil {
+		return nil
+	}
+	switch {
+	case isJSON(mediaType):
+		value, err := decodeJSON(data)
+		if err != nil {
+			checker.errorf("/body", "is not valid JSON: %v", err)
+			return nil
+		}
+		checker.check(content.Schema, value, "/body")
+	case mediaType == "application/x-www-form-urlencoded" &&
+		(mediaTypeParameters["charset"] == "" || strings.EqualFold(mediaTypeParameters["charset"], "utf-8")):
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			checker.errorf("/body", "is not a valid form: %v", err)
+			return nil
+		}
+		checker.check(content.Schema, checker.formObject(content.Schema, form), "/body")
+	}
+	return nil
+}
+
+// formObject returns the object of the fields of a form, converted to the types of the properties of schemaRef.
+func (checker *checker) formObject(schemaRef *v303.SchemaRef, form url.Values) map[string]interface{} {
+	object := map[string]interface{}{}
+	schema := checker.routes.resolveSchema(schemaRef)
+	for name, values := range form {
+		var propertyRef *v303.SchemaRef
+		if schema != nil {
+			ok := false
+			if propertyRef, ok = schema.Properties[name]; !ok {
+				propertyRef = additionalSchema(schema)
+			}
+		}
+		property := checker.routes.resolveSchema(propertyRef)
+		if property != nil && property.Type == "array" {
+			elements := make([]interface{}, len(values))
+			for i, value := range values {
+				elements[i] = checker.convert(property.Items, value)
+			}
+			object[name] = elements
+			continue
+		}
+		object[name] = checker.convert(propertyRef, values[0])
+	}
+	return object
+}
+
+// additionalSchema returns the schema of the properties of schema which are not listed in its properties, or nil.
+func additionalSchema(schema *v303.Schema) *v303.SchemaRef {
+	if schema.AdditionalProperties == nil {
+		return nil
+	}
+	return schema.AdditionalProperties.Schema
+}
+
+// isJSON reports whether a media type is JSON, such as application/json or application/problem+json.
+func isJSON(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers as json.Number.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("data after the value")
+	}
+	return value, nil
+}
+
+// matchMediaType returns the media type of content which matches mediaType most specifically: the media type
+// itself, then type/* and */*.
+// http://spec.openapis.org/oas/v3.0.3#fixed-fields-10
+func matchMediaType(content map[string]*v303.MediaType, mediaType string) *v303.MediaType {
+	mediaType = strings.ToLower(mediaType)
+	ranges := []string{mediaType, strings.Split(mediaType, "/")[0] + "/*", "*/*"}
+	for _, mediaRange := range ranges {
+		for key, value := range content {
+			if parsed, _, err := mime.ParseMediaType(key); err == nil && strings.ToLower(parsed) == mediaRange {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// mediaTypes returns the sorted media types of content.
+func mediaTypes(content map[string]*v303.MediaType) []string {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
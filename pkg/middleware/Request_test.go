@@ -0,0 +1,267 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// petsSpec is the document the tests validate requests and responses against.
+const petsSpec = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1.0.0"},
+	"servers": [{"url": "https://pets.example.com/v1"}],
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "listPets",
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}},
+					{"name": "tags", "in": "query", "explode": false, "schema": {"type": "array", "items": {"type": "string", "enum": ["cat", "dog"]}}},
+					{"name": "filter", "in": "query", "style": "deepObject", "explode": true,
+						"schema": {"type": "object", "properties": {"age": {"type": "integer"}}}},
+					{"name": "X-Request-Id", "in": "header", "required": true, "schema": {"type": "string", "format": "uuid"}},
+					{"name": "session", "in": "cookie", "schema": {"type": "string", "minLength": 4}}
+				],
+				"responses": {
+					"200": {
+						"description": "pets",
+						"headers": {"X-Total": {"required": true, "schema": {"type": "integer"}}},
+						"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}
+					},
+					"4XX": {"description": "client error", "content": {"application/problem+json": {"schema": {"type": "object"}}}},
+					"default": {"description": "unexpected error"}
+				}
+			},
+			"post": {
+				"operationId": "addPet",
+				"requestBody": {
+					"required": true,
+					"content": {
+						"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}},
+						"application/x-www-form-urlencoded": {"schema": {"$ref": "#/components/schemas/Pet"}}
+					}
+				},
+				"responses": {"201": {"description": "created"}}
+			}
+		},
+		"/pets/{ids}": {
+			"get": {
+				"operationId": "getPets",
+				"parameters": [
+					{"name": "ids", "in": "path", "required": true, "schema": {"type": "array", "items": {"type": "integer"}}}
+				],
+				"responses": {"200": {"description": "pets"}}
+			}
+		},
+		"/pets/{id}/label": {
+			"get": {
+				"operationId": "getLabel",
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "style": "label", "schema": {"type": "integer"}}
+				],
+				"responses": {"200": {"description": "label"}}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"id": {"type": "integer", "readOnly": true},
+					"name": {"type": "string", "maxLength": 5},
+					"age": {"type": "integer", "minimum": 0}
+				}
+			}
+		}
+	}
+}`
+
+func loadPetsSpec(t *testing.T) *v303.OpenAPI {
+	t.Helper()
+	var spec v303.OpenAPI
+	if err := json.Unmarshal([]byte(petsSpec), &spec); err != nil {
+		t.Fatal(err)
+	}
+	return &spec
+}
+
+// serve sends r through the request validation of spec and returns the response, along with the body the next
+// handler read, nil when it was not called.
+func serve(t *testing.T, spec *v303.OpenAPI, r *http.Request, options ...RequestOption) (*httptest.ResponseRecorder, []byte) {
+	t.Helper()
+	var received []byte
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = []byte{}
+		if r.Body != nil {
+			received, _ = ioutil.ReadAll(r.Body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	w := httptest.NewRecorder()
+	ValidateRequest(spec, options...)(next).ServeHTTP(w, r)
+	return w, received
+}
+
+// problemOf decodes the problem details of a response.
+func problemOf(t *testing.T, w *httptest.ResponseRecorder) *Problem {
+	t.Helper()
+	if contentType := w.Header().Get("Content-Type"); contentType != ProblemContentType {
+		t.Fatalf("Content-Type = %q, want %s; body %s", contentType, ProblemContentType, w.Body)
+	}
+	var problem Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	return &problem
+}
+
+func TestValidateRequestParameters(t *testing.T) {
+	spec := loadPetsSpec(t)
+	const requestID = "0b5e5f0e-4c36-4a8f-9f3c-2b8d0c6a1e52"
+	tests := []struct {
+		name    string
+		target  string
+		header  map[string]string
+		cookie  string
+		status  int
+		want    []ProblemError
+		wantAll bool
+	}{
+		{name: "valid", target: "/v1/pets?limit=10&tags=cat,dog&filter[age]=3", header: map[string]string{"X-Request-Id": requestID},
+			cookie: "abcd", status: http.StatusNoContent},
+		{name: "query form", target: "/v1/pets?limit=ten", header: map[string]string{"X-Request-Id": requestID},
+			status: http.StatusBadRequest, want: []ProblemError{{"/query/limit", "must be of type integer"}}},
+		{name: "query maximum", target: "/v1/pets?limit=101", header: map[string]string{"X-Request-Id": requestID},
+			status: http.StatusBadRequest, want: []ProblemError{{"/query/limit", "must be at most 100"}}},
+		{name: "query form not exploded", target: "/v1/pets?tags=cat,bird", header: map[string]string{"X-Request-Id": requestID},
+			status: http.StatusBadRequest, want: []ProblemError{{"/query/tags/1", "must be one of the values of the enum"}}},
+		{name: "query deepObject", target: "/v1/pets?filter[age]=old", header: map[string]string{"X-Request-Id": requestID},
+			status: http.StatusBadRequest, want: []ProblemError{{"/query/filter/age", "must be of type integer"}}},
+		{name: "missing header", target: "/v1/pets", status: http.StatusBadRequest,
+			want: []ProblemError{{"/header/X-Request-Id", "is required"}}},
+		{name: "header format", target: "/v1/pets", header: map[string]string{"X-Request-Id": "42"},
+			status: http.StatusBadRequest, want: []ProblemError{{"/header/X-Request-Id", "must be a valid uuid"}}},
+		{name: "cookie", target: "/v1/pets", header: map[string]string{"X-Request-Id": requestID}, cookie: "abc",
+			status: http.StatusBadRequest, want: []ProblemError{{"/cookie/session", "length must be at least 4"}}},
+		{name: "path simple", target: "/v1/pets/1,2,x", status: http.StatusBadRequest,
+			want: []ProblemError{{"/path/ids/2", "must be of type integer"}}},
+		{name: "path simple valid", target: "/v1/pets/1,2,3", status: http.StatusNoContent},
+		{name: "path label", target: "/v1/pets/.7/label", status: http.StatusNoContent},
+		{name: "path label invalid", target: "/v1/pets/7/label", status: http.StatusBadRequest},
+		{name: "not found", target: "/pets", status: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+			for name, value := range test.header {
+				r.Header.Set(name, value)
+			}
+			if test.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "session", Value: test.cookie})
+			}
+			w, _ := serve(t, spec, r)
+			if w.Code != test.status {
+				t.Fatalf("status = %d, want %d; body %s", w.Code, test.status, w.Body)
+			}
+			if test.want == nil {
+				return
+			}
+			if problem := problemOf(t, w); !reflect.DeepEqual(problem.Errors, test.want) {
+				t.Errorf("errors = %v, want %v", problem.Errors, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateRequestMethodNotAllowed(t *testing.T) {
+	w, _ := serve(t, loadPetsSpec(t), httptest.NewRequest(http.MethodDelete, "/v1/pets", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", w.Code)
+	}
+	if allow := w.Header().Get("Allow"); allow != "GET, POST" {
+		t.Errorf("Allow = %q, want GET, POST", allow)
+	}
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	spec := loadPetsSpec(t)
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		want        []ProblemError
+	}{
+		{name: "valid", contentType: "application/json", body: `{"name": "rex", "age": 3}`, status: http.StatusNoContent},
+		{name: "valid form", contentType: "application/x-www-form-urlencoded", body: "name=rex&age=3", status: http.StatusNoContent},
+		{name: "missing", contentType: "application/json", status: http.StatusBadRequest,
+			want: []ProblemError{{"/body", "is required"}}},
+		{name: "wrong content type", contentType: "text/plain", body: "rex", status: http.StatusUnsupportedMediaType},
+		{name: "invalid JSON", contentType: "application/json", body: `{"name":`, status: http.StatusBadRequest},
+		{name: "schema", contentType: "application/json; charset=utf-8", body: `{"id": 1, "name": "rexford", "age": -1}`,
+			status: http.StatusBadRequest, want: []ProblemError{
+				{"/body/age", "must be at least 0"},
+				{"/body/id", "is read only"},
+				{"/body/name", "length must be at most 5"},
+			}},
+		{name: "form schema", contentType: "application/x-www-form-urlencoded", body: "age=x", status: http.StatusBadRequest,
+			want: []ProblemError{{"/body/name", "is required"}, {"/body/age", "must be of type integer"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(test.body))
+			r.Header.Set("Content-Type", test.contentType)
+			w, received := serve(t, spec, r)
+			if w.Code != test.status {
+				t.Fatalf("status = %d, want %d; body %s", w.Code, test.status, w.Body)
+			}
+			if w.Code == http.StatusNoContent && string(received) != test.body {
+				t.Errorf("the next handler read %q, want %q", received, test.body)
+			}
+			if w.Code != http.StatusNoContent {
+				problem := problemOf(t, w)
+				if problem.Status != test.status || problem.Title != http.StatusText(test.status) {
+					t.Errorf("problem = %+v, want the status %d", problem, test.status)
+				}
+				if test.want != nil && !reflect.DeepEqual(problem.Errors, test.want) {
+					t.Errorf("errors = %v, want %v", problem.Errors, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRequestBodyTooLarge(t *testing.T) {
+	spec := loadPetsSpec(t)
+	body := `{"name": "rex", "age": 3}`
+	for _, contentLength := range []bool{true, false} {
+		r := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		if !contentLength {
+			// A chunked body is only limited while it is read.
+			r.ContentLength = -1
+		}
+		w, received := serve(t, spec, r, MaxBodySize(10))
+		if w.Code != http.StatusRequestEntityTooLarge || received != nil {
+			t.Errorf("status = %d, want 413 without calling the next handler", w.Code)
+			continue
+		}
+		if problem := problemOf(t, w); problem.Status != http.StatusRequestEntityTooLarge {
+			t.Errorf("problem = %+v, want the status 413", problem)
+		}
+	}
+	r := httptest.NewRequest(http.MethodPost, "/v1/pets", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if w, _ := serve(t, spec, r, MaxBodySize(int64(len(body)))); w.Code != http.StatusNoContent {
+		t.Errorf("status = %d for a body of the maximum size, want 204; body %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,331 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// ResponseMode is what a ResponseValidator does with the responses violating their operation.
+type ResponseMode int
+
+const (
+	// LogResponses sends the responses unchanged and logs their violations, for production.
+	LogResponses ResponseMode = iota
+	// StrictResponses replaces the responses with 500 Internal Server Error problem details listing their violations,
+	// so that the tests of a handler fail.
+	StrictResponses
+)
+
+// ResponseValidator checks the responses of a handler against the operations of a document, for contract testing:
+// their status must be a response of the operation, exactly or in a range such as 2XX, or else the operation must
+// have a default response. The headers of the response must then be sent and match their schema, and its body must
+// have one of its content types and match the schema of that media type when it is JSON.
+type ResponseValidator struct {
+	// Mode is what is done with the responses violating their operation.
+	Mode ResponseMode
+	// Logf logs the violations in LogResponses mode, log.Printf when nil.
+	Logf func(format string, args ...interface{})
+	// MaxBodySize is the size of the longest body recorded in LogResponses mode, DefaultMaxBodySize when 0. The
+	// longer bodies are sent without checking them against their schema.
+	MaxBodySize int64
+	routes      *routes
+}
+
+// NewResponseValidator returns the ResponseValidator of spec in mode. The refs of spec are either resolved by a
+// v303.Loader or local to its components, and spec must not be modified while the validator is in use.
+func NewResponseValidator(spec *v303.OpenAPI, mode ResponseMode) *ResponseValidator {
+	return &ResponseValidator{Mode: mode, routes: newRoutes(spec)}
+}
+
+// Handler returns the handler checking the responses of next. Requests which match no operation of the document
+// are not checked, nor are the responses of hijacked connections.
+//
+// In LogResponses mode, only the bodies which are checked against a schema are recorded, up to MaxBodySize, and
+// flushing the response writer flushes the response. In StrictResponses mode, the whole response is buffered until
+// next returns, so that it can be replaced, and flushing has no effect.
+func (validator *ResponseValidator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, _ := validator.routes.match(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		operation := operation(route.pathItem, r.Method)
+		if operation == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		recorder := &responseRecorder{w: w, buffer: validator.Mode == StrictResponses, maxBodySize: validator.MaxBodySize}
+		if recorder.buffer {
+			recorder.header = http.Header{}
+		}
+		if recorder.maxBodySize <= 0 {
+			recorder.maxBodySize = DefaultMaxBodySize
+		}
+		recorder.checksBody = func(contentType string) bool {
+			return validator.checksBody(operation, r, recorder.status, contentType)
+		}
+		next.ServeHTTP(recorder, r)
+		if recorder.hijacked {
+			return
+		}
+		if !recorder.wroteHeader {
+			recorder.WriteHeader(http.StatusOK)
+		}
+		header := recorder.Header()
+		if recorder.contentType != "" {
+			header = header.Clone()
+			header.Set("Content-Type", recorder.contentType)
+		}
+		problem := validator.check(r, recorder.status, header, &responseBody{data: recorder.body.Bytes(),
+			length: recorder.length, recorded: recorder.recorded()})
+		switch {
+		case problem != nil && validator.Mode == StrictResponses:
+			WriteProblem(w, problem)
+		case problem != nil:
+			logf := validator.Logf
+			if logf == nil {
+				logf = log.Printf
+			}
+			logf("%s %s: %v", r.Method, r.URL.Path, problem)
+		case recorder.buffer:
+			recorder.flush()
+		}
+	})
+}
+
+// Check returns the violations of the operation r is matched to by a response, nil when the response is valid or when
+// r matches no operation.
+func (validator *ResponseValidator) Check(r *http.Request, status int, header http.Header, body []byte) *Problem {
+	return validator.check(r, status, header, &responseBody{data: body, length: int64(len(body)), recorded: true})
+}
+
+// responseBody is the body of a response, of which only the length is known when it was not recorded.
+type responseBody struct {
+	data     []byte
+	length   int64
+	recorded bool
+}
+
+func (validator *ResponseValidator) check(r *http.Request, status int, header http.Header, body *responseBody) *Problem {
+	route, _ := validator.routes.match(r)
+	if route == nil {
+		return nil
+	}
+	operation := operation(route.pathItem, r.Method)
+	if operation == nil {
+		return nil
+	}
+	checker := &checker{routes: validator.routes}
+	if response := validator.response(operation, status); response == nil {
+		checker.errorf("/status", "%d is not a response of the operation", status)
+	} else {
+		checker.checkResponse(response, r, status, header, body)
+	}
+	if len(checker.errors) == 0 {
+		return nil
+	}
+	return newProblem(http.StatusInternalServerError,
+		"the response violates the operation "+operationName(operation, r.Method, route.path), checker.errors)
+}
+
+// response returns the response of operation for status: the response of the status code, then of its range, then
+// the default response.
+// http://spec.openapis.org/oas/v3.0.3#patterned-fields-0
+func (validator *ResponseValidator) response(operation *v303.Operation, status int) *v303.Response {
+	code := strconv.Itoa(status)
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if responseRef, ok := operation.Responses[key]; ok {
+			return validator.routes.resolveResponse(responseRef)
+		}
+	}
+	return nil
+}
+
+// checksBody reports whether the body of a response of operation with status and contentType is checked against a
+// schema, and must be recorded.
+func (validator *ResponseValidator) checksBody(operation *v303.Operation, r *http.Request, status int, contentType string) bool {
+	if r.Method == http.MethodHead || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	response := validator.response(operation, status)
+	if response == nil {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !isJSON(mediaType) {
+		return false
+	}
+	content := matchMediaType(response.Content, mediaType)
+	return content != nil && content.Schema != nil
+}
+
+// checkResponse reports the violations of response by the headers and the body of a response.
+// http://spec.openapis.org/oas/v3.0.3#response-object
+func (checker *checker) checkResponse(response *v303.Response, r *http.Request, status int, header http.Header, body *responseBody) {
+	for name, headerRef := range response.Headers {
+		// A Content-Type header is ignored, the content describes it.
+		if strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		value := checker.routes.resolveHeader(headerRef)
+		if value == nil {
+			continue
+		}
+		// A header is checked as a header parameter of a request holding the headers of the response.
+		checker.checkParameter(&v303.Parameter{Name: name, In: "header", Required: value.Required, Style: value.Style,
+			Explode: value.Explode, Schema: value.Schema, Content: value.Content}, &http.Request{Header: header}, nil)
+	}
+	if r.Method == http.MethodHead || status == http.StatusNoContent || status == http.StatusNotModified {
+		return
+	}
+	if len(response.Content) == 0 {
+		if body.length > 0 {
+			checker.errorf("/body", "must be empty")
+		}
+		return
+	}
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		// The content type net/http sends.
+		contentType = http.DetectContentType(body.data)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		checker.errorf("/header/Content-Type", "%q is invalid", contentType)
+		return
+	}
+	content := matchMediaType(response.Content, mediaType)
+	if content == nil {
+		checker.errorf("/header/Content-Type", "%s is not one of %s", mediaType, strings.Join(mediaTypes(response.Content), ", "))
+		return
+	}
+	if content.Schema == nil || !isJSON(mediaType) || !body.recorded {
+		return
+	}
+	value, err := decodeJSON(body.data)
+	if err != nil {
+		checker.errorf("/body", "is not valid JSON: %v", err)
+		return
+	}
+	checker.check(content.Schema, value, "/body")
+}
+
+// responseRecorder records the response of a handler, sending it as it is written unless it is buffered. The body of
+// a response which is sent is only recorded when checksBody reports it is checked, up to maxBodySize.
+type responseRecorder struct {
+	w      http.ResponseWriter
+	buffer bool
+	// header is the header of a buffered response.
+	header      http.Header
+	status      int
+	wroteHeader bool
+	// contentType is the content type sniffed from the first write of a body sent without a Content-Type header.
+	contentType string
+	checksBody  func(contentType string) bool
+	maxBodySize int64
+	// capture is whether the body is recorded, decided on its first write, and truncated whether it was longer than
+	// maxBodySize.
+	capture   bool
+	truncated bool
+	length    int64
+	body      bytes.Buffer
+	hijacked  bool
+}
+
+func (recorder *responseRecorder) Header() http.Header {
+	if recorder.buffer {
+		return recorder.header
+	}
+	return recorder.w.Header()
+}
+
+func (recorder *responseRecorder) WriteHeader(status int) {
+	if recorder.wroteHeader {
+		return
+	}
+	recorder.status, recorder.wroteHeader = status, true
+	if !recorder.buffer {
+		recorder.w.WriteHeader(status)
+	}
+}
+
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	if !recorder.wroteHeader {
+		if recorder.Header().Get("Content-Type") == "" {
+			recorder.Header().Set("Content-Type", http.DetectContentType(data))
+		}
+		recorder.WriteHeader(http.StatusOK)
+	}
+	if recorder.length == 0 && len(data) > 0 {
+		contentType := recorder.Header().Get("Content-Type")
+		if contentType == "" {
+			// The content type net/http sends for a header written before it was set.
+			contentType = http.DetectContentType(data)
+			recorder.contentType = contentType
+		}
+		recorder.capture = recorder.buffer || recorder.checksBody(contentType)
+	}
+	recorder.length += int64(len(data))
+	if recorder.buffer {
+		recorder.body.Write(data)
+		return len(data), nil
+	}
+	if recorder.capture && !recorder.truncated {
+		if recorder.length > recorder.maxBodySize {
+			recorder.truncated = true
+			recorder.body = bytes.Buffer{}
+		} else {
+			recorder.body.Write(data)
+		}
+	}
+	return recorder.w.Write(data)
+}
+
+// recorded reports whether the whole body was recorded.
+func (recorder *responseRecorder) recorded() bool {
+	return recorder.length == 0 || recorder.capture && !recorder.truncated
+}
+
+// Flush sends the response written so far, unless it is buffered.
+func (recorder *responseRecorder) Flush() {
+	if recorder.buffer {
+		return
+	}
+	if !recorder.wroteHeader {
+		recorder.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := recorder.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hands the connection over to the handler, whose response is then not checked.
+func (recorder *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := recorder.w.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		recorder.hijacked = true
+	}
+	return conn, rw, err
+}
+
+// flush sends a buffered response.
+func (recorder *responseRecorder) flush() {
+	for name, values := range recorder.header {
+		recorder.w.Header()[name] = values
+	}
+	recorder.w.WriteHeader(recorder.status)
+	_, _ = recorder.w.Write(recorder.body.Bytes())
+}
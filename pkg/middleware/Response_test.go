@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// respond returns the handler writing a response with status, header and body.
+func respond(status int, header map[string]string, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range header {
+			w.Header().Set(name, value)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestResponseValidatorStrict(t *testing.T) {
+	validator := NewResponseValidator(loadPetsSpec(t), StrictResponses)
+	jsonHeader := map[string]string{"Content-Type": "application/json", "X-Total": "1"}
+	tests := []struct {
+		name   string
+		method string
+		status int
+		header map[string]string
+		body   string
+		want   []ProblemError
+	}{
+		{name: "valid", status: http.StatusOK, header: jsonHeader, body: `[{"id": 1, "name": "rex"}]`},
+		{name: "missing header", status: http.StatusOK, header: map[string]string{"Content-Type": "application/json"},
+			body: `[]`, want: []ProblemError{{"/header/X-Total", "is required"}}},
+		{name: "header schema", status: http.StatusOK, header: map[string]string{"Content-Type": "application/json", "X-Total": "one"},
+			body: `[]`, want: []ProblemError{{"/header/X-Total", "must be of type integer"}}},
+		{name: "body schema", status: http.StatusOK, header: jsonHeader, body: `[{"name": "rexford"}]`,
+			want: []ProblemError{{"/body/0/name", "length must be at most 5"}}},
+		{name: "invalid JSON", status: http.StatusOK, header: jsonHeader, body: `[`,
+			want: []ProblemError{{"/body", "is not valid JSON: unexpected EOF"}}},
+		{name: "content type", status: http.StatusOK, header: map[string]string{"X-Total": "1"}, body: "rex",
+			want: []ProblemError{{"/header/Content-Type", "text/plain is not one of application/json"}}},
+		{name: "range", status: http.StatusNotFound, header: map[string]string{"Content-Type": ProblemContentType}, body: `{}`},
+		{name: "range content type", status: http.StatusNotFound, header: map[string]string{"Content-Type": "application/json"},
+			body: `{}`, want: []ProblemError{{"/header/Content-Type", "application/json is not one of application/problem+json"}}},
+		{name: "range schema", status: http.StatusConflict, header: map[string]string{"Content-Type": ProblemContentType},
+			body: `[]`, want: []ProblemError{{"/body", "must be of type object"}}},
+		{name: "default", status: http.StatusServiceUnavailable},
+		{name: "default body", status: http.StatusServiceUnavailable, body: "down", want: []ProblemError{{"/body", "must be empty"}}},
+		{name: "status", method: http.MethodPost, status: http.StatusOK,
+			want: []ProblemError{{"/status", "200 is not a response of the operation"}}},
+		{name: "created", method: http.MethodPost, status: http.StatusCreated},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			w := httptest.NewRecorder()
+			validator.Handler(respond(test.status, test.header, test.body)).ServeHTTP(w, httptest.NewRequest(method, "/v1/pets", nil))
+			if test.want != nil {
+				if w.Code != http.StatusInternalServerError {
+					t.Fatalf("status = %d, want 500", w.Code)
+				}
+				if problem := problemOf(t, w); !reflect.DeepEqual(problem.Errors, test.want) {
+					t.Errorf("errors = %v, want %v", problem.Errors, test.want)
+				}
+				return
+			}
+			if w.Code != test.status || w.Body.String() != test.body {
+				t.Fatalf("response = %d %q, want %d %q", w.Code, w.Body, test.status, test.body)
+			}
+			for name, value := range test.header {
+				if got := w.Header().Get(name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseValidatorLog(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		header      map[string]string
+		body        string
+		maxBodySize int64
+		want        string
+	}{
+		{name: "valid", status: http.StatusOK, header: map[string]string{"Content-Type": "application/json", "X-Total": "1"},
+			body: `[{"name": "rex"}]`},
+		{name: "invalid", status: http.StatusOK, header: map[string]string{"Content-Type": "application/json"}, body: `[{}]`,
+			want: "GET /v1/pets: Internal Server Error: /header/X-Total: is required; /body/0/name: is required"},
+		{name: "too long to check", status: http.StatusOK, header: map[string]string{"Content-Type": "application/json", "X-Total": "1"},
+			body: `[{"name": "rexford"}]`, maxBodySize: 10},
+		{name: "not checked", status: http.StatusServiceUnavailable, body: "down",
+			want: "GET /v1/pets: Internal Server Error: /body: must be empty"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var logged []string
+			validator := NewResponseValidator(loadPetsSpec(t), LogResponses)
+			validator.MaxBodySize = test.maxBodySize
+			validator.Logf = func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}
+			w := httptest.NewRecorder()
+			validator.Handler(respond(test.status, test.header, test.body)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/pets", nil))
+			if w.Code != test.status || w.Body.String() != test.body {
+				t.Errorf("response = %d %q, want it unchanged", w.Code, w.Body)
+			}
+			var want []string
+			if test.want != "" {
+				want = []string{test.want}
+			}
+			if !reflect.DeepEqual(logged, want) {
+				t.Errorf("logged %q, want %q", logged, want)
+			}
+		})
+	}
+}
+
+func TestResponseRecorderCapture(t *testing.T) {
+	spec := loadPetsSpec(t)
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		maxBodySize int64
+		want        string
+		recorded    bool
+	}{
+		{name: "schema", status: http.StatusOK, contentType: "application/json", body: `[]`, want: `[]`, recorded: true},
+		{name: "sniffed", status: http.StatusOK, body: `[]`, recorded: false},
+		{name: "no schema", status: http.StatusServiceUnavailable, contentType: "application/json", body: `{}`, recorded: false},
+		{name: "too long", status: http.StatusOK, contentType: "application/json", body: `[1, 2, 3]`, maxBodySize: 4, recorded: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validator := NewResponseValidator(spec, LogResponses)
+			validator.Logf = func(string, ...interface{}) {}
+			validator.MaxBodySize = test.maxBodySize
+			var recorder *responseRecorder
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				recorder = w.(*responseRecorder)
+				if test.contentType != "" {
+					w.Header().Set("Content-Type", test.contentType)
+				}
+				w.WriteHeader(test.status)
+				for _, data := range strings.SplitAfter(test.body, ",") {
+					_, _ = w.Write([]byte(data))
+				}
+			})
+			w := httptest.NewRecorder()
+			validator.Handler(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/pets", nil))
+			if got := recorder.body.String(); got != test.want || recorder.recorded() != test.recorded {
+				t.Errorf("recorded %q (%t), want %q (%t)", got, recorder.recorded(), test.want, test.recorded)
+			}
+			if w.Body.String() != test.body {
+				t.Errorf("sent %q, want %q", w.Body, test.body)
+			}
+		})
+	}
+}
+
+func TestResponseRecorderFlush(t *testing.T) {
+	for _, mode := range []ResponseMode{LogResponses, StrictResponses} {
+		validator := NewResponseValidator(loadPetsSpec(t), mode)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Total", "0")
+			_, _ = w.Write([]byte("["))
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("]"))
+		})
+		w := httptest.NewRecorder()
+		validator.Handler(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/pets", nil))
+		if w.Flushed != (mode == LogResponses) {
+			t.Errorf("mode %d: flushed = %t", mode, w.Flushed)
+		}
+		if w.Code != http.StatusOK || w.Body.String() != "[]" {
+			t.Errorf("mode %d: response = %d %q, want 200 []", mode, w.Code, w.Body)
+		}
+	}
+}
+
+// hijackRecorder is a ResponseRecorder whose connection can be hijacked.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (recorder *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return recorder.conn, bufio.NewReadWriter(bufio.NewReader(recorder.conn), bufio.NewWriter(recorder.conn)), nil
+}
+
+func TestResponseRecorderHijack(t *testing.T) {
+	for _, mode := range []ResponseMode{LogResponses, StrictResponses} {
+		validator := NewResponseValidator(loadPetsSpec(t), mode)
+		validator.Logf = func(format string, args ...interface{}) {
+			t.Errorf("mode %d: logged "+format, append([]interface{}{mode}, args...)...)
+		}
+		client, server := net.Pipe()
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil || conn != server {
+				t.Errorf("mode %d: Hijack() = %v, %v", mode, conn, err)
+			}
+		})
+		w := &hijackRecorder{ResponseRecorder: httptest.NewRecorder(), conn: server}
+		validator.Handler(handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/pets", nil))
+		if w.Code != http.StatusOK || w.Body.Len() != 0 || len(w.Header()) != 0 {
+			t.Errorf("mode %d: a response was written after the connection was hijacked", mode)
+		}
+		_ = client.Close()
+		_ = server.Close()
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := w.(http.Hijacker).Hijack(); err != http.ErrNotSupported {
+			t.Errorf("Hijack() = %v, want %v", err, http.ErrNotSupported)
+		}
+	})
+	validator := NewResponseValidator(loadPetsSpec(t), LogResponses)
+	validator.Logf = func(string, ...interface{}) {}
+	validator.Handler(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/pets", nil))
+}
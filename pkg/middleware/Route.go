@@ -0,0 +1,236 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// templateVariable matches the variables of a path template.
+// http://spec.openapis.org/oas/v3.0.3#path-templating
+var templateVariable = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// route is a compiled path of the document.
+type route struct {
+	path     string
+	pathItem *v303.PathItem
+	pattern  *regexp.Regexp
+	names    []string
+}
+
+// routes matches requests to the paths of a document.
+type routes struct {
+	openAPI *v303.OpenAPI
+	// basePaths are the paths of the URLs of the servers, which prefix the paths of the document.
+	basePaths []string
+	routes    []route
+
+	// validators are the compiled schemas the values of requests and responses are checked against.
+	validators      map[*v303.Schema]compiledSchema
+	validatorsMutex sync.Mutex
+}
+
+func newRoutes(openAPI *v303.OpenAPI) *routes {
+	routes := &routes{openAPI: openAPI, validators: map[*v303.Schema]compiledSchema{}}
+	for _, server := range openAPI.Servers {
+		// Server variables are not substituted, a base path holding one is not matched.
+		if serverURL, err := url.Parse(server.Url); err == nil {
+			routes.basePaths = append(routes.basePaths, strings.TrimSuffix(serverURL.Path, "/"))
+		}
+	}
+	if len(routes.basePaths) == 0 {
+		routes.basePaths = []string{""}
+	}
+	for path, pathItem := range openAPI.Paths {
+		route := route{path: path, pathItem: pathItem}
+		var pattern strings.Builder
+		pattern.WriteString("^")
+		last := 0
+		for _, match := range templateVariable.FindAllStringSubmatchIndex(path, -1) {
+			pattern.WriteString(regexp.QuoteMeta(path[last:match[0]]))
+			pattern.WriteString("([^/]+)")
+			route.names = append(route.names, path[match[2]:match[3]])
+			last = match[1]
+		}
+		pattern.WriteString(regexp.QuoteMeta(path[last:]) + "$")
+		route.pattern = regexp.MustCompile(pattern.String())
+		routes.routes = append(routes.routes, route)
+	}
+	// Concrete paths are matched before templated ones.
+	// http://spec.openapis.org/oas/v3.0.3#patterned-fields
+	sort.Slice(routes.routes, func(i, j int) bool {
+		if len(routes.routes[i].names) != len(routes.routes[j].names) {
+			return len(routes.routes[i].names) < len(routes.routes[j].names)
+		}
+		return routes.routes[i].path < routes.routes[j].path
+	})
+	return routes
+}
+
+// match returns the route of the path of r, along with the raw values of the variables of its template.
+func (routes *routes) match(r *http.Request) (*route, map[string]string) {
+	escapedPath := r.URL.EscapedPath()
+	for _, basePath := range routes.basePaths {
+		if !strings.HasPrefix(escapedPath, basePath) {
+			continue
+		}
+		path := escapedPath[len(basePath):]
+		for i := range routes.routes {
+			match := routes.routes[i].pattern.FindStringSubmatch(path)
+			if match == nil {
+				continue
+			}
+			variables := make(map[string]string, len(match)-1)
+			for j, name := range routes.routes[i].names {
+				variables[name] = match[j+1]
+			}
+			return &routes.routes[i], variables
+		}
+	}
+	return nil, nil
+}
+
+// operation returns the operation of method in pathItem.
+func operation(pathItem *v303.PathItem, method string) *v303.Operation {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return pathItem.Get
+	case http.MethodPut:
+		return pathItem.Put
+	case http.MethodPost:
+		return pathItem.Post
+	case http.MethodDelete:
+		return pathItem.Delete
+	case http.MethodOptions:
+		return pathItem.Options
+	case http.MethodHead:
+		return pathItem.Head
+	case http.MethodPatch:
+		return pathItem.Patch
+	case http.MethodTrace:
+		return pathItem.Trace
+	}
+	return nil
+}
+
+// allowed returns the methods of the operations of pathItem.
+func allowed(pathItem *v303.PathItem) []string {
+	var methods []string
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace} {
+		if operation(pathItem, method) != nil {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// component returns the name of the component of section which ref points to, empty for other refs.
+func component(ref, section string) string {
+	prefix := "#/components/" + section + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return ""
+	}
+	return strings.Replace(strings.Replace(ref[len(prefix):], "~1", "/", -1), "~0", "~", -1)
+}
+
+// The resolve functions return the value of a reference, looking up the components of the document when its refs
+// were not resolved by a v303.Loader, such as for a document built in code. They return nil for refs which cannot
+// be resolved, the document being validated beforehand by v303.OpenAPI.Validate.
+
+func (routes *routes) resolveSchema(schemaRef *v303.SchemaRef) *v303.Schema {
+	for depth := 0; schemaRef != nil && depth < 64; depth++ {
+		if schemaRef.Value != nil {
+			return schemaRef.Value
+		}
+		name := component(schemaRef.Ref, "schemas")
+		if routes.openAPI.Components == nil || name == "" {
+			return nil
+		}
+		schemaRef = routes.openAPI.Components.Schema[name]
+	}
+	return nil
+}
+
+func (routes *routes) resolveParameter(parameterRef *v303.ParameterRef) *v303.Parameter {
+	for depth := 0; parameterRef != nil && depth < 64; depth++ {
+		if parameterRef.Value != nil {
+			return parameterRef.Value
+		}
+		name := component(parameterRef.Ref, "parameters")
+		if routes.openAPI.Components == nil || name == "" {
+			return nil
+		}
+		parameterRef = routes.openAPI.Components.Parameters[name]
+	}
+	return nil
+}
+
+func (routes *routes) resolveRequestBody(requestBodyRef *v303.RequestBodyRef) *v303.RequestBody {
+	for depth := 0; requestBodyRef != nil && depth < 64; depth++ {
+		if requestBodyRef.Value != nil {
+			return requestBodyRef.Value
+		}
+		name := component(requestBodyRef.Ref, "requestBodies")
+		if routes.openAPI.Components == nil || name == "" {
+			return nil
+		}
+		requestBodyRef = routes.openAPI.Components.RequestBodies[name]
+	}
+	return nil
+}
+
+func (routes *routes) resolveResponse(responseRef *v303.ResponseRef) *v303.Response {
+	for depth := 0; responseRef != nil && depth < 64; depth++ {
+		if responseRef.Value != nil {
+			return responseRef.Value
+		}
+		name := component(responseRef.Ref, "responses")
+		if routes.openAPI.Components == nil || name == "" {
+			return nil
+		}
+		responseRef = routes.openAPI.Components.Responses[name]
+	}
+	return nil
+}
+
+func (routes *routes) resolveHeader(headerRef *v303.HeaderRef) *v303.Header {
+	for depth := 0; headerRef != nil && depth < 64; depth++ {
+		if headerRef.Value != nil {
+			return headerRef.Value
+		}
+		name := component(headerRef.Ref, "headers")
+		if routes.openAPI.Components == nil || name == "" {
+			return nil
+		}
+		headerRef = routes.openAPI.Components.Headers[name]
+	}
+	return nil
+}
+
+// parameters returns the parameters of an operation, which override those of its path item.
+func (routes *routes) parameters(pathItem *v303.PathItem, operation *v303.Operation) []*v303.Parameter {
+	var parameters []*v303.Parameter
+	index := map[[2]string]int{}
+	for _, parameterRefs := range [][]*v303.ParameterRef{pathItem.Parameters, operation.Parameters} {
+		for _, parameterRef := range parameterRefs {
+			parameter := routes.resolveParameter(parameterRef)
+			if parameter == nil {
+				continue
+			}
+			key := [2]string{parameter.In, parameter.Name}
+			if i, ok := index[key]; ok {
+				parameters[i] = parameter
+				continue
+			}
+			index[key] = len(parameters)
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// checker checks values decoded from JSON with json.Decoder.UseNumber against schemas.
+type checker struct {
+	routes *routes
+	// request is true for the values of requests, where readOnly properties are not required and must not be sent,
+	// and false for those of responses, where writeOnly properties are not.
+	request bool
+	errors  []ProblemError
+}
+
+func (checker *checker) errorf(pointer, format string, args ...interface{}) {
+	checker.errors = append(checker.errors, ProblemError{Pointer: pointer, Detail: fmt.Sprintf(format, args...)})
+}
+
+// check reports the violations of schemaRef by value, located at pointer.
+func (checker *checker) check(schemaRef *v303.SchemaRef, value interface{}, pointer string) {
+	schema := checker.routes.resolveSchema(schemaRef)
+	if schema == nil {
+		return
+	}
+	direction := v303.InResponse()
+	if checker.request {
+		direction = v303.InRequest()
+	}
+	validator, err := checker.routes.validator(schema)
+	if err != nil {
+		checker.errorf(pointer, "cannot be checked: %v", err)
+		return
+	}
+	err = validator.ValidateValue(value, direction)
+	valueErrors, _ := err.(v303.ValueErrors)
+	for _, valueError := range valueErrors {
+		checker.errorf(pointer+valueError.Pointer, "%s", valueError.Message)
+	}
+}
+
+// compiledSchema is a schema compiled by routes.validator, or the error of its compilation.
+type compiledSchema struct {
+	validator *v303.ValueValidator
+	err       error
+}
+
+// validator returns the compiled schema, compiling it on first use.
+func (routes *routes) validator(schema *v303.Schema) (*v303.ValueValidator, error) {
+	routes.validatorsMutex.Lock()
+	defer routes.validatorsMutex.Unlock()
+	compiled, ok := routes.validators[schema]
+	if !ok {
+		compiled.validator, compiled.err = schema.Compile(routes.openAPI.Components)
+		routes.validators[schema] = compiled
+	}
+	return compiled.validator, compiled.err
+}
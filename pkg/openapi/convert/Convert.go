@@ -0,0 +1,766 @@
+// Package convert moves documents between the Swagger 2.0 and OpenAPI 3.0.3 models.
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v200"
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// Warning reports a construct of the source document which could not be converted exactly.
+type Warning struct {
+	// Location is the JSON pointer of the construct in the source document.
+	Location string
+	Message  string
+}
+
+func (warning Warning) String() string {
+	return warning.Location + ": " + warning.Message
+}
+
+// defaultMediaType is used when neither the operation nor the document declare consumes or produces.
+const defaultMediaType = "application/json"
+
+// refSections maps the Swagger 2.0 sections which can be referenced to their OpenAPI 3.0.3 Components member.
+var refSections = []struct{ from, to string }{
+	{"/definitions/", "/components/schemas/"},
+	{"/parameters/", "/components/parameters/"},
+	{"/responses/", "/components/responses/"},
+}
+
+// Convert converts a Swagger 2.0 document into an OpenAPI 3.0.3 document. Every construct which has no exact
+// equivalent is approximated and reported as a Warning located in swagger.
+// https://swagger.io/specification/v2/
+// http://spec.openapis.org/oas/v3.0.3
+func Convert(swagger *v200.Swagger) (*v303.OpenAPI, []Warning) {
+	if swagger == nil {
+		return nil, nil
+	}
+	converter := &upConverter{swagger: swagger}
+	return converter.openAPI(), converter.warnings
+}
+
+type upConverter struct {
+	swagger  *v200.Swagger
+	warnings []Warning
+}
+
+func (converter *upConverter) warn(location, format string, args ...interface{}) {
+	converter.warnings = append(converter.warnings, Warning{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (converter *upConverter) openAPI() *v303.OpenAPI {
+	swagger := converter.swagger
+	openAPI := &v303.OpenAPI{
+		Extensions:      copyExtensions(swagger.Extensions),
+		OpenAPI:         "3.0.3",
+		Info:            convertInfo(swagger.Info),
+		PathsExtensions: copyExtensions(swagger.PathsExtensions),
+		Servers:         converter.servers(swagger.Schemes, "#/schemes"),
+		Components:      converter.components(),
+		ExternalDocs:    convertExternalDocs(swagger.ExternalDocs),
+	}
+	if swagger.Swagger != "2.0" {
+		converter.warn("#/swagger", "version %q is not 2.0, the document is converted as a 2.0 one", swagger.Swagger)
+	}
+	openAPI.Security = convertSecurity(swagger.Security)
+	for _, tag := range swagger.Tags {
+		if tag != nil {
+			openAPI.Tags = append(openAPI.Tags, &v303.Tag{Extensions: copyExtensions(tag.Extensions), Name: tag.Name, Description: tag.Description,
+				ExternalDocs: convertExternalDocs(tag.ExternalDocs)})
+		}
+	}
+	openAPI.Paths = map[string]*v303.PathItem{}
+	for _, name := range sortedKeys(swagger.Paths) {
+		if pathItem := swagger.Paths[name]; pathItem != nil {
+			openAPI.Paths[name] = converter.pathItem(pathItem, "#/paths/"+escape(name))
+		}
+	}
+	return openAPI
+}
+
+func convertInfo(info *v200.Info) *v303.Info {
+	if info == nil {
+		return nil
+	}
+	converted := &v303.Info{Extensions: copyExtensions(info.Extensions), Title: info.Title, Description: info.Description, TermsOfService: info.TermsOfService, Version: info.Version}
+	if info.Contact != nil {
+		converted.Contact = &v303.Contact{Extensions: copyExtensions(info.Contact.Extensions), Name: info.Contact.Name, Url: info.Contact.Url, Email: info.Contact.Email}
+	}
+	if info.License != nil {
+		converted.License = &v303.License{Extensions: copyExtensions(info.License.Extensions), Name: info.License.Name, Url: info.License.Url}
+	}
+	return converted
+}
+
+func convertExternalDocs(externalDocs *v200.ExternalDocumentation) *v303.ExternalDocumentation {
+	if externalDocs == nil {
+		return nil
+	}
+	return &v303.ExternalDocumentation{Extensions: copyExtensions(externalDocs.Extensions), Description: externalDocs.Description, URL: externalDocs.URL}
+}
+
+// convertSecurity returns the alternative security requirements of the document or an operation.
+func convertSecurity(security []v200.SecurityRequirement) []v303.SecurityRequirement {
+	if security == nil {
+		return nil
+	}
+	converted := make([]v303.SecurityRequirement, len(security))
+	for i, requirement := range security {
+		converted[i] = v303.SecurityRequirement{}
+		for name, scopes := range requirement {
+			converted[i][name] = append([]string{}, scopes...)
+		}
+	}
+	return converted
+}
+
+// servers builds the Server Objects from the host and basePath of the document and the given schemes.
+// Without schemes the url is relative to the scheme the document is served with.
+func (converter *upConverter) servers(schemes []string, location string) []*v303.Server {
+	host, basePath := converter.swagger.Host, converter.swagger.BasePath
+	if host == "" {
+		if len(schemes) > 0 {
+			converter.warn(location, "schemes cannot be expressed without a host, the server url is relative")
+		}
+		if basePath == "" {
+			return nil
+		}
+		return []*v303.Server{{Url: basePath}}
+	}
+	if len(schemes) == 0 {
+		return []*v303.Server{{Url: "//" + host + basePath}}
+	}
+	servers := make([]*v303.Server, len(schemes))
+	for i, scheme := range schemes {
+		servers[i] = &v303.Server{Url: scheme + "://" + host + basePath}
+	}
+	return servers
+}
+
+func (converter *upConverter) components() *v303.Components {
+	swagger := converter.swagger
+	components := &v303.Components{}
+	for _, name := range sortedKeys(swagger.Definitions) {
+		if schema := swagger.Definitions[name]; schema != nil {
+			if components.Schema == nil {
+				components.Schema = map[string]*v303.SchemaRef{}
+			}
+			components.Schema[name] = converter.schema(schema, "#/definitions/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(swagger.Parameters) {
+		parameter, location := swagger.Parameters[name], "#/parameters/"+escape(name)
+		if parameter == nil {
+			continue
+		}
+		switch parameter.In {
+		case "body":
+			if components.RequestBodies == nil {
+				components.RequestBodies = map[string]*v303.RequestBodyRef{}
+			}
+			components.RequestBodies[name] = &v303.RequestBodyRef{Value: converter.requestBody(parameter, mediaTypes(swagger.Consumes), location)}
+		case "formData":
+			converter.warn(location, "formData parameters cannot be components, they are inlined in the request body of the operations using them")
+		default:
+			if components.Parameters == nil {
+				components.Parameters = map[string]*v303.ParameterRef{}
+			}
+			components.Parameters[name] = converter.parameterRef(parameter, location)
+		}
+	}
+	for _, name := range sortedKeys(swagger.Responses) {
+		if response := swagger.Responses[name]; response != nil {
+			if components.Responses == nil {
+				components.Responses = map[string]*v303.ResponseRef{}
+			}
+			components.Responses[name] = converter.responseRef(response, mediaTypes(swagger.Produces), "#/responses/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(swagger.SecurityDefinitions) {
+		if securityScheme := swagger.SecurityDefinitions[name]; securityScheme != nil {
+			if components.SecuritySchemes == nil {
+				components.SecuritySchemes = map[string]*v303.SecuritySchemeRef{}
+			}
+			components.SecuritySchemes[name] = &v303.SecuritySchemeRef{Value: converter.securityScheme(securityScheme, "#/securityDefinitions/"+escape(name))}
+		}
+	}
+	if components.Schema == nil && components.Parameters == nil && components.RequestBodies == nil &&
+		components.Responses == nil && components.SecuritySchemes == nil {
+		return nil
+	}
+	return components
+}
+
+func (converter *upConverter) securityScheme(securityScheme *v200.SecurityScheme, location string) *v303.SecurityScheme {
+	converted := &v303.SecurityScheme{Extensions: copyExtensions(securityScheme.Extensions), Type: securityScheme.Type, Description: securityScheme.Description}
+	switch securityScheme.Type {
+	case "basic":
+		converted.Type, converted.Scheme = "http", "basic"
+	case "apiKey":
+		converted.Name, converted.In = securityScheme.Name, securityScheme.In
+	case "oauth2":
+		flow := &v303.OAuthFlow{Scopes: securityScheme.Scopes}
+		converted.Flows = &v303.OAuthFlows{}
+		switch securityScheme.Flow {
+		case "implicit":
+			flow.AuthorizationURL = securityScheme.AuthorizationURL
+			converted.Flows.Implicit = flow
+		case "password":
+			flow.TokenURL = securityScheme.TokenURL
+			converted.Flows.Password = flow
+		case "application":
+			flow.TokenURL = securityScheme.TokenURL
+			converted.Flows.ClientCredentials = flow
+		case "accessCode":
+			flow.AuthorizationURL, flow.TokenURL = securityScheme.AuthorizationURL, securityScheme.TokenURL
+			converted.Flows.AuthorizationCode = flow
+		default:
+			converter.warn(location+"/flow", "unknown OAuth2 flow %q is dropped", securityScheme.Flow)
+		}
+	default:
+		converter.warn(location+"/type", "unknown security scheme type %q is copied as is", securityScheme.Type)
+	}
+	return converted
+}
+
+// located is a parameter along with its location in the source document.
+type located struct {
+	parameter *v200.Parameter
+	location  string
+}
+
+func (converter *upConverter) pathItem(pathItem *v200.PathItem, location string) *v303.PathItem {
+	converted := &v303.PathItem{
+		Extensions:  copyExtensions(pathItem.Extensions),
+		Reference:   v303.Reference{Ref: pathItem.Ref},
+		Summary:     pathItem.Summary,
+		Description: pathItem.Description,
+	}
+	// Body and formData parameters become the request body of every operation of the path.
+	var shared []located
+	for i, parameter := range pathItem.Parameters {
+		if parameter == nil {
+			continue
+		}
+		parameterLocation := location + "/parameters/" + fmt.Sprint(i)
+		if in := converter.resolveParameter(parameter).In; in == "body" || in == "formData" {
+			shared = append(shared, located{parameter, parameterLocation})
+			continue
+		}
+		converted.Parameters = append(converted.Parameters, converter.parameterRef(parameter, parameterLocation))
+	}
+	operation := func(operation *v200.Operation, method string) *v303.Operation {
+		if operation == nil {
+			return nil
+		}
+		return converter.operation(operation, shared, location+"/"+method)
+	}
+	converted.Get = operation(pathItem.Get, "get")
+	converted.Put = operation(pathItem.Put, "put")
+	converted.Post = operation(pathItem.Post, "post")
+	converted.Delete = operation(pathItem.Delete, "delete")
+	converted.Options = operation(pathItem.Options, "options")
+	converted.Head = operation(pathItem.Head, "head")
+	converted.Patch = operation(pathItem.Patch, "patch")
+	return converted
+}
+
+// resolveParameter returns the target of a local parameter reference, or the parameter itself.
+func (converter *upConverter) resolveParameter(parameter *v200.Parameter) *v200.Parameter {
+	if name, ok := localName(parameter.Ref, "/parameters/"); ok {
+		if target := converter.swagger.Parameters[name]; target != nil {
+			return target
+		}
+	}
+	return parameter
+}
+
+func (converter *upConverter) operation(operation *v200.Operation, shared []located, location string) *v303.Operation {
+	swagger := converter.swagger
+	converted := &v303.Operation{
+		Extensions:   copyExtensions(operation.Extensions),
+		Tags:         operation.Tags,
+		Summary:      operation.Summary,
+		Description:  operation.Description,
+		ExternalDocs: convertExternalDocs(operation.ExternalDocs),
+		OperationID:  operation.OperationID,
+		Deprecated:   operation.Deprecated,
+	}
+	consumes, produces := mediaTypes(swagger.Consumes), mediaTypes(swagger.Produces)
+	if operation.Consumes != nil {
+		consumes = mediaTypes(operation.Consumes)
+	}
+	if operation.Produces != nil {
+		produces = mediaTypes(operation.Produces)
+	}
+
+	var bodies []located
+	overridden := map[string]bool{}
+	for i, parameter := range operation.Parameters {
+		if parameter == nil {
+			continue
+		}
+		resolved, parameterLocation := converter.resolveParameter(parameter), location+"/parameters/"+fmt.Sprint(i)
+		overridden[resolved.In+" "+resolved.Name] = true
+		if resolved.In == "body" || resolved.In == "formData" {
+			bodies = append(bodies, located{parameter, parameterLocation})
+			continue
+		}
+		converted.Parameters = append(converted.Parameters, converter.parameterRef(parameter, parameterLocation))
+	}
+	for _, body := range shared {
+		if resolved := converter.resolveParameter(body.parameter); !overridden[resolved.In+" "+resolved.Name] {
+			bodies = append(bodies, body)
+		}
+	}
+	converted.RequestBody = converter.requestBodyRef(bodies, consumes)
+
+	converted.Responses = map[string]*v303.ResponseRef{}
+	converted.ResponsesExtensions = copyExtensions(operation.ResponsesExtensions)
+	for _, code := range sortedKeys(operation.Responses) {
+		if response := operation.Responses[code]; response != nil {
+			converted.Responses[code] = converter.responseRef(response, produces, location+"/responses/"+escape(code))
+		}
+	}
+	if operation.Security != nil {
+		// An empty list removes the security of the document.
+		security := append([]v303.SecurityRequirement{}, convertSecurity(*operation.Security)...)
+		converted.Security = &security
+	}
+	if len(operation.Schemes) > 0 {
+		converted.Servers = converter.servers(operation.Schemes, location+"/schemes")
+	}
+	return converted
+}
+
+// requestBodyRef builds the request body from the body or formData parameters of an operation.
+func (converter *upConverter) requestBodyRef(bodies []located, consumes []string) *v303.RequestBodyRef {
+	var body *located
+	var form []located
+	for i := range bodies {
+		if converter.resolveParameter(bodies[i].parameter).In == "body" {
+			if body != nil {
+				converter.warn(bodies[i].location, "an operation has at most one body parameter, this one is dropped")
+				continue
+			}
+			body = &bodies[i]
+		} else {
+			form = append(form, bodies[i])
+		}
+	}
+	if body != nil {
+		for _, parameter := range form {
+			converter.warn(parameter.location, "formData parameters cannot be used along a body parameter, this one is dropped")
+		}
+		if name, ok := localName(body.parameter.Ref, "/parameters/"); ok && converter.swagger.Parameters[name] != nil &&
+			equalStrings(consumes, mediaTypes(converter.swagger.Consumes)) {
+			return &v303.RequestBodyRef{Ref: "#/components/requestBodies/" + escape(name)}
+		}
+		return &v303.RequestBodyRef{Value: converter.requestBody(converter.resolveParameter(body.parameter), consumes, body.location)}
+	}
+	if len(form) > 0 {
+		return &v303.RequestBodyRef{Value: converter.formBody(form, consumes)}
+	}
+	return nil
+}
+
+func (converter *upConverter) requestBody(parameter *v200.Parameter, consumes []string, location string) *v303.RequestBody {
+	requestBody := &v303.RequestBody{Extensions: copyExtensions(parameter.Extensions), Description: parameter.Description,
+		Required: parameter.Required, Content: map[string]*v303.MediaType{}}
+	var schema *v303.SchemaRef
+	if parameter.Schema != nil {
+		schema = converter.schema(parameter.Schema, location+"/schema")
+	}
+	for _, mediaType := range consumes {
+		requestBody.Content[mediaType] = &v303.MediaType{Schema: schema}
+	}
+	return requestBody
+}
+
+// formBody merges formData parameters into the properties of a single object schema.
+func (converter *upConverter) formBody(form []located, consumes []string) *v303.RequestBody {
+	schema := &v303.Schema{Type: "object", Properties: map[string]*v303.SchemaRef{}}
+	encoding := map[string]*v303.Encoding{}
+	hasFile := false
+	for _, field := range form {
+		parameter := converter.resolveParameter(field.parameter)
+		property := converter.simpleSchema(parameterType(parameter), field.location)
+		property.Value.Description = parameter.Description
+		schema.Properties[parameter.Name] = property
+		if parameter.Required {
+			schema.Required = append(schema.Required, parameter.Name)
+		}
+		if parameter.Type == "file" {
+			hasFile = true
+		}
+		if parameter.Type == "array" {
+			if style, explode, ok := converter.style("query", parameter.CollectionFormat, field.location); ok {
+				encoding[parameter.Name] = &v303.Encoding{Style: style, Explode: explode}
+			}
+		}
+	}
+	var formTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data" {
+			formTypes = append(formTypes, mediaType)
+		}
+	}
+	if len(formTypes) == 0 {
+		formTypes = []string{"application/x-www-form-urlencoded"}
+		if hasFile {
+			formTypes = []string{"multipart/form-data"}
+		}
+	}
+	requestBody := &v303.RequestBody{Content: map[string]*v303.MediaType{}}
+	for _, mediaType := range formTypes {
+		content := &v303.MediaType{Schema: &v303.SchemaRef{Value: schema}}
+		if mediaType == "application/x-www-form-urlencoded" && len(encoding) > 0 {
+			content.Encoding = encoding
+		}
+		requestBody.Content[mediaType] = content
+	}
+	requestBody.Required = len(schema.Required) > 0
+	return requestBody
+}
+
+func (converter *upConverter) parameterRef(parameter *v200.Parameter, location string) *v303.ParameterRef {
+	if parameter.Ref != "" {
+		return &v303.ParameterRef{Ref: rewriteRef(parameter.Ref)}
+	}
+	converted := &v303.Parameter{
+		Extensions:  copyExtensions(parameter.Extensions),
+		Name:        parameter.Name,
+		In:          parameter.In,
+		Description: parameter.Description,
+		Required:    parameter.Required,
+		Schema:      converter.simpleSchema(parameterType(parameter), location),
+	}
+	if parameter.AllowEmptyValue {
+		if parameter.In == "query" {
+			converted.AllowEmptyValue = true
+		} else {
+			converter.warn(location+"/allowEmptyValue", "allowEmptyValue only applies to query parameters and is dropped")
+		}
+	}
+	if parameter.Type == "array" {
+		converted.Style, converted.Explode, _ = converter.style(parameter.In, parameter.CollectionFormat, location)
+	}
+	return &v303.ParameterRef{Value: converted}
+}
+
+// style maps a collectionFormat to the style and explode of a parameter located in in. It returns false when
+// the default serialization of in already matches.
+// http://spec.openapis.org/oas/v3.0.3#style-values
+func (converter *upConverter) style(in, collectionFormat, location string) (string, *bool, bool) {
+	explode := false
+	switch collectionFormat {
+	case "", "csv":
+		if in == "query" || in == "cookie" {
+			return "form", &explode, true
+		}
+		return "", nil, false
+	case "ssv":
+		if in == "query" {
+			return "spaceDelimited", &explode, true
+		}
+	case "pipes":
+		if in == "query" {
+			return "pipeDelimited", &explode, true
+		}
+	case "multi":
+		if in == "query" {
+			explode = true
+			return "form", &explode, true
+		}
+	}
+	converter.warn(location+"/collectionFormat", "collectionFormat %q has no equivalent for %s parameters, the default style is used", collectionFormat, in)
+	return "", nil, false
+}
+
+func (converter *upConverter) responseRef(response *v200.Response, produces []string, location string) *v303.ResponseRef {
+	if name, ok := localName(response.Ref, "/responses/"); ok {
+		target := converter.swagger.Responses[name]
+		if target == nil {
+			converter.warn(location, "$ref %q cannot be resolved", response.Ref)
+		}
+		if target == nil || equalStrings(produces, mediaTypes(converter.swagger.Produces)) {
+			return &v303.ResponseRef{Ref: rewriteRef(response.Ref)}
+		}
+		// The referenced response is declared with the produces of the document, which this operation overrides.
+		response, location = target, "#/responses/"+escape(name)
+	} else if response.Ref != "" {
+		return &v303.ResponseRef{Ref: rewriteRef(response.Ref)}
+	}
+	converted := &v303.Response{Extensions: copyExtensions(response.Extensions), Description: response.Description}
+	for _, name := range sortedKeys(response.Headers) {
+		if header := response.Headers[name]; header != nil {
+			if converted.Headers == nil {
+				converted.Headers = map[string]*v303.HeaderRef{}
+			}
+			converted.Headers[name] = &v303.HeaderRef{Value: converter.header(header, location+"/headers/"+escape(name))}
+		}
+	}
+	if response.Schema != nil {
+		schema := converter.schema(response.Schema, location+"/schema")
+		converted.Content = map[string]*v303.MediaType{}
+		for _, mediaType := range produces {
+			converted.Content[mediaType] = &v303.MediaType{Schema: schema}
+		}
+	}
+	for _, mediaType := range sortedKeys(response.Example) {
+		if converted.Content == nil {
+			converted.Content = map[string]*v303.MediaType{}
+		}
+		if converted.Content[mediaType] == nil {
+			converted.Content[mediaType] = &v303.MediaType{}
+		}
+		converted.Content[mediaType].Example = response.Example[mediaType]
+	}
+	return &v303.ResponseRef{Value: converted}
+}
+
+func (converter *upConverter) header(header *v200.Header, location string) *v303.Header {
+	converted := &v303.Header{
+		Extensions:  copyExtensions(header.Extensions),
+		Description: header.Description,
+		Schema:      converter.simpleSchema(headerType(header), location),
+	}
+	if header.Type == "array" && header.CollectionFormat != "" && header.CollectionFormat != "csv" {
+		converter.warn(location+"/collectionFormat", "collectionFormat %q has no equivalent for headers, the default style is used", header.CollectionFormat)
+	}
+	return converted
+}
+
+// simpleType holds the fields shared by the non-body Parameter, Header and Items objects.
+// https://swagger.io/specification/v2/#items-object
+type simpleType struct {
+	Type, Format, CollectionFormat, Pattern string
+	Items                                   *v200.Items
+	Default                                 json.RawMessage
+	Enum                                    []json.RawMessage
+	Maximum, Minimum, MultipleOf            json.Number
+	ExclusiveMaximum, ExclusiveMinimum      bool
+	MaxLength, MinLength, MaxItems          *int
+	MinItems                                *int
+	UniqueItems                             bool
+}
+
+func parameterType(parameter *v200.Parameter) simpleType {
+	return simpleType{
+		Type: parameter.Type, Format: parameter.Format, CollectionFormat: parameter.CollectionFormat, Pattern: parameter.Pattern,
+		Items: parameter.Items, Default: parameter.Default, Enum: parameter.Enum,
+		Maximum: parameter.Maximum, Minimum: parameter.Minimum, MultipleOf: parameter.MultipleOf,
+		ExclusiveMaximum: parameter.ExclusiveMaximum, ExclusiveMinimum: parameter.ExclusiveMinimum,
+		MaxLength: parameter.MaxLength, MinLength: parameter.MinLength, MaxItems: parameter.MaxItems,
+		MinItems: parameter.MinItems, UniqueItems: parameter.UniqueItems,
+	}
+}
+
+func headerType(header *v200.Header) simpleType {
+	return simpleType{
+		Type: header.Type, Format: header.Format, CollectionFormat: header.CollectionFormat, Pattern: header.Pattern,
+		Items: header.Items, Default: header.Default, Enum: header.Enum,
+		Maximum: header.Maximum, Minimum: header.Minimum, MultipleOf: header.MultipleOf,
+		ExclusiveMaximum: header.ExclusiveMaximum, ExclusiveMinimum: header.ExclusiveMinimum,
+		MaxLength: header.MaxLength, MinLength: header.MinLength, MaxItems: header.MaxItems,
+		MinItems: header.MinItems, UniqueItems: header.UniqueItems,
+	}
+}
+
+func itemsType(items *v200.Items) simpleType {
+	return simpleType{
+		Type: items.Type, Format: items.Format, CollectionFormat: items.CollectionFormat, Pattern: items.Pattern,
+		Items: items.Items, Default: items.Default, Enum: items.Enum,
+		Maximum: items.Maximum, Minimum: items.Minimum, MultipleOf: items.MultipleOf,
+		ExclusiveMaximum: items.ExclusiveMaximum, ExclusiveMinimum: items.ExclusiveMinimum,
+		MaxLength: items.MaxLength, MinLength: items.MinLength, MaxItems: items.MaxItems,
+		MinItems: items.MinItems, UniqueItems: items.UniqueItems,
+	}
+}
+
+// simpleSchema converts the type of a non-body parameter, a header or an items object into a Schema.
+func (converter *upConverter) simpleSchema(simple simpleType, location string) *v303.SchemaRef {
+	schema := &v303.Schema{
+		Type:             simple.Type,
+		Format:           simple.Format,
+		Default:          simple.Default,
+		Enum:             simple.Enum,
+		Maximum:          simple.Maximum,
+		ExclusiveMaximum: simple.ExclusiveMaximum,
+		Minimum:          simple.Minimum,
+		ExclusiveMinimum: simple.ExclusiveMinimum,
+		MaxLength:        simple.MaxLength,
+		MinLength:        simple.MinLength,
+		Pattern:          simple.Pattern,
+		MaxItems:         simple.MaxItems,
+		MinItems:         simple.MinItems,
+		UniqueItems:      simple.UniqueItems,
+		MultipleOf:       simple.MultipleOf,
+	}
+	if simple.Type == "file" {
+		schema.Type, schema.Format = "string", "binary"
+	}
+	if simple.Items != nil {
+		itemsLocation := location + "/items"
+		if simple.Items.Type == "array" && simple.Items.CollectionFormat != "" && simple.Items.CollectionFormat != "csv" {
+			converter.warn(itemsLocation+"/collectionFormat", "collectionFormat %q of nested items has no equivalent and is dropped", simple.Items.CollectionFormat)
+		}
+		schema.Items = converter.simpleSchema(itemsType(simple.Items), itemsLocation)
+	}
+	return &v303.SchemaRef{Value: schema}
+}
+
+func (converter *upConverter) schema(schema *v200.Schema, location string) *v303.SchemaRef {
+	if schema.Ref != "" {
+		return &v303.SchemaRef{Ref: rewriteRef(schema.Ref)}
+	}
+	converted := &v303.Schema{
+		Extensions:       copyExtensions(schema.Extensions),
+		Title:            schema.Title,
+		MultipleOf:       schema.MultipleOf,
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+		MaxProperties:    schema.MaxProperties,
+		MinProperties:    schema.MinProperties,
+		Required:         schema.Required,
+		Enum:             schema.Enum,
+		Type:             schema.Type,
+		Description:      schema.Description,
+		Format:           schema.Format,
+		Default:          schema.Default,
+		ReadOnly:         schema.ReadOnly,
+		ExternalDocs:     convertExternalDocs(schema.ExternalDocs),
+	}
+	if schema.Type == "file" {
+		converted.Type, converted.Format = "string", "binary"
+	}
+	if schema.Discriminator != "" {
+		converted.Discriminator = &v303.Discriminator{PropertyName: schema.Discriminator}
+	}
+	if schema.Xml != nil {
+		converted.Xml = &v303.XML{
+			Extensions: copyExtensions(schema.Xml.Extensions),
+			Name:       schema.Xml.Name,
+			Namespace:  schema.Xml.Namespace,
+			Prefix:     schema.Xml.Prefix,
+			Attribute:  schema.Xml.Attribute,
+			Wrapped:    schema.Xml.Wrapped,
+		}
+	}
+	converted.Example = schema.Example
+	if schema.Items != nil {
+		converted.Items = converter.schema(schema.Items, location+"/items")
+	}
+	for i, item := range schema.AllOf {
+		if item != nil {
+			converted.AllOf = append(converted.AllOf, converter.schema(item, location+"/allOf/"+fmt.Sprint(i)))
+		}
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if property := schema.Properties[name]; property != nil {
+			if converted.Properties == nil {
+				converted.Properties = map[string]*v303.SchemaRef{}
+			}
+			converted.Properties[name] = converter.schema(property, location+"/properties/"+escape(name))
+		}
+	}
+	if additionalProperties := schema.AdditionalProperties; additionalProperties != nil {
+		converted.AdditionalProperties = &v303.AdditionalProperties{Allowed: additionalProperties.Allowed}
+		if additionalProperties.Schema != nil {
+			converted.AdditionalProperties.Schema = converter.schema(additionalProperties.Schema, location+"/additionalProperties")
+		}
+	}
+	return &v303.SchemaRef{Value: converted}
+}
+
+// rewriteRef points a reference to a Swagger 2.0 section at the matching Components member, in the same file.
+func rewriteRef(ref string) string {
+	i := strings.IndexByte(ref, '#')
+	if i < 0 {
+		return ref
+	}
+	file, pointer := ref[:i], ref[i+1:]
+	for _, section := range refSections {
+		if strings.HasPrefix(pointer, section.from) {
+			return file + "#" + section.to + pointer[len(section.from):]
+		}
+	}
+	return ref
+}
+
+// localName returns the name of the entry of section a local reference points to.
+func localName(ref, section string) (string, bool) {
+	prefix := "#" + section
+	if !strings.HasPrefix(ref, prefix) || strings.Contains(ref[len(prefix):], "/") {
+		return "", false
+	}
+	return unescape(ref[len(prefix):]), true
+}
+
+// mediaTypes returns the media types declared by consumes or produces, defaulting to JSON.
+func mediaTypes(declared []string) []string {
+	if len(declared) == 0 {
+		return []string{defaultMediaType}
+	}
+	return declared
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// copyExtensions returns a copy of the specification extensions of an object, for the object it is converted to.
+func copyExtensions(extensions map[string]json.RawMessage) map[string]json.RawMessage {
+	if len(extensions) == 0 {
+		return nil
+	}
+	copied := make(map[string]json.RawMessage, len(extensions))
+	for name, value := range extensions {
+		copied[name] = value
+	}
+	return copied
+}
+
+// escape and unescape encode a JSON pointer reference token.
+// https://tools.ietf.org/html/rfc6901#section-4
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func unescape(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// sortedKeys returns the keys of a map with string keys in order, so that the conversion and its warnings
+// are deterministic.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.String()
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,83 @@
+package convert
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v200"
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+func TestSecurity(t *testing.T) {
+	data := []byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"securityDefinitions": {
+			"apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			"oauth": {"type": "oauth2", "flow": "implicit", "authorizationUrl": "https://example.com/auth", "scopes": {"read": "read pets"}}
+		},
+		"security": [{"apiKey": []}, {"oauth": ["read"]}],
+		"paths": {
+			"/pets": {
+				"get": {"security": [], "responses": {"200": {"description": "pets"}}},
+				"post": {"security": [{"apiKey": [], "oauth": ["read"]}], "responses": {"201": {"description": "created"}}},
+				"delete": {"responses": {"204": {"description": "deleted"}}}
+			}
+		}
+	}`)
+	var swagger v200.Swagger
+	if err := json.Unmarshal(data, &swagger); err != nil {
+		t.Fatal(err)
+	}
+	openAPI, _ := Convert(&swagger)
+	if want := []v303.SecurityRequirement{{"apiKey": {}}, {"oauth": {"read"}}}; !reflect.DeepEqual(openAPI.Security, want) {
+		t.Errorf("security = %v, want %v", openAPI.Security, want)
+	}
+	pathItem := openAPI.Paths["/pets"]
+	if security := pathItem.Get.Security; security == nil || len(*security) != 0 {
+		t.Errorf("get security = %v, want an empty list", security)
+	}
+	want := []v303.SecurityRequirement{{"apiKey": {}, "oauth": {"read"}}}
+	if security := pathItem.Post.Security; security == nil || !reflect.DeepEqual(*security, want) {
+		t.Errorf("post security = %v, want %v", security, want)
+	}
+	if security := pathItem.Delete.Security; security != nil {
+		t.Errorf("delete security = %v, want nil", *security)
+	}
+
+	downconverted, warnings := Downconvert(openAPI)
+	for _, warning := range warnings {
+		t.Errorf("unexpected warning %v", warning)
+	}
+	if !reflect.DeepEqual(downconverted.Security, swagger.Security) {
+		t.Errorf("downconverted security = %v, want %v", downconverted.Security, swagger.Security)
+	}
+	pets, downconvertedPets := swagger.Paths["/pets"], downconverted.Paths["/pets"]
+	if !reflect.DeepEqual(downconvertedPets.Get.Security, pets.Get.Security) {
+		t.Errorf("downconverted get security = %v, want %v", downconvertedPets.Get.Security, pets.Get.Security)
+	}
+	if !reflect.DeepEqual(downconvertedPets.Post.Security, pets.Post.Security) {
+		t.Errorf("downconverted post security = %v, want %v", downconvertedPets.Post.Security, pets.Post.Security)
+	}
+}
+
+func TestLegacySecurity(t *testing.T) {
+	data := []byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"security": {"apiKey": []},
+		"paths": {"/pets": {"get": {"security": {"oauth": ["read"]}, "responses": {"200": {"description": "pets"}}}}}
+	}`)
+	var swagger v200.Swagger
+	if err := json.Unmarshal(data, &swagger); err != nil {
+		t.Fatal(err)
+	}
+	if want := []v200.SecurityRequirement{{"apiKey": {}}}; !reflect.DeepEqual(swagger.Security, want) {
+		t.Errorf("security = %v, want %v", swagger.Security, want)
+	}
+	want := []v200.SecurityRequirement{{"oauth": {"read"}}}
+	if security := swagger.Paths["/pets"].Get.Security; security == nil || !reflect.DeepEqual(*security, want) {
+		t.Errorf("operation security = %v, want %v", security, want)
+	}
+}
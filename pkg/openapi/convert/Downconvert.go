@@ -0,0 +1,974 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v200"
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// downRefSections maps the OpenAPI 3.0.3 Components members which can be referenced from Swagger 2.0 to their
+// section. Request bodies become body parameters.
+var downRefSections = []struct{ from, to string }{
+	{"/components/schemas/", "/definitions/"},
+	{"/components/parameters/", "/parameters/"},
+	{"/components/requestBodies/", "/parameters/"},
+	{"/components/responses/", "/responses/"},
+}
+
+// formMediaTypes are the request body media types described with formData parameters in Swagger 2.0.
+var formMediaTypes = map[string]bool{"application/x-www-form-urlencoded": true, "multipart/form-data": true}
+
+// Downconvert converts an OpenAPI 3.0.3 document into a Swagger 2.0 document. Every construct which cannot be
+// represented is approximated or dropped, and reported as a Warning located in openAPI.
+// http://spec.openapis.org/oas/v3.0.3
+// https://swagger.io/specification/v2/
+func Downconvert(openAPI *v303.OpenAPI) (*v200.Swagger, []Warning) {
+	if openAPI == nil {
+		return nil, nil
+	}
+	converter := &downConverter{openAPI: openAPI, components: openAPI.Components}
+	if converter.components == nil {
+		converter.components = &v303.Components{}
+	}
+	return converter.swagger(), converter.warnings
+}
+
+type downConverter struct {
+	openAPI    *v303.OpenAPI
+	components *v303.Components
+	// host and basePath are the ones of the document server, operation servers can only change the schemes.
+	host, basePath string
+	warnings       []Warning
+}
+
+func (converter *downConverter) warn(location, format string, args ...interface{}) {
+	converter.warnings = append(converter.warnings, Warning{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (converter *downConverter) swagger() *v200.Swagger {
+	openAPI := converter.openAPI
+	swagger := &v200.Swagger{
+		Extensions:      copyExtensions(openAPI.Extensions),
+		Swagger:         "2.0",
+		Info:            downconvertInfo(openAPI.Info),
+		PathsExtensions: copyExtensions(openAPI.PathsExtensions),
+		ExternalDocs:    downconvertExternalDocs(openAPI.ExternalDocs),
+		Security:        downconvertSecurity(openAPI.Security),
+	}
+	swagger.Host, swagger.BasePath, swagger.Schemes = converter.servers(openAPI.Servers, "#/servers")
+	converter.host, converter.basePath = swagger.Host, swagger.BasePath
+	for _, tag := range openAPI.Tags {
+		if tag != nil {
+			swagger.Tags = append(swagger.Tags, &v200.Tag{Extensions: copyExtensions(tag.Extensions), Name: tag.Name, Description: tag.Description,
+				ExternalDocs: downconvertExternalDocs(tag.ExternalDocs)})
+		}
+	}
+	converter.definitions(swagger)
+	swagger.Paths = map[string]*v200.PathItem{}
+	for _, name := range sortedKeys(openAPI.Paths) {
+		if pathItem := openAPI.Paths[name]; pathItem != nil {
+			swagger.Paths[name] = converter.pathItem(pathItem, "#/paths/"+escape(name))
+		}
+	}
+	return swagger
+}
+
+func downconvertInfo(info *v303.Info) *v200.Info {
+	if info == nil {
+		return nil
+	}
+	converted := &v200.Info{Extensions: copyExtensions(info.Extensions), Title: info.Title, Description: info.Description, TermsOfService: info.TermsOfService, Version: info.Version}
+	if info.Contact != nil {
+		converted.Contact = &v200.Contact{Extensions: copyExtensions(info.Contact.Extensions), Name: info.Contact.Name, Url: info.Contact.Url, Email: info.Contact.Email}
+	}
+	if info.License != nil {
+		converted.License = &v200.License{Extensions: copyExtensions(info.License.Extensions), Name: info.License.Name, Url: info.License.Url}
+	}
+	return converted
+}
+
+func downconvertExternalDocs(externalDocs *v303.ExternalDocumentation) *v200.ExternalDocumentation {
+	if externalDocs == nil {
+		return nil
+	}
+	return &v200.ExternalDocumentation{Extensions: copyExtensions(externalDocs.Extensions), Description: externalDocs.Description, URL: externalDocs.URL}
+}
+
+// servers collapses Server Objects into a host, a basePath and schemes. Variables are replaced by their
+// default. Servers which differ from the first one by more than their scheme are dropped.
+func (converter *downConverter) servers(servers []*v303.Server, location string) (string, string, []string) {
+	var host, basePath string
+	var schemes []string
+	for i, server := range servers {
+		if server == nil {
+			continue
+		}
+		serverLocation := location + "/" + fmt.Sprint(i)
+		serverURL := server.Url
+		for _, name := range sortedKeys(server.Variables) {
+			if variable := server.Variables[name]; variable != nil {
+				serverURL = strings.Replace(serverURL, "{"+name+"}", variable.Default, -1)
+			}
+		}
+		parsed, err := url.Parse(serverURL)
+		if err != nil {
+			converter.warn(serverLocation+"/url", "url %q cannot be parsed and is dropped", server.Url)
+			continue
+		}
+		serverBasePath := parsed.Path
+		if len(serverBasePath) > 1 {
+			serverBasePath = strings.TrimSuffix(serverBasePath, "/")
+		}
+		if len(schemes) == 0 && host == "" && basePath == "" {
+			host, basePath = parsed.Host, serverBasePath
+		} else if parsed.Host != host || serverBasePath != basePath {
+			converter.warn(serverLocation, "only one host and basePath can be declared, server %q is dropped", serverURL)
+			continue
+		}
+		if parsed.Scheme != "" {
+			schemes = appendUnique(schemes, parsed.Scheme)
+		}
+	}
+	return host, basePath, schemes
+}
+
+// operationSchemes returns the schemes of the servers of a path item or operation, which must share the host
+// and basePath of the document.
+func (converter *downConverter) operationSchemes(servers []*v303.Server, location string) []string {
+	if len(servers) == 0 {
+		return nil
+	}
+	host, basePath, schemes := converter.servers(servers, location)
+	if host != converter.host || basePath != converter.basePath {
+		converter.warn(location, "servers with another host or basePath than the document cannot be represented and are dropped")
+		return nil
+	}
+	return schemes
+}
+
+// downconvertSecurity returns the alternative security requirements of the document or an operation.
+func downconvertSecurity(security []v303.SecurityRequirement) []v200.SecurityRequirement {
+	if security == nil {
+		return nil
+	}
+	converted := make([]v200.SecurityRequirement, len(security))
+	for i, requirement := range security {
+		converted[i] = v200.SecurityRequirement{}
+		for name, scopes := range requirement {
+			converted[i][name] = append([]string{}, scopes...)
+		}
+	}
+	return converted
+}
+
+// definitions fills the definitions, parameters, responses and securityDefinitions of swagger from Components.
+func (converter *downConverter) definitions(swagger *v200.Swagger) {
+	components := converter.components
+	for _, name := range sortedKeys(components.Schema) {
+		if schemaRef := components.Schema[name]; schemaRef != nil {
+			if swagger.Definitions == nil {
+				swagger.Definitions = map[string]*v200.Schema{}
+			}
+			swagger.Definitions[name] = converter.schema(schemaRef, "#/components/schemas/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		if parameterRef := components.Parameters[name]; parameterRef != nil {
+			if parameter := converter.parameter(parameterRef, "#/components/parameters/"+escape(name)); parameter != nil {
+				if swagger.Parameters == nil {
+					swagger.Parameters = map[string]*v200.Parameter{}
+				}
+				swagger.Parameters[name] = parameter
+			}
+		}
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		location := "#/components/requestBodies/" + escape(name)
+		requestBody := converter.resolveRequestBody(components.RequestBodies[name], location)
+		if requestBody == nil {
+			continue
+		}
+		if isForm(requestBody) {
+			converter.warn(location, "form request bodies cannot be components, they are inlined as formData parameters in the operations using them")
+			continue
+		}
+		if _, ok := swagger.Parameters[name]; ok {
+			converter.warn(location, "request body %q clashes with a parameter of the same name and is inlined in the operations using it", name)
+			continue
+		}
+		if swagger.Parameters == nil {
+			swagger.Parameters = map[string]*v200.Parameter{}
+		}
+		swagger.Parameters[name] = converter.bodyParameter(requestBody, name, location)
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if responseRef := components.Responses[name]; responseRef != nil {
+			if swagger.Responses == nil {
+				swagger.Responses = map[string]*v200.Response{}
+			}
+			swagger.Responses[name], _ = converter.response(responseRef, "#/components/responses/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.SecuritySchemes) {
+		location := "#/components/securitySchemes/" + escape(name)
+		securitySchemeRef := components.SecuritySchemes[name]
+		if securitySchemeRef == nil {
+			continue
+		}
+		if securitySchemeRef.Value == nil {
+			converter.warn(location, "$ref %q to a security scheme cannot be represented and is dropped", securitySchemeRef.Ref)
+			continue
+		}
+		if securityScheme := converter.securityScheme(securitySchemeRef.Value, location); securityScheme != nil {
+			if swagger.SecurityDefinitions == nil {
+				swagger.SecurityDefinitions = map[string]*v200.SecurityScheme{}
+			}
+			swagger.SecurityDefinitions[name] = securityScheme
+		}
+	}
+	// Headers and examples are inlined where they are used.
+	if len(components.Links) > 0 {
+		converter.warn("#/components/links", "links cannot be represented and are dropped")
+	}
+	if len(components.Callbacks) > 0 {
+		converter.warn("#/components/callbacks", "callbacks cannot be represented and are dropped")
+	}
+}
+
+func (converter *downConverter) securityScheme(securityScheme *v303.SecurityScheme, location string) *v200.SecurityScheme {
+	converted := &v200.SecurityScheme{Extensions: copyExtensions(securityScheme.Extensions), Type: securityScheme.Type, Description: securityScheme.Description}
+	switch securityScheme.Type {
+	case "http":
+		switch strings.ToLower(securityScheme.Scheme) {
+		case "basic":
+			converted.Type = "basic"
+		case "bearer":
+			converter.warn(location, "bearer authentication is approximated by an Authorization header API key")
+			converted.Type, converted.Name, converted.In = "apiKey", "Authorization", "header"
+		default:
+			converter.warn(location+"/scheme", "HTTP authentication scheme %q cannot be represented, the security scheme is dropped", securityScheme.Scheme)
+			return nil
+		}
+	case "apiKey":
+		if securityScheme.In == "cookie" {
+			converter.warn(location+"/in", "cookie API keys cannot be represented, the security scheme is dropped")
+			return nil
+		}
+		converted.Name, converted.In = securityScheme.Name, securityScheme.In
+	case "oauth2":
+		flows := securityScheme.Flows
+		if flows == nil {
+			flows = &v303.OAuthFlows{}
+		}
+		var kept string
+		for _, flow := range []struct {
+			name, flow string
+			value      *v303.OAuthFlow
+		}{
+			{"implicit", "implicit", flows.Implicit},
+			{"password", "password", flows.Password},
+			{"clientCredentials", "application", flows.ClientCredentials},
+			{"authorizationCode", "accessCode", flows.AuthorizationCode},
+		} {
+			if flow.value == nil {
+				continue
+			}
+			if kept != "" {
+				converter.warn(location+"/flows/"+flow.name, "only one OAuth2 flow can be declared, %s is kept and %s is dropped", kept, flow.name)
+				continue
+			}
+			kept = flow.name
+			converted.Flow = flow.flow
+			converted.Scopes = flow.value.Scopes
+			if flow.flow == "implicit" || flow.flow == "accessCode" {
+				converted.AuthorizationURL = flow.value.AuthorizationURL
+			}
+			if flow.flow != "implicit" {
+				converted.TokenURL = flow.value.TokenURL
+			}
+			if flow.value.RefreshURL != "" {
+				converter.warn(location+"/flows/"+flow.name+"/refreshUrl", "refreshUrl cannot be represented and is dropped")
+			}
+		}
+		if kept == "" {
+			converter.warn(location+"/flows", "an OAuth2 security scheme needs a flow, the security scheme is dropped")
+			return nil
+		}
+	default:
+		converter.warn(location+"/type", "security scheme type %q cannot be represented, the security scheme is dropped", securityScheme.Type)
+		return nil
+	}
+	return converted
+}
+
+func (converter *downConverter) pathItem(pathItem *v303.PathItem, location string) *v200.PathItem {
+	converted := &v200.PathItem{
+		Extensions:  copyExtensions(pathItem.Extensions),
+		Reference:   v200.Reference{Ref: pathItem.Ref},
+		Summary:     pathItem.Summary,
+		Description: pathItem.Description,
+	}
+	for i, parameterRef := range pathItem.Parameters {
+		if parameterRef != nil {
+			if parameter := converter.parameter(parameterRef, location+"/parameters/"+fmt.Sprint(i)); parameter != nil {
+				converted.Parameters = append(converted.Parameters, parameter)
+			}
+		}
+	}
+	schemes := converter.operationSchemes(pathItem.Servers, location+"/servers")
+	operation := func(operation *v303.Operation, method string) *v200.Operation {
+		if operation == nil {
+			return nil
+		}
+		return converter.operation(operation, schemes, location+"/"+method)
+	}
+	converted.Get = operation(pathItem.Get, "get")
+	converted.Put = operation(pathItem.Put, "put")
+	converted.Post = operation(pathItem.Post, "post")
+	converted.Delete = operation(pathItem.Delete, "delete")
+	converted.Options = operation(pathItem.Options, "options")
+	converted.Head = operation(pathItem.Head, "head")
+	converted.Patch = operation(pathItem.Patch, "patch")
+	if pathItem.Trace != nil {
+		converter.warn(location+"/trace", "TRACE operations cannot be represented and are dropped")
+	}
+	return converted
+}
+
+func (converter *downConverter) operation(operation *v303.Operation, schemes []string, location string) *v200.Operation {
+	converted := &v200.Operation{
+		Extensions:          copyExtensions(operation.Extensions),
+		ResponsesExtensions: copyExtensions(operation.ResponsesExtensions),
+		Tags:                operation.Tags,
+		Summary:             operation.Summary,
+		Description:         operation.Description,
+		ExternalDocs:        downconvertExternalDocs(operation.ExternalDocs),
+		OperationID:         operation.OperationID,
+		Deprecated:          operation.Deprecated,
+		Schemes:             schemes,
+	}
+	for i, parameterRef := range operation.Parameters {
+		if parameterRef != nil {
+			if parameter := converter.parameter(parameterRef, location+"/parameters/"+fmt.Sprint(i)); parameter != nil {
+				converted.Parameters = append(converted.Parameters, parameter)
+			}
+		}
+	}
+	if operation.RequestBody != nil {
+		parameters, consumes := converter.requestBodyParameters(operation.RequestBody, location+"/requestBody")
+		converted.Parameters = append(converted.Parameters, parameters...)
+		converted.Consumes = consumes
+	}
+	converted.Responses = map[string]*v200.Response{}
+	var produces []string
+	for _, code := range sortedKeys(operation.Responses) {
+		if responseRef := operation.Responses[code]; responseRef != nil {
+			response, mediaTypes := converter.response(responseRef, location+"/responses/"+escape(code))
+			converted.Responses[code] = response
+			for _, mediaType := range mediaTypes {
+				produces = appendUnique(produces, mediaType)
+			}
+		}
+	}
+	sort.Strings(produces)
+	converted.Produces = produces
+	if len(operation.Callbacks) > 0 {
+		converter.warn(location+"/callbacks", "callbacks cannot be represented and are dropped")
+	}
+	if operation.Security != nil {
+		// An empty list removes the security of the document.
+		security := append([]v200.SecurityRequirement{}, downconvertSecurity(*operation.Security)...)
+		converted.Security = &security
+	}
+	if len(operation.Servers) > 0 {
+		converted.Schemes = converter.operationSchemes(operation.Servers, location+"/servers")
+	}
+	return converted
+}
+
+// localComponent returns the name of the entry of the Components member section a local $ref points to.
+func localComponent(ref, section string) (string, bool) {
+	return localName(ref, "/components/"+section+"/")
+}
+
+func (converter *downConverter) resolveRequestBody(requestBodyRef *v303.RequestBodyRef, location string) *v303.RequestBody {
+	if requestBodyRef == nil {
+		return nil
+	}
+	if requestBodyRef.Value != nil {
+		return requestBodyRef.Value
+	}
+	if name, ok := localComponent(requestBodyRef.Ref, "requestBodies"); ok {
+		if target := converter.components.RequestBodies[name]; target != nil && target != requestBodyRef {
+			return converter.resolveRequestBody(target, location)
+		}
+	}
+	converter.warn(location, "$ref %q cannot be resolved and is dropped", requestBodyRef.Ref)
+	return nil
+}
+
+func (converter *downConverter) resolveSchema(schemaRef *v303.SchemaRef) *v303.Schema {
+	for depth := 0; schemaRef != nil && depth < 64; depth++ {
+		if schemaRef.Value != nil {
+			return schemaRef.Value
+		}
+		name, ok := localComponent(schemaRef.Ref, "schemas")
+		if !ok {
+			return nil
+		}
+		schemaRef = converter.components.Schema[name]
+	}
+	return nil
+}
+
+func (converter *downConverter) resolveHeader(headerRef *v303.HeaderRef) *v303.Header {
+	for depth := 0; headerRef != nil && depth < 64; depth++ {
+		if headerRef.Value != nil {
+			return headerRef.Value
+		}
+		name, ok := localComponent(headerRef.Ref, "headers")
+		if !ok {
+			return nil
+		}
+		headerRef = converter.components.Headers[name]
+	}
+	return nil
+}
+
+func (converter *downConverter) resolveExample(exampleRef *v303.ExampleRef) *v303.Example {
+	for depth := 0; exampleRef != nil && depth < 64; depth++ {
+		if exampleRef.Value != nil {
+			return exampleRef.Value
+		}
+		name, ok := localComponent(exampleRef.Ref, "examples")
+		if !ok {
+			return nil
+		}
+		exampleRef = converter.components.Examples[name]
+	}
+	return nil
+}
+
+// downRef points a reference to a Components member at the matching Swagger 2.0 section, in the same file.
+func downRef(ref string) string {
+	i := strings.IndexByte(ref, '#')
+	if i < 0 {
+		return ref
+	}
+	file, pointer := ref[:i], ref[i+1:]
+	for _, section := range downRefSections {
+		if strings.HasPrefix(pointer, section.from) {
+			return file + "#" + section.to + pointer[len(section.from):]
+		}
+	}
+	return ref
+}
+
+// isForm reports whether a request body is only described by form media types.
+func isForm(requestBody *v303.RequestBody) bool {
+	if len(requestBody.Content) == 0 {
+		return false
+	}
+	for mediaType := range requestBody.Content {
+		if !formMediaTypes[mediaType] {
+			return false
+		}
+	}
+	return true
+}
+
+// requestBodyParameters turns a request body into a body parameter, or formData parameters for form media
+// types, along with the media types it consumes.
+func (converter *downConverter) requestBodyParameters(requestBodyRef *v303.RequestBodyRef, location string) ([]*v200.Parameter, []string) {
+	requestBody := converter.resolveRequestBody(requestBodyRef, location)
+	if requestBody == nil {
+		return nil, nil
+	}
+	consumes := sortedKeys(requestBody.Content)
+	if !isForm(requestBody) {
+		for _, mediaType := range consumes {
+			if formMediaTypes[mediaType] {
+				converter.warn(location+"/content/"+escape(mediaType), "form media types cannot be consumed along a body, %s is dropped", mediaType)
+				consumes = removeString(consumes, mediaType)
+			}
+		}
+		if name, ok := localComponent(requestBodyRef.Ref, "requestBodies"); ok {
+			if _, clash := converter.components.Parameters[name]; !clash {
+				return []*v200.Parameter{{Reference: v200.Reference{Ref: downRef(requestBodyRef.Ref)}}}, consumes
+			}
+		}
+		return []*v200.Parameter{converter.bodyParameter(requestBody, "body", location)}, consumes
+	}
+	return converter.formParameters(requestBody, location), consumes
+}
+
+// bodyParameter turns a request body into a body parameter. The schema of the first media type, JSON when
+// available, is used.
+func (converter *downConverter) bodyParameter(requestBody *v303.RequestBody, name, location string) *v200.Parameter {
+	parameter := &v200.Parameter{Extensions: copyExtensions(requestBody.Extensions), Name: name, In: "body",
+		Description: requestBody.Description, Required: requestBody.Required}
+	if mediaType, schema := converter.contentSchema(requestBody.Content, location+"/content"); schema != nil {
+		parameter.Schema = converter.schema(schema, location+"/content/"+escape(mediaType)+"/schema")
+	} else {
+		parameter.Schema = &v200.Schema{}
+	}
+	return parameter
+}
+
+// contentSchema returns the schema shared by the media types of content. Swagger 2.0 declares a single schema
+// for every media type, the one of application/json or else of the first media type is used.
+func (converter *downConverter) contentSchema(content map[string]*v303.MediaType, location string) (string, *v303.SchemaRef) {
+	names := sortedKeys(content)
+	if len(names) == 0 {
+		return "", nil
+	}
+	chosen := names[0]
+	if content[defaultMediaType] != nil {
+		chosen = defaultMediaType
+	}
+	for _, name := range names {
+		mediaType := content[name]
+		if name == chosen || mediaType == nil || mediaType.Schema == nil {
+			continue
+		}
+		if content[chosen] == nil || mediaType.Schema != content[chosen].Schema &&
+			(mediaType.Schema.Ref == "" || mediaType.Schema.Ref != content[chosen].Schema.Ref) {
+			converter.warn(location+"/"+escape(name)+"/schema", "a single schema can be declared for all media types, the one of %s is used", chosen)
+		}
+	}
+	if content[chosen] == nil {
+		return chosen, nil
+	}
+	return chosen, content[chosen].Schema
+}
+
+// formParameters turns the properties of a form request body schema into formData parameters.
+func (converter *downConverter) formParameters(requestBody *v303.RequestBody, location string) []*v200.Parameter {
+	mediaTypeName, schemaRef := converter.contentSchema(requestBody.Content, location+"/content")
+	schemaLocation := location + "/content/" + escape(mediaTypeName) + "/schema"
+	schema := converter.resolveSchema(schemaRef)
+	if schema == nil || schema.Properties == nil {
+		if schemaRef != nil {
+			converter.warn(schemaLocation, "only object schemas with properties can be represented as formData parameters, the schema is dropped")
+		}
+		return nil
+	}
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	var encoding map[string]*v303.Encoding
+	if mediaType := requestBody.Content[mediaTypeName]; mediaType != nil {
+		encoding = mediaType.Encoding
+	}
+	var parameters []*v200.Parameter
+	for _, name := range sortedKeys(schema.Properties) {
+		propertyLocation := schemaLocation + "/properties/" + escape(name)
+		property := converter.resolveSchema(schema.Properties[name])
+		parameter := &v200.Parameter{Name: name, In: "formData", Required: required[name]}
+		if property != nil {
+			parameter.Description = property.Description
+		}
+		simple := converter.simpleType(schema.Properties[name], propertyLocation)
+		if simple.Type == "string" && (simple.Format == "binary" || simple.Format == "byte" && mediaTypeName == "multipart/form-data") {
+			simple.Type, simple.Format = "file", ""
+		}
+		setParameterType(parameter, simple)
+		if parameter.Type == "array" {
+			style, explode := "form", (*bool)(nil)
+			if encoding[name] != nil {
+				if encoding[name].Style != "" {
+					style = encoding[name].Style
+				}
+				explode = encoding[name].Explode
+			}
+			parameter.CollectionFormat = converter.collectionFormat("query", style, explode, location+"/content/"+escape(mediaTypeName)+"/encoding/"+escape(name))
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// parameter converts a non-body parameter. It returns nil when the parameter cannot be represented.
+func (converter *downConverter) parameter(parameterRef *v303.ParameterRef, location string) *v200.Parameter {
+	if parameterRef.Ref != "" {
+		if name, ok := localComponent(parameterRef.Ref, "parameters"); ok {
+			target := converter.components.Parameters[name]
+			if target != nil && target.Value != nil && target.Value.In == "cookie" {
+				converter.warn(location, "cookie parameters cannot be represented and are dropped")
+				return nil
+			}
+		}
+		return &v200.Parameter{Reference: v200.Reference{Ref: downRef(parameterRef.Ref)}}
+	}
+	parameter := parameterRef.Value
+	if parameter == nil {
+		return nil
+	}
+	if parameter.In == "cookie" {
+		converter.warn(location, "cookie parameters cannot be represented and are dropped")
+		return nil
+	}
+	converted := &v200.Parameter{
+		Extensions:  copyExtensions(parameter.Extensions),
+		Name:        parameter.Name,
+		In:          parameter.In,
+		Description: parameter.Description,
+		Required:    parameter.Required,
+	}
+	if parameter.AllowEmptyValue {
+		converted.AllowEmptyValue = true
+	}
+	schema, schemaLocation := parameter.Schema, location+"/schema"
+	if schema == nil && len(parameter.Content) > 0 {
+		var mediaType string
+		mediaType, schema = converter.contentSchema(parameter.Content, location+"/content")
+		schemaLocation = location + "/content/" + escape(mediaType) + "/schema"
+		converter.warn(location+"/content", "parameter content cannot be represented, the parameter is described by the %s schema", mediaType)
+	}
+	setParameterType(converted, converter.simpleType(schema, schemaLocation))
+	if converted.Type == "array" {
+		converted.CollectionFormat = converter.collectionFormat(parameter.In, parameter.Style, parameter.Explode, location)
+	} else if parameter.Style != "" && parameter.Style != "form" && parameter.Style != "simple" {
+		converter.warn(location+"/style", "style %q cannot be represented and is dropped", parameter.Style)
+	}
+	if parameter.Deprecated {
+		converter.warn(location+"/deprecated", "deprecated parameters cannot be represented, the parameter is kept")
+	}
+	if len(parameter.Example) > 0 || len(parameter.Examples) > 0 {
+		converter.warn(location, "parameter examples cannot be represented and are dropped")
+	}
+	return converted
+}
+
+// collectionFormat maps the style and explode of an array parameter located in in to a collectionFormat.
+// http://spec.openapis.org/oas/v3.0.3#style-values
+func (converter *downConverter) collectionFormat(in, style string, explode *bool, location string) string {
+	if style == "" {
+		style = "simple"
+		if in == "query" || in == "cookie" {
+			style = "form"
+		}
+	}
+	exploded := style == "form"
+	if explode != nil {
+		exploded = *explode
+	}
+	switch {
+	case style == "form" && exploded:
+		if in == "query" {
+			return "multi"
+		}
+	case style == "form", style == "simple" && !exploded:
+		return "csv"
+	case style == "spaceDelimited" && !exploded:
+		return "ssv"
+	case style == "pipeDelimited" && !exploded:
+		return "pipes"
+	}
+	converter.warn(location+"/style", "style %q with explode %t cannot be represented, csv is used", style, exploded)
+	return "csv"
+}
+
+// simpleType converts the schema of a non-body parameter, a header or an array item. Swagger 2.0 only
+// allows primitive types and arrays of them there.
+func (converter *downConverter) simpleType(schemaRef *v303.SchemaRef, location string) simpleType {
+	schema := converter.resolveSchema(schemaRef)
+	if schema == nil {
+		if schemaRef != nil && schemaRef.Ref != "" {
+			converter.warn(location, "$ref %q cannot be used outside of a body, the value is described as a string", schemaRef.Ref)
+		}
+		return simpleType{Type: "string"}
+	}
+	if schemaRef.Ref != "" {
+		converter.warn(location, "$ref %q cannot be used outside of a body, the schema is inlined", schemaRef.Ref)
+	}
+	simple := simpleType{
+		Type:             schema.Type,
+		Format:           schema.Format,
+		Pattern:          schema.Pattern,
+		Enum:             schema.Enum,
+		Maximum:          schema.Maximum,
+		Minimum:          schema.Minimum,
+		MultipleOf:       schema.MultipleOf,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+	}
+	simple.Default = schema.Default
+	switch schema.Type {
+	case "string", "number", "integer", "boolean", "array":
+	case "":
+		converter.warn(location, "a type is required outside of a body, the value is described as a string")
+		simple.Type = "string"
+	default:
+		converter.warn(location+"/type", "type %q cannot be used outside of a body, the value is described as a string", schema.Type)
+		simple.Type = "string"
+	}
+	if simple.Type == "array" {
+		itemsType := converter.simpleType(schema.Items, location+"/items")
+		simple.Items = &v200.Items{}
+		setItemsType(simple.Items, itemsType)
+		if itemsType.Type == "array" {
+			simple.Items.CollectionFormat = "csv"
+		}
+	}
+	return simple
+}
+
+func setParameterType(parameter *v200.Parameter, simple simpleType) {
+	parameter.Type, parameter.Format, parameter.Pattern = simple.Type, simple.Format, simple.Pattern
+	parameter.Items, parameter.Default, parameter.Enum = simple.Items, simple.Default, simple.Enum
+	parameter.Maximum, parameter.Minimum, parameter.MultipleOf = simple.Maximum, simple.Minimum, simple.MultipleOf
+	parameter.ExclusiveMaximum, parameter.ExclusiveMinimum = simple.ExclusiveMaximum, simple.ExclusiveMinimum
+	parameter.MaxLength, parameter.MinLength = simple.MaxLength, simple.MinLength
+	parameter.MaxItems, parameter.MinItems, parameter.UniqueItems = simple.MaxItems, simple.MinItems, simple.UniqueItems
+}
+
+func setHeaderType(header *v200.Header, simple simpleType) {
+	header.Type, header.Format, header.Pattern = simple.Type, simple.Format, simple.Pattern
+	header.Items, header.Default, header.Enum = simple.Items, simple.Default, simple.Enum
+	header.Maximum, header.Minimum, header.MultipleOf = simple.Maximum, simple.Minimum, simple.MultipleOf
+	header.ExclusiveMaximum, header.ExclusiveMinimum = simple.ExclusiveMaximum, simple.ExclusiveMinimum
+	header.MaxLength, header.MinLength = simple.MaxLength, simple.MinLength
+	header.MaxItems, header.MinItems, header.UniqueItems = simple.MaxItems, simple.MinItems, simple.UniqueItems
+}
+
+func setItemsType(items *v200.Items, simple simpleType) {
+	items.Type, items.Format, items.Pattern = simple.Type, simple.Format, simple.Pattern
+	items.Items, items.Default, items.Enum = simple.Items, simple.Default, simple.Enum
+	items.Maximum, items.Minimum, items.MultipleOf = simple.Maximum, simple.Minimum, simple.MultipleOf
+	items.ExclusiveMaximum, items.ExclusiveMinimum = simple.ExclusiveMaximum, simple.ExclusiveMinimum
+	items.MaxLength, items.MinLength = simple.MaxLength, simple.MinLength
+	items.MaxItems, items.MinItems, items.UniqueItems = simple.MaxItems, simple.MinItems, simple.UniqueItems
+}
+
+// response converts a response and returns the media types it produces.
+func (converter *downConverter) response(responseRef *v303.ResponseRef, location string) (*v200.Response, []string) {
+	response := responseRef.Value
+	if responseRef.Ref != "" {
+		if name, ok := localComponent(responseRef.Ref, "responses"); ok && response == nil {
+			if target := converter.components.Responses[name]; target != nil && target != responseRef {
+				response = target.Value
+			}
+		}
+		var mediaTypes []string
+		if response != nil {
+			mediaTypes = sortedKeys(response.Content)
+		}
+		return &v200.Response{Reference: v200.Reference{Ref: downRef(responseRef.Ref)}}, mediaTypes
+	}
+	if response == nil {
+		return &v200.Response{}, nil
+	}
+	converted := &v200.Response{Extensions: copyExtensions(response.Extensions), Description: response.Description}
+	for _, name := range sortedKeys(response.Headers) {
+		headerLocation := location + "/headers/" + escape(name)
+		header := converter.resolveHeader(response.Headers[name])
+		if header == nil {
+			converter.warn(headerLocation, "$ref %q cannot be resolved and is dropped", response.Headers[name].Ref)
+			continue
+		}
+		if converted.Headers == nil {
+			converted.Headers = map[string]*v200.Header{}
+		}
+		converted.Headers[name] = converter.header(header, headerLocation)
+	}
+	if mediaType, schema := converter.contentSchema(response.Content, location+"/content"); schema != nil {
+		converted.Schema = converter.schema(schema, location+"/content/"+escape(mediaType)+"/schema")
+	}
+	for _, name := range sortedKeys(response.Content) {
+		mediaType := response.Content[name]
+		if mediaType == nil {
+			continue
+		}
+		example := mediaType.Example
+		if example == nil {
+			for _, exampleName := range sortedKeys(mediaType.Examples) {
+				if resolved := converter.resolveExample(mediaType.Examples[exampleName]); resolved != nil {
+					example = resolved.Value
+				}
+				if len(mediaType.Examples) > 1 {
+					converter.warn(location+"/content/"+escape(name)+"/examples", "a single example per media type can be declared, %q is kept", exampleName)
+				}
+				break
+			}
+		}
+		if example != nil {
+			if converted.Example == nil {
+				converted.Example = map[string]json.RawMessage{}
+			}
+			converted.Example[name] = example
+		}
+	}
+	if len(response.Links) > 0 {
+		converter.warn(location+"/links", "links cannot be represented and are dropped")
+	}
+	return converted, sortedKeys(response.Content)
+}
+
+func (converter *downConverter) header(header *v303.Header, location string) *v200.Header {
+	converted := &v200.Header{Extensions: copyExtensions(header.Extensions), Description: header.Description}
+	schema, schemaLocation := header.Schema, location+"/schema"
+	if schema == nil && len(header.Content) > 0 {
+		var mediaType string
+		mediaType, schema = converter.contentSchema(header.Content, location+"/content")
+		schemaLocation = location + "/content/" + escape(mediaType) + "/schema"
+		converter.warn(location+"/content", "header content cannot be represented, the header is described by the %s schema", mediaType)
+	}
+	setHeaderType(converted, converter.simpleType(schema, schemaLocation))
+	if converted.Type == "array" {
+		converted.CollectionFormat = "csv"
+	}
+	return converted
+}
+
+func (converter *downConverter) schema(schemaRef *v303.SchemaRef, location string) *v200.Schema {
+	if schemaRef.Ref != "" {
+		return &v200.Schema{Reference: v200.Reference{Ref: downRef(schemaRef.Ref)}}
+	}
+	schema := schemaRef.Value
+	if schema == nil {
+		return &v200.Schema{}
+	}
+	converted := &v200.Schema{
+		Extensions:       copyExtensions(schema.Extensions),
+		Title:            schema.Title,
+		MultipleOf:       schema.MultipleOf,
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		MaxItems:         schema.MaxItems,
+		MinItems:         schema.MinItems,
+		UniqueItems:      schema.UniqueItems,
+		MaxProperties:    schema.MaxProperties,
+		MinProperties:    schema.MinProperties,
+		Required:         schema.Required,
+		Enum:             schema.Enum,
+		Type:             schema.Type,
+		Description:      schema.Description,
+		Format:           schema.Format,
+		Default:          schema.Default,
+		ReadOnly:         schema.ReadOnly,
+		ExternalDocs:     downconvertExternalDocs(schema.ExternalDocs),
+	}
+	if schema.Discriminator != nil {
+		converted.Discriminator = schema.Discriminator.PropertyName
+		if len(schema.Discriminator.Mapping) > 0 {
+			converter.warn(location+"/discriminator/mapping", "discriminator mappings cannot be represented, the schema names are used as values")
+		}
+	}
+	if schema.Xml != nil {
+		converted.Xml = &v200.XML{
+			Extensions: copyExtensions(schema.Xml.Extensions),
+			Name:       schema.Xml.Name,
+			Namespace:  schema.Xml.Namespace,
+			Prefix:     schema.Xml.Prefix,
+			Attribute:  schema.Xml.Attribute,
+			Wrapped:    schema.Xml.Wrapped,
+		}
+	}
+	converted.Example = schema.Example
+	if schema.Items != nil {
+		converted.Items = converter.schema(schema.Items, location+"/items")
+	}
+	for i, item := range schema.AllOf {
+		if item != nil {
+			converted.AllOf = append(converted.AllOf, converter.schema(item, location+"/allOf/"+fmt.Sprint(i)))
+		}
+	}
+	for _, keyword := range []struct {
+		name    string
+		schemas []*v303.SchemaRef
+	}{{"oneOf", schema.OneOf}, {"anyOf", schema.AnyOf}} {
+		converter.alternatives(converted, keyword.name, keyword.schemas, location)
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if property := schema.Properties[name]; property != nil {
+			if converted.Properties == nil {
+				converted.Properties = map[string]*v200.Schema{}
+			}
+			converted.Properties[name] = converter.schema(property, location+"/properties/"+escape(name))
+		}
+	}
+	if additionalProperties := schema.AdditionalProperties; additionalProperties != nil {
+		converted.AdditionalProperties = &v200.AdditionalProperties{Allowed: additionalProperties.Allowed}
+		if additionalProperties.Schema != nil {
+			converted.AdditionalProperties.Schema = converter.schema(additionalProperties.Schema, location+"/additionalProperties")
+		}
+	}
+	if schema.Nullable {
+		converter.warn(location+"/nullable", "null values cannot be represented, the schema does not accept null")
+	}
+	if schema.Not != nil {
+		converter.warn(location+"/not", "not cannot be represented and is dropped")
+	}
+	if schema.WriteOnly {
+		converter.warn(location+"/writeOnly", "writeOnly cannot be represented and is dropped")
+	}
+	if schema.Deprecated {
+		converter.warn(location+"/deprecated", "deprecated cannot be represented and is dropped")
+	}
+	return converted
+}
+
+// alternatives approximates oneOf and anyOf. A single alternative is the schema itself and becomes an allOf
+// member, alternatives sharing the same primitive type keep that type, other ones accept any value.
+func (converter *downConverter) alternatives(converted *v200.Schema, keyword string, schemas []*v303.SchemaRef, location string) {
+	if len(schemas) == 0 {
+		return
+	}
+	if len(schemas) == 1 {
+		if schemas[0] != nil {
+			converted.AllOf = append(converted.AllOf, converter.schema(schemas[0], location+"/"+keyword+"/0"))
+		}
+		return
+	}
+	var shared string
+	for i, schemaRef := range schemas {
+		schema := converter.resolveSchema(schemaRef)
+		if schema == nil || schema.Type == "" || schema.Type == "object" || schema.Type == "array" || i > 0 && schema.Type != shared {
+			shared = ""
+			break
+		}
+		shared = schema.Type
+	}
+	if shared != "" && (converted.Type == "" || converted.Type == shared) {
+		converted.Type = shared
+		converter.warn(location+"/"+keyword, "%s cannot be represented, it is approximated by type %s", keyword, shared)
+		return
+	}
+	converter.warn(location+"/"+keyword, "%s cannot be represented and is dropped, the schema no longer restricts values to the alternatives", keyword)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func removeString(values []string, value string) []string {
+	var kept []string
+	for _, existing := range values {
+		if existing != value {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
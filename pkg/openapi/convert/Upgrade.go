@@ -0,0 +1,603 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/openapi/v310"
+)
+
+// Upgrade converts an OpenAPI 3.0.3 document into an OpenAPI 3.1.0 document. Schemas are rewritten to their
+// JSON Schema 2020-12 equivalent: nullable adds "null" to the types, example becomes examples, boolean
+// exclusiveMinimum and exclusiveMaximum become numeric ones, and a boolean additionalProperties becomes a boolean
+// schema.
+// https://spec.openapis.org/oas/v3.1.0
+func Upgrade(openAPI *v303.OpenAPI) (*v310.OpenAPI, []Warning) {
+	if openAPI == nil {
+		return nil, nil
+	}
+	converter := &upgrader{}
+	return converter.openAPI(openAPI), converter.warnings
+}
+
+type upgrader struct {
+	warnings []Warning
+}
+
+func (converter *upgrader) warn(location, format string, args ...interface{}) {
+	converter.warnings = append(converter.warnings, Warning{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+func (converter *upgrader) openAPI(openAPI *v303.OpenAPI) *v310.OpenAPI {
+	upgraded := &v310.OpenAPI{
+		OpenAPI:      "3.1.0",
+		Info:         upgradeInfo(openAPI.Info),
+		Servers:      upgradeServers(openAPI.Servers),
+		Components:   converter.components(openAPI.Components),
+		ExternalDocs: upgradeExternalDocs(openAPI.ExternalDocs),
+	}
+	for _, requirement := range openAPI.Security {
+		upgraded.Security = append(upgraded.Security, v310.SecurityRequirement(requirement))
+	}
+	for _, tag := range openAPI.Tags {
+		if tag != nil {
+			upgraded.Tags = append(upgraded.Tags, &v310.Tag{Name: tag.Name, Description: tag.Description, ExternalDocs: upgradeExternalDocs(tag.ExternalDocs)})
+		}
+	}
+	upgraded.Paths = map[string]*v310.PathItem{}
+	for _, name := range sortedKeys(openAPI.Paths) {
+		if pathItem := openAPI.Paths[name]; pathItem != nil {
+			upgraded.Paths[name] = converter.pathItem(pathItem, "#/paths/"+escape(name))
+		}
+	}
+	return upgraded
+}
+
+func upgradeInfo(info *v303.Info) *v310.Info {
+	if info == nil {
+		return nil
+	}
+	upgraded := &v310.Info{Title: info.Title, Description: info.Description, TermsOfService: info.TermsOfService, Version: info.Version}
+	if info.Contact != nil {
+		upgraded.Contact = &v310.Contact{Name: info.Contact.Name, Url: info.Contact.Url, Email: info.Contact.Email}
+	}
+	if info.License != nil {
+		upgraded.License = &v310.License{Name: info.License.Name, Url: info.License.Url}
+	}
+	return upgraded
+}
+
+func upgradeExternalDocs(externalDocs *v303.ExternalDocumentation) *v310.ExternalDocumentation {
+	if externalDocs == nil {
+		return nil
+	}
+	return &v310.ExternalDocumentation{Description: externalDocs.Description, URL: externalDocs.URL}
+}
+
+func upgradeServers(servers []*v303.Server) []*v310.Server {
+	var upgraded []*v310.Server
+	for _, server := range servers {
+		if server != nil {
+			upgraded = append(upgraded, upgradeServer(server))
+		}
+	}
+	return upgraded
+}
+
+func upgradeServer(server *v303.Server) *v310.Server {
+	if server == nil {
+		return nil
+	}
+	upgraded := &v310.Server{Url: server.Url, Description: server.Description}
+	for _, name := range sortedKeys(server.Variables) {
+		if variable := server.Variables[name]; variable != nil {
+			if upgraded.Variables == nil {
+				upgraded.Variables = map[string]*v310.ServerVariable{}
+			}
+			upgraded.Variables[name] = &v310.ServerVariable{Enum: variable.Enum, Default: variable.Default, Description: variable.Description}
+		}
+	}
+	return upgraded
+}
+
+func (converter *upgrader) components(components *v303.Components) *v310.Components {
+	if components == nil {
+		return nil
+	}
+	upgraded := &v310.Components{}
+	location := "#/components/"
+	for _, name := range sortedKeys(components.Schema) {
+		if schemaRef := components.Schema[name]; schemaRef != nil {
+			if upgraded.Schemas == nil {
+				upgraded.Schemas = map[string]*v310.Schema{}
+			}
+			upgraded.Schemas[name] = converter.schema(schemaRef, location+"schemas/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if responseRef := components.Responses[name]; responseRef != nil {
+			if upgraded.Responses == nil {
+				upgraded.Responses = map[string]*v310.ResponseRef{}
+			}
+			upgraded.Responses[name] = converter.responseRef(responseRef, location+"responses/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		if parameterRef := components.Parameters[name]; parameterRef != nil {
+			if upgraded.Parameters == nil {
+				upgraded.Parameters = map[string]*v310.ParameterRef{}
+			}
+			upgraded.Parameters[name] = converter.parameterRef(parameterRef, location+"parameters/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.Examples) {
+		if exampleRef := components.Examples[name]; exampleRef != nil {
+			if upgraded.Examples == nil {
+				upgraded.Examples = map[string]*v310.ExampleRef{}
+			}
+			upgraded.Examples[name] = converter.exampleRef(exampleRef, location+"examples/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		if requestBodyRef := components.RequestBodies[name]; requestBodyRef != nil {
+			if upgraded.RequestBodies == nil {
+				upgraded.RequestBodies = map[string]*v310.RequestBodyRef{}
+			}
+			upgraded.RequestBodies[name] = converter.requestBodyRef(requestBodyRef, location+"requestBodies/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.Headers) {
+		if headerRef := components.Headers[name]; headerRef != nil {
+			if upgraded.Headers == nil {
+				upgraded.Headers = map[string]*v310.HeaderRef{}
+			}
+			upgraded.Headers[name] = converter.headerRef(headerRef, location+"headers/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.SecuritySchemes) {
+		if securitySchemeRef := components.SecuritySchemes[name]; securitySchemeRef != nil {
+			if upgraded.SecuritySchemes == nil {
+				upgraded.SecuritySchemes = map[string]*v310.SecuritySchemeRef{}
+			}
+			upgraded.SecuritySchemes[name] = upgradeSecuritySchemeRef(securitySchemeRef)
+		}
+	}
+	for _, name := range sortedKeys(components.Links) {
+		if linkRef := components.Links[name]; linkRef != nil {
+			if upgraded.Links == nil {
+				upgraded.Links = map[string]*v310.LinkRef{}
+			}
+			upgraded.Links[name] = converter.linkRef(linkRef, location+"links/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(components.Callbacks) {
+		if callbackRef := components.Callbacks[name]; callbackRef != nil {
+			if upgraded.Callbacks == nil {
+				upgraded.Callbacks = map[string]*v310.CallbackRef{}
+			}
+			upgraded.Callbacks[name] = converter.callbackRef(callbackRef, location+"callbacks/"+escape(name))
+		}
+	}
+	return upgraded
+}
+
+func (converter *upgrader) pathItem(pathItem *v303.PathItem, location string) *v310.PathItem {
+	upgraded := &v310.PathItem{
+		Ref:         pathItem.Ref,
+		Summary:     pathItem.Summary,
+		Description: pathItem.Description,
+		Servers:     upgradeServers(pathItem.Servers),
+	}
+	for i, parameterRef := range pathItem.Parameters {
+		if parameterRef != nil {
+			upgraded.Parameters = append(upgraded.Parameters, converter.parameterRef(parameterRef, location+"/parameters/"+fmt.Sprint(i)))
+		}
+	}
+	operation := func(operation *v303.Operation, method string) *v310.Operation {
+		if operation == nil {
+			return nil
+		}
+		return converter.operation(operation, location+"/"+method)
+	}
+	upgraded.Get = operation(pathItem.Get, "get")
+	upgraded.Put = operation(pathItem.Put, "put")
+	upgraded.Post = operation(pathItem.Post, "post")
+	upgraded.Delete = operation(pathItem.Delete, "delete")
+	upgraded.Options = operation(pathItem.Options, "options")
+	upgraded.Head = operation(pathItem.Head, "head")
+	upgraded.Patch = operation(pathItem.Patch, "patch")
+	upgraded.Trace = operation(pathItem.Trace, "trace")
+	return upgraded
+}
+
+func (converter *upgrader) operation(operation *v303.Operation, location string) *v310.Operation {
+	upgraded := &v310.Operation{
+		Tags:         operation.Tags,
+		Summary:      operation.Summary,
+		Description:  operation.Description,
+		ExternalDocs: upgradeExternalDocs(operation.ExternalDocs),
+		OperationID:  operation.OperationID,
+		Deprecated:   operation.Deprecated,
+		Servers:      upgradeServers(operation.Servers),
+	}
+	for i, parameterRef := range operation.Parameters {
+		if parameterRef != nil {
+			upgraded.Parameters = append(upgraded.Parameters, converter.parameterRef(parameterRef, location+"/parameters/"+fmt.Sprint(i)))
+		}
+	}
+	if operation.RequestBody != nil {
+		upgraded.RequestBody = converter.requestBodyRef(operation.RequestBody, location+"/requestBody")
+	}
+	for _, code := range sortedKeys(operation.Responses) {
+		if responseRef := operation.Responses[code]; responseRef != nil {
+			if upgraded.Responses == nil {
+				upgraded.Responses = map[string]*v310.ResponseRef{}
+			}
+			upgraded.Responses[code] = converter.responseRef(responseRef, location+"/responses/"+escape(code))
+		}
+	}
+	for _, name := range sortedKeys(operation.Callbacks) {
+		if callbackRef := operation.Callbacks[name]; callbackRef != nil {
+			if upgraded.Callbacks == nil {
+				upgraded.Callbacks = map[string]*v310.CallbackRef{}
+			}
+			upgraded.Callbacks[name] = converter.callbackRef(callbackRef, location+"/callbacks/"+escape(name))
+		}
+	}
+	if operation.Security != nil {
+		security := []v310.SecurityRequirement{}
+		for _, requirement := range *operation.Security {
+			security = append(security, v310.SecurityRequirement(requirement))
+		}
+		upgraded.Security = &security
+	}
+	return upgraded
+}
+
+func (converter *upgrader) parameterRef(parameterRef *v303.ParameterRef, location string) *v310.ParameterRef {
+	if parameterRef.Ref != "" || parameterRef.Value == nil {
+		return &v310.ParameterRef{Ref: parameterRef.Ref}
+	}
+	parameter := parameterRef.Value
+	return &v310.ParameterRef{Value: &v310.Parameter{
+		Name:            parameter.Name,
+		In:              parameter.In,
+		Description:     parameter.Description,
+		Required:        parameter.Required,
+		Deprecated:      parameter.Deprecated,
+		AllowEmptyValue: parameter.AllowEmptyValue,
+		Style:           parameter.Style,
+		Explode:         parameter.Explode,
+		AllowReserved:   parameter.AllowReserved,
+		Schema:          converter.optionalSchema(parameter.Schema, location+"/schema"),
+		Example:         parameter.Example,
+		Examples:        converter.examples(parameter.Examples, location+"/examples"),
+		Content:         converter.content(parameter.Content, location+"/content"),
+	}}
+}
+
+func (converter *upgrader) headerRef(headerRef *v303.HeaderRef, location string) *v310.HeaderRef {
+	if headerRef.Ref != "" || headerRef.Value == nil {
+		return &v310.HeaderRef{Ref: headerRef.Ref}
+	}
+	header := headerRef.Value
+	return &v310.HeaderRef{Value: &v310.Header{
+		Description:     header.Description,
+		Required:        header.Required,
+		Deprecated:      header.Deprecated,
+		AllowEmptyValue: header.AllowEmptyValue,
+		Style:           header.Style,
+		Explode:         header.Explode,
+		AllowReserved:   header.AllowReserved,
+		Schema:          converter.optionalSchema(header.Schema, location+"/schema"),
+		Example:         header.Example,
+		Examples:        converter.examples(header.Examples, location+"/examples"),
+		Content:         converter.content(header.Content, location+"/content"),
+	}}
+}
+
+func (converter *upgrader) requestBodyRef(requestBodyRef *v303.RequestBodyRef, location string) *v310.RequestBodyRef {
+	if requestBodyRef.Ref != "" || requestBodyRef.Value == nil {
+		return &v310.RequestBodyRef{Ref: requestBodyRef.Ref}
+	}
+	requestBody := requestBodyRef.Value
+	return &v310.RequestBodyRef{Value: &v310.RequestBody{
+		Description: requestBody.Description,
+		Content:     converter.content(requestBody.Content, location+"/content"),
+		Required:    requestBody.Required,
+	}}
+}
+
+func (converter *upgrader) responseRef(responseRef *v303.ResponseRef, location string) *v310.ResponseRef {
+	if responseRef.Ref != "" || responseRef.Value == nil {
+		return &v310.ResponseRef{Ref: responseRef.Ref}
+	}
+	response := responseRef.Value
+	upgraded := &v310.Response{
+		Description: response.Description,
+		Content:     converter.content(response.Content, location+"/content"),
+	}
+	for _, name := range sortedKeys(response.Headers) {
+		if headerRef := response.Headers[name]; headerRef != nil {
+			if upgraded.Headers == nil {
+				upgraded.Headers = map[string]*v310.HeaderRef{}
+			}
+			upgraded.Headers[name] = converter.headerRef(headerRef, location+"/headers/"+escape(name))
+		}
+	}
+	for _, name := range sortedKeys(response.Links) {
+		if linkRef := response.Links[name]; linkRef != nil {
+			if upgraded.Links == nil {
+				upgraded.Links = map[string]*v310.LinkRef{}
+			}
+			upgraded.Links[name] = converter.linkRef(linkRef, location+"/links/"+escape(name))
+		}
+	}
+	return &v310.ResponseRef{Value: upgraded}
+}
+
+func (converter *upgrader) content(content map[string]*v303.MediaType, location string) map[string]*v310.MediaType {
+	var upgraded map[string]*v310.MediaType
+	for _, name := range sortedKeys(content) {
+		mediaType, mediaTypeLocation := content[name], location+"/"+escape(name)
+		if mediaType == nil {
+			continue
+		}
+		if upgraded == nil {
+			upgraded = map[string]*v310.MediaType{}
+		}
+		upgradedMediaType := &v310.MediaType{
+			Schema:   converter.optionalSchema(mediaType.Schema, mediaTypeLocation+"/schema"),
+			Example:  mediaType.Example,
+			Examples: converter.examples(mediaType.Examples, mediaTypeLocation+"/examples"),
+		}
+		for _, encodingName := range sortedKeys(mediaType.Encoding) {
+			encoding := mediaType.Encoding[encodingName]
+			if encoding == nil {
+				continue
+			}
+			if upgradedMediaType.Encoding == nil {
+				upgradedMediaType.Encoding = map[string]*v310.Encoding{}
+			}
+			upgradedEncoding := &v310.Encoding{
+				ContentType:   encoding.ContentType,
+				Style:         encoding.Style,
+				Explode:       encoding.Explode,
+				AllowReserved: encoding.AllowReserved,
+			}
+			for _, headerName := range sortedKeys(encoding.Headers) {
+				if headerRef := encoding.Headers[headerName]; headerRef != nil {
+					if upgradedEncoding.Headers == nil {
+						upgradedEncoding.Headers = map[string]*v310.HeaderRef{}
+					}
+					upgradedEncoding.Headers[headerName] = converter.headerRef(headerRef, mediaTypeLocation+"/encoding/"+escape(encodingName)+"/headers/"+escape(headerName))
+				}
+			}
+			upgradedMediaType.Encoding[encodingName] = upgradedEncoding
+		}
+		upgraded[name] = upgradedMediaType
+	}
+	return upgraded
+}
+
+func (converter *upgrader) examples(examples map[string]*v303.ExampleRef, location string) map[string]*v310.ExampleRef {
+	var upgraded map[string]*v310.ExampleRef
+	for _, name := range sortedKeys(examples) {
+		if exampleRef := examples[name]; exampleRef != nil {
+			if upgraded == nil {
+				upgraded = map[string]*v310.ExampleRef{}
+			}
+			upgraded[name] = converter.exampleRef(exampleRef, location+"/"+escape(name))
+		}
+	}
+	return upgraded
+}
+
+func (converter *upgrader) exampleRef(exampleRef *v303.ExampleRef, location string) *v310.ExampleRef {
+	if exampleRef.Ref != "" || exampleRef.Value == nil {
+		return &v310.ExampleRef{Ref: exampleRef.Ref}
+	}
+	example := exampleRef.Value
+	return &v310.ExampleRef{Value: &v310.Example{
+		Summary:       example.Summary,
+		Description:   example.Description,
+		Value:         example.Value,
+		ExternalValue: example.ExternalValue,
+	}}
+}
+
+func (converter *upgrader) linkRef(linkRef *v303.LinkRef, location string) *v310.LinkRef {
+	if linkRef.Ref != "" || linkRef.Value == nil {
+		return &v310.LinkRef{Ref: linkRef.Ref}
+	}
+	link := linkRef.Value
+	upgraded := &v310.Link{
+		OperationRef: link.OperationRef,
+		OperationID:  link.OperationID,
+		RequestBody:  converter.value(link.RequestBody, location+"/requestBody"),
+		Description:  link.Description,
+		Server:       upgradeServer(link.Server),
+	}
+	for _, name := range sortedKeys(link.Parameters) {
+		if upgraded.Parameters == nil {
+			upgraded.Parameters = map[string]json.RawMessage{}
+		}
+		upgraded.Parameters[name] = converter.value(link.Parameters[name], location+"/parameters/"+escape(name))
+	}
+	return &v310.LinkRef{Value: upgraded}
+}
+
+func (converter *upgrader) callbackRef(callbackRef *v303.CallbackRef, location string) *v310.CallbackRef {
+	if callbackRef.Ref != "" || callbackRef.Value == nil {
+		return &v310.CallbackRef{Ref: callbackRef.Ref}
+	}
+	upgraded := v310.Callback{}
+	for _, expression := range sortedKeys(callbackRef.Value.Paths) {
+		if pathItem := callbackRef.Value.Paths[expression]; pathItem != nil {
+			upgraded[expression] = converter.pathItem(pathItem, location+"/"+escape(expression))
+		}
+	}
+	return &v310.CallbackRef{Value: &upgraded}
+}
+
+func upgradeSecuritySchemeRef(securitySchemeRef *v303.SecuritySchemeRef) *v310.SecuritySchemeRef {
+	if securitySchemeRef.Ref != "" || securitySchemeRef.Value == nil {
+		return &v310.SecuritySchemeRef{Ref: securitySchemeRef.Ref}
+	}
+	securityScheme := securitySchemeRef.Value
+	upgraded := &v310.SecurityScheme{
+		Type:             securityScheme.Type,
+		Description:      securityScheme.Description,
+		Name:             securityScheme.Name,
+		In:               securityScheme.In,
+		Scheme:           securityScheme.Scheme,
+		BearerFormat:     securityScheme.BearerFormat,
+		OpenIDConnectURL: securityScheme.OpenIDConnectURL,
+	}
+	if flows := securityScheme.Flows; flows != nil {
+		upgraded.Flows = &v310.OAuthFlows{
+			Implicit:          upgradeOAuthFlow(flows.Implicit),
+			Password:          upgradeOAuthFlow(flows.Password),
+			ClientCredentials: upgradeOAuthFlow(flows.ClientCredentials),
+			AuthorizationCode: upgradeOAuthFlow(flows.AuthorizationCode),
+		}
+	}
+	return &v310.SecuritySchemeRef{Value: upgraded}
+}
+
+func upgradeOAuthFlow(flow *v303.OAuthFlow) *v310.OAuthFlow {
+	if flow == nil {
+		return nil
+	}
+	return &v310.OAuthFlow{AuthorizationURL: flow.AuthorizationURL, TokenURL: flow.TokenURL, RefreshURL: flow.RefreshURL, Scopes: flow.Scopes}
+}
+
+func (converter *upgrader) optionalSchema(schemaRef *v303.SchemaRef, location string) *v310.Schema {
+	if schemaRef == nil {
+		return nil
+	}
+	return converter.schema(schemaRef, location)
+}
+
+// schema rewrites a 3.0.3 schema into its JSON Schema 2020-12 equivalent.
+// https://spec.openapis.org/oas/v3.1.0#schema-object
+func (converter *upgrader) schema(schemaRef *v303.SchemaRef, location string) *v310.Schema {
+	if schemaRef.Ref != "" || schemaRef.Value == nil {
+		return &v310.Schema{Ref: schemaRef.Ref}
+	}
+	schema := schemaRef.Value
+	upgraded := &v310.Schema{
+		Title:         schema.Title,
+		MultipleOf:    schema.MultipleOf,
+		MaxLength:     schema.MaxLength,
+		MinLength:     lowerBound(schema.MinLength),
+		Pattern:       schema.Pattern,
+		MaxItems:      schema.MaxItems,
+		MinItems:      lowerBound(schema.MinItems),
+		UniqueItems:   schema.UniqueItems,
+		MaxProperties: schema.MaxProperties,
+		MinProperties: lowerBound(schema.MinProperties),
+		Required:      schema.Required,
+		Description:   schema.Description,
+		Format:        schema.Format,
+		ReadOnly:      schema.ReadOnly,
+		WriteOnly:     schema.WriteOnly,
+		Deprecated:    schema.Deprecated,
+		ExternalDocs:  upgradeExternalDocs(schema.ExternalDocs),
+	}
+	if schema.Type != "" {
+		upgraded.Type = v310.SchemaType{schema.Type}
+	}
+	if schema.ExclusiveMaximum {
+		upgraded.ExclusiveMaximum = schema.Maximum
+	} else {
+		upgraded.Maximum = schema.Maximum
+	}
+	if schema.ExclusiveMinimum {
+		upgraded.ExclusiveMinimum = schema.Minimum
+	} else {
+		upgraded.Minimum = schema.Minimum
+	}
+	// The enum is copied, null is appended to it for nullable schemas.
+	upgraded.Enum = append([]json.RawMessage(nil), schema.Enum...)
+	upgraded.Default = schema.Default
+	if schema.Example != nil {
+		upgraded.Examples = []json.RawMessage{schema.Example}
+	}
+	if schema.Nullable {
+		if schema.Type == "" {
+			converter.warn(location+"/nullable", "nullable without a type has no effect and is dropped")
+		} else {
+			upgraded.Type = append(upgraded.Type, "null")
+			if upgraded.Enum != nil {
+				upgraded.Enum = append(upgraded.Enum, json.RawMessage("null"))
+			}
+		}
+	}
+	if schema.Discriminator != nil {
+		upgraded.Discriminator = &v310.Discriminator{PropertyName: schema.Discriminator.PropertyName, Mapping: schema.Discriminator.Mapping}
+	}
+	if schema.Xml != nil {
+		upgraded.Xml = &v310.XML{
+			Name:      schema.Xml.Name,
+			Namespace: schema.Xml.Namespace,
+			Prefix:    schema.Xml.Prefix,
+			Attribute: schema.Xml.Attribute,
+			Wrapped:   schema.Xml.Wrapped,
+		}
+	}
+	upgraded.AllOf = converter.schemas(schema.AllOf, location+"/allOf")
+	upgraded.OneOf = converter.schemas(schema.OneOf, location+"/oneOf")
+	upgraded.AnyOf = converter.schemas(schema.AnyOf, location+"/anyOf")
+	upgraded.Not = converter.optionalSchema(schema.Not, location+"/not")
+	upgraded.Items = converter.optionalSchema(schema.Items, location+"/items")
+	for _, name := range sortedKeys(schema.Properties) {
+		if property := schema.Properties[name]; property != nil {
+			if upgraded.Properties == nil {
+				upgraded.Properties = map[string]*v310.Schema{}
+			}
+			upgraded.Properties[name] = converter.schema(property, location+"/properties/"+escape(name))
+		}
+	}
+	if additionalProperties := schema.AdditionalProperties; additionalProperties != nil {
+		if additionalProperties.Schema != nil {
+			upgraded.AdditionalProperties = converter.schema(additionalProperties.Schema, location+"/additionalProperties")
+		} else {
+			upgraded.AdditionalProperties = v310.BoolSchema(additionalProperties.Allowed)
+		}
+	}
+	return upgraded
+}
+
+func (converter *upgrader) schemas(schemaRefs []*v303.SchemaRef, location string) []*v310.Schema {
+	var upgraded []*v310.Schema
+	for i, schemaRef := range schemaRefs {
+		if schemaRef != nil {
+			upgraded = append(upgraded, converter.schema(schemaRef, location+"/"+fmt.Sprint(i)))
+		}
+	}
+	return upgraded
+}
+
+// value returns the JSON encoding of a link value, nil for a nil value.
+func (converter *upgrader) value(value interface{}, location string) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		converter.warn(location, "value cannot be encoded and is dropped: %v", err)
+		return nil
+	}
+	return data
+}
+
+// lowerBound returns the value of a minimum length, number of items or number of properties, 0 when it is unset,
+// which v310 omits as every value satisfies it.
+func lowerBound(bound *int) int {
+	if bound == nil {
+		return 0
+	}
+	return *bound
+}
@@ -0,0 +1,225 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Member is a member of a JSON object.
+type Member struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// Members returns the members of the JSON object data in their order of appearance.
+func Members(data []byte) ([]Member, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, errors.New("json: not an object")
+	}
+	var members []Member
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, Member{Key: key.(string), Value: value})
+	}
+	return members, nil
+}
+
+// Keys returns the keys of the JSON object data in their order of appearance, nil when data is not an object.
+func Keys(data []byte) []string {
+	members, err := Members(data)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = member.Key
+	}
+	return keys
+}
+
+// MemberKeys returns the keys of the object held by the member named key of the JSON object data.
+func MemberKeys(data []byte, key string) []string {
+	members, err := Members(data)
+	if err != nil {
+		return nil
+	}
+	for _, member := range members {
+		if member.Key == key {
+			return Keys(member.Value)
+		}
+	}
+	return nil
+}
+
+// WriteObject encodes members as a JSON object.
+func WriteObject(members []Member) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, member := range members {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(member.Key)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(member.Value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes()
+}
+
+// Reorder sorts the members of the object held by the member named key of the JSON object data: the keys
+// listed in order come first, in that order, followed by the others in lexical order.
+func Reorder(data []byte, key string, order []string) ([]byte, error) {
+	if len(order) == 0 {
+		return data, nil
+	}
+	members, err := Members(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, member := range members {
+		if member.Key != key {
+			continue
+		}
+		nested, err := Members(member.Value)
+		if err != nil {
+			return data, nil
+		}
+		rank := make(map[string]int, len(order))
+		for position, name := range order {
+			if _, ok := rank[name]; !ok {
+				rank[name] = position
+			}
+		}
+		sort.SliceStable(nested, func(a, b int) bool {
+			rankA, okA := rank[nested[a].Key]
+			rankB, okB := rank[nested[b].Key]
+			switch {
+			case okA && okB:
+				return rankA < rankB
+			case okA != okB:
+				return okA
+			}
+			return nested[a].Key < nested[b].Key
+		})
+		members[i].Value = WriteObject(nested)
+		return WriteObject(members), nil
+	}
+	return data, nil
+}
+
+// extensionPrefix starts the names of the specification extensions.
+const extensionPrefix = "x-"
+
+// IsExtension reports whether the member key of an object is a specification extension.
+func IsExtension(key string) bool {
+	return strings.HasPrefix(key, extensionPrefix)
+}
+
+// Extensions returns the members of the JSON object data which are specification extensions, nil when there are
+// none.
+func Extensions(data []byte) (map[string]json.RawMessage, error) {
+	if !bytes.Contains(data, []byte(`"`+extensionPrefix)) {
+		return nil, nil
+	}
+	members, err := Members(data)
+	if err != nil {
+		return nil, err
+	}
+	var extensions map[string]json.RawMessage
+	for _, member := range members {
+		if !IsExtension(member.Key) {
+			continue
+		}
+		if extensions == nil {
+			extensions = map[string]json.RawMessage{}
+		}
+		extensions[member.Key] = member.Value
+	}
+	return extensions, nil
+}
+
+// SplitExtensions returns the JSON object data without its specification extensions, and these extensions.
+func SplitExtensions(data []byte) ([]byte, map[string]json.RawMessage, error) {
+	extensions, err := Extensions(data)
+	if err != nil || len(extensions) == 0 {
+		return data, nil, err
+	}
+	members, err := Members(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	kept := members[:0]
+	for _, member := range members {
+		if !IsExtension(member.Key) {
+			kept = append(kept, member)
+		}
+	}
+	return WriteObject(kept), extensions, nil
+}
+
+// AppendExtensions adds extensions to the members of the JSON object data, in lexical order. It fails on a name
+// which is not a specification extension, or a value which is not valid JSON.
+func AppendExtensions(data []byte, extensions map[string]json.RawMessage) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+	members, err := Members(data)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(extensions))
+	for name := range extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := extensions[name]
+		if !IsExtension(name) {
+			return nil, fmt.Errorf("json: extension %q does not start with %q", name, extensionPrefix)
+		}
+		if !json.Valid(value) {
+			return nil, fmt.Errorf("json: extension %q is not valid JSON", name)
+		}
+		members = append(members, Member{Key: name, Value: value})
+	}
+	return WriteObject(members), nil
+}
+
+// AppendMemberExtensions adds extensions to the object held by the member named key of the JSON object data.
+func AppendMemberExtensions(data []byte, key string, extensions map[string]json.RawMessage) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+	members, err := Members(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, member := range members {
+		if member.Key != key {
+			continue
+		}
+		if members[i].Value, err = AppendExtensions(member.Value, extensions); err != nil {
+			return nil, err
+		}
+		return WriteObject(members), nil
+	}
+	return data, nil
+}
@@ -0,0 +1,303 @@
+// Package codec holds the JSON and YAML plumbing shared by the OpenAPI model packages.
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxNodes bounds the number of nodes produced once YAML aliases are expanded, so that a small document
+// made of nested aliases cannot expand into gigabytes of JSON.
+const maxNodes = 10000000
+
+// IsJSON reports whether data looks like a JSON document rather than YAML.
+func IsJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[')
+}
+
+// YAMLToJSON converts a YAML document into JSON, keeping the order of mapping keys.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	return NodeToJSON(&node)
+}
+
+// NodeToJSON converts a YAML node into JSON, keeping the order of mapping keys. Aliases are expanded and
+// merge keys (<<) are applied, keys written in the mapping itself taking precedence over merged ones.
+func NodeToJSON(node *yaml.Node) ([]byte, error) {
+	converter := &nodeConverter{anchors: map[*yaml.Node]bool{}}
+	var buffer bytes.Buffer
+	if err := converter.write(&buffer, node); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+type nodeConverter struct {
+	// anchors holds the anchored nodes being expanded, an alias to one of them is a recursive structure.
+	anchors  map[*yaml.Node]bool
+	expanded int
+}
+
+func (converter *nodeConverter) write(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case 0:
+		buffer.WriteString("null")
+		return nil
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buffer.WriteString("null")
+			return nil
+		}
+		return converter.write(buffer, node.Content[0])
+	case yaml.AliasNode:
+		if converter.anchors[node.Alias] {
+			return fmt.Errorf("yaml: line %d: recursive alias *%s", node.Line, node.Value)
+		}
+		converter.anchors[node.Alias] = true
+		defer delete(converter.anchors, node.Alias)
+		return converter.write(buffer, node.Alias)
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := converter.write(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+		return converter.count()
+	case yaml.MappingNode:
+		keys, values, err := converter.members(node)
+		if err != nil {
+			return err
+		}
+		buffer.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			name, _ := json.Marshal(key)
+			buffer.Write(name)
+			buffer.WriteByte(':')
+			if err := converter.write(buffer, values[i]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+		return converter.count()
+	case yaml.ScalarNode:
+		if err := converter.scalar(buffer, node); err != nil {
+			return err
+		}
+		return converter.count()
+	}
+	return fmt.Errorf("yaml: line %d: unsupported node kind %d", node.Line, node.Kind)
+}
+
+func (converter *nodeConverter) count() error {
+	converter.expanded++
+	if converter.expanded > maxNodes {
+		return errors.New("yaml: document is too large once its aliases are expanded")
+	}
+	return nil
+}
+
+// members returns the keys of a mapping in order along with their values, after applying merge keys.
+func (converter *nodeConverter) members(node *yaml.Node) ([]string, []*yaml.Node, error) {
+	var keys []string
+	var values []*yaml.Node
+	index := map[string]int{}
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Kind == yaml.ScalarNode && keyNode.Tag == "!!merge" {
+			merges = append(merges, valueNode)
+			continue
+		}
+		key, err := converter.key(keyNode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if position, ok := index[key]; ok {
+			values[position] = valueNode
+			continue
+		}
+		index[key] = len(keys)
+		keys = append(keys, key)
+		values = append(values, valueNode)
+	}
+	for _, merge := range merges {
+		for merge.Kind == yaml.AliasNode {
+			merge = merge.Alias
+		}
+		sources := []*yaml.Node{merge}
+		if merge.Kind == yaml.SequenceNode {
+			sources = merge.Content
+		}
+		for _, source := range sources {
+			for source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				return nil, nil, fmt.Errorf("yaml: line %d: merge key expects a mapping", source.Line)
+			}
+			mergedKeys, mergedValues, err := converter.members(source)
+			if err != nil {
+				return nil, nil, err
+			}
+			for i, key := range mergedKeys {
+				if _, ok := index[key]; ok {
+					continue
+				}
+				index[key] = len(keys)
+				keys = append(keys, key)
+				values = append(values, mergedValues[i])
+			}
+		}
+	}
+	return keys, values, nil
+}
+
+func (converter *nodeConverter) key(node *yaml.Node) (string, error) {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("yaml: line %d: mapping keys must be scalars", node.Line)
+	}
+	return node.Value, nil
+}
+
+func (converter *nodeConverter) scalar(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.ShortTag() {
+	case "!!null":
+		buffer.WriteString("null")
+	case "!!bool":
+		var value bool
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		buffer.WriteString(strconv.FormatBool(value))
+	case "!!int", "!!float":
+		if isJSONNumber(node.Value) {
+			buffer.WriteString(node.Value)
+			return nil
+		}
+		var value float64
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return fmt.Errorf("yaml: line %d: %s cannot be represented in JSON", node.Line, node.Value)
+		}
+		buffer.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	default:
+		// Strings, timestamps and binaries are all kept as their textual value.
+		value, _ := json.Marshal(node.Value)
+		buffer.Write(value)
+	}
+	return nil
+}
+
+// isJSONNumber reports whether literal is a number in the JSON grammar, so it can be copied as is and keep
+// its exact precision.
+func isJSONNumber(literal string) bool {
+	var number json.Number
+	return json.Unmarshal([]byte(literal), &number) == nil
+}
+
+// JSONToYAML converts a JSON document into YAML, keeping the order of object members.
+func JSONToYAML(data []byte) ([]byte, error) {
+	node, err := JSONToNode(data)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// JSONToNode converts a JSON document into a YAML node, keeping the order of object members.
+func JSONToNode(data []byte) (*yaml.Node, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := jsonNode(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("json: unexpected data after the top-level value")
+	}
+	return node, nil
+}
+
+func jsonNode(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token := token.(type) {
+	case json.Delim:
+		switch token {
+		case '{':
+			node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := jsonNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)}, value)
+			}
+			_, err := decoder.Token()
+			return node, err
+		case '[':
+			node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for decoder.More() {
+				value, err := jsonNode(decoder)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, value)
+			}
+			_, err := decoder.Token()
+			return node, err
+		}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: token}, nil
+	case json.Number:
+		tag := "!!int"
+		if _, err := token.Int64(); err != nil {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: token.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(token)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return nil, fmt.Errorf("json: unexpected token %v", token)
+}
@@ -0,0 +1,77 @@
+package v200
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+)
+
+// Extensions are the specification extensions of an object, its members whose name starts with "x-", as raw JSON.
+// They are embedded in every object which the specification allows to extend, so that GetExtension and
+// SetExtension are available on the object itself.
+// https://swagger.io/specification/v2/#specification-extensions
+type Extensions map[string]json.RawMessage
+
+// GetExtension decodes the extension name into the value pointed by dst. It returns false when there is no such
+// extension.
+func (extensions Extensions) GetExtension(name string, dst interface{}) (bool, error) {
+	raw, ok := extensions[name]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return true, fmt.Errorf("extension %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// SetExtension sets the extension name to the JSON encoding of value. name must start with "x-".
+func (extensions *Extensions) SetExtension(name string, value interface{}) error {
+	if !codec.IsExtension(name) {
+		return fmt.Errorf("extension %s does not start with x-", name)
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("extension %s: %w", name, err)
+	}
+	if *extensions == nil {
+		*extensions = Extensions{}
+	}
+	(*extensions)[name] = raw
+	return nil
+}
+
+// marshalExtensible returns the JSON encoding of value, the alias of an extensible object, followed by its
+// extensions.
+func marshalExtensible(value interface{}, extensions Extensions) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, extensions)
+}
+
+// unmarshalExtensible decodes data into value, the alias of an extensible object, and its extensions.
+func unmarshalExtensible(data []byte, value interface{}, extensions *Extensions) error {
+	if err := json.Unmarshal(data, value); err != nil {
+		return err
+	}
+	found, err := codec.Extensions(data)
+	*extensions = found
+	return err
+}
+
+// unmarshalExtensibleMap decodes raw, an object of named objects which the specification allows to extend such as
+// the Paths Object, into the map pointed by dst and its extensions.
+func unmarshalExtensibleMap(raw json.RawMessage, dst interface{}, extensions *Extensions) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	rest, found, err := codec.SplitExtensions(raw)
+	if err != nil {
+		return err
+	}
+	*extensions = found
+	return json.Unmarshal(rest, dst)
+}
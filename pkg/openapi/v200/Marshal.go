@@ -0,0 +1,343 @@
+package v200
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+)
+
+// marshalRef returns the JSON encoding of a Reference Object, whose members other than "$ref" are ignored.
+// https://swagger.io/specification/v2/#reference-object
+func marshalRef(ref string) ([]byte, error) {
+	return json.Marshal(Reference{Ref: ref})
+}
+
+// unmarshalArray decodes raw into the slice pointed by dst. A lone object is decoded as a one-element array, which is
+// how security was serialized before it was modelled as a list of requirements.
+func unmarshalArray(raw json.RawMessage, dst interface{}) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if raw[0] == '{' {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+	return json.Unmarshal(raw, dst)
+}
+
+// MarshalJSON returns the JSON encoding of the Swagger, always emitting the required paths object.
+// Paths keep the order they were decoded in and are followed by their extensions.
+func (swagger Swagger) MarshalJSON() ([]byte, error) {
+	type alias Swagger
+	if swagger.Paths == nil {
+		swagger.Paths = map[string]*PathItem{}
+	}
+	data, err := json.Marshal(alias(swagger))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = codec.Reorder(data, "paths", swagger.pathsOrder); err != nil {
+		return nil, err
+	}
+	if data, err = codec.AppendMemberExtensions(data, "paths", swagger.PathsExtensions); err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, swagger.Extensions)
+}
+
+// UnmarshalJSON decodes a Swagger and its extensions, recording the order of its paths and also accepting the legacy
+// single object form of security.
+func (swagger *Swagger) UnmarshalJSON(data []byte) error {
+	type alias Swagger
+	aux := struct {
+		*alias
+		Paths    json.RawMessage `json:"paths"`
+		Security json.RawMessage `json:"security"`
+	}{alias: (*alias)(swagger)}
+	if err := unmarshalExtensible(data, &aux, &swagger.Extensions); err != nil {
+		return err
+	}
+	if err := unmarshalExtensibleMap(aux.Paths, &swagger.Paths, &swagger.PathsExtensions); err != nil {
+		return err
+	}
+	if err := unmarshalArray(aux.Security, &swagger.Security); err != nil {
+		return err
+	}
+	swagger.pathsOrder = codec.MemberKeys(data, "paths")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the Operation, always emitting the required responses object.
+// Responses keep the order they were decoded in and are followed by their extensions.
+func (operation Operation) MarshalJSON() ([]byte, error) {
+	type alias Operation
+	if operation.Responses == nil {
+		operation.Responses = map[string]*Response{}
+	}
+	data, err := json.Marshal(alias(operation))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = codec.Reorder(data, "responses", operation.responsesOrder); err != nil {
+		return nil, err
+	}
+	if data, err = codec.AppendMemberExtensions(data, "responses", operation.ResponsesExtensions); err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, operation.Extensions)
+}
+
+// UnmarshalJSON decodes an Operation and its extensions, recording the order of its responses and also accepting the
+// legacy single object form of security. An absent security leaves Security nil while an empty array removes the
+// top-level security.
+func (operation *Operation) UnmarshalJSON(data []byte) error {
+	type alias Operation
+	aux := struct {
+		*alias
+		Responses json.RawMessage `json:"responses"`
+		Security  json.RawMessage `json:"security"`
+	}{alias: (*alias)(operation)}
+	if err := unmarshalExtensible(data, &aux, &operation.Extensions); err != nil {
+		return err
+	}
+	if err := unmarshalExtensibleMap(aux.Responses, &operation.Responses, &operation.ResponsesExtensions); err != nil {
+		return err
+	}
+	if err := unmarshalArray(aux.Security, &operation.Security); err != nil {
+		return err
+	}
+	operation.responsesOrder = codec.MemberKeys(data, "responses")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the Parameter and its extensions, or only its "$ref" when Ref is set.
+func (parameter Parameter) MarshalJSON() ([]byte, error) {
+	if parameter.Ref != "" {
+		return marshalRef(parameter.Ref)
+	}
+	type alias Parameter
+	return marshalExtensible(alias(parameter), parameter.Extensions)
+}
+
+// UnmarshalJSON decodes a Parameter and its extensions.
+func (parameter *Parameter) UnmarshalJSON(data []byte) error {
+	type alias Parameter
+	return unmarshalExtensible(data, (*alias)(parameter), &parameter.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Response and its extensions, or only its "$ref" when Ref is set.
+func (response Response) MarshalJSON() ([]byte, error) {
+	if response.Ref != "" {
+		return marshalRef(response.Ref)
+	}
+	type alias Response
+	return marshalExtensible(alias(response), response.Extensions)
+}
+
+// UnmarshalJSON decodes a Response and its extensions.
+func (response *Response) UnmarshalJSON(data []byte) error {
+	type alias Response
+	return unmarshalExtensible(data, (*alias)(response), &response.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Schema and its extensions, or only its "$ref" when Ref is set.
+// Properties keep the order they were decoded in.
+func (schema Schema) MarshalJSON() ([]byte, error) {
+	if schema.Ref != "" {
+		return marshalRef(schema.Ref)
+	}
+	type alias Schema
+	data, err := json.Marshal(alias(schema))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = codec.Reorder(data, "properties", schema.propertiesOrder); err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, schema.Extensions)
+}
+
+// UnmarshalJSON decodes a Schema and its extensions, recording the order of its properties. items is also accepted
+// as a one-element array, which is how it was serialized before it was modelled as a single schema.
+func (schema *Schema) UnmarshalJSON(data []byte) error {
+	type alias Schema
+	aux := struct {
+		*alias
+		Items json.RawMessage `json:"items"`
+	}{alias: (*alias)(schema)}
+	if err := unmarshalExtensible(data, &aux, &schema.Extensions); err != nil {
+		return err
+	}
+	if items := bytes.TrimSpace(aux.Items); len(items) > 0 && !bytes.Equal(items, []byte("null")) {
+		if items[0] == '[' {
+			var elements []json.RawMessage
+			if err := json.Unmarshal(items, &elements); err != nil {
+				return err
+			}
+			if len(elements) != 1 {
+				return fmt.Errorf("items must be a single schema, not an array of %d", len(elements))
+			}
+			items = elements[0]
+		}
+		schema.Items = new(Schema)
+		if err := json.Unmarshal(items, schema.Items); err != nil {
+			return err
+		}
+	}
+	schema.propertiesOrder = codec.MemberKeys(data, "properties")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of additionalProperties: its schema when Schema is set, otherwise the
+// boolean Allowed.
+func (additionalProperties AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if additionalProperties.Schema != nil {
+		return json.Marshal(additionalProperties.Schema)
+	}
+	return json.Marshal(additionalProperties.Allowed)
+}
+
+// UnmarshalJSON decodes a boolean into Allowed, or a schema into Schema with Allowed set.
+func (additionalProperties *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		*additionalProperties = AdditionalProperties{Allowed: trimmed[0] == 't'}
+		return nil
+	}
+	*additionalProperties = AdditionalProperties{Allowed: true, Schema: new(Schema)}
+	return json.Unmarshal(data, additionalProperties.Schema)
+}
+
+// MarshalJSON returns the JSON encoding of the Items and its extensions.
+func (items Items) MarshalJSON() ([]byte, error) {
+	type alias Items
+	return marshalExtensible(alias(items), items.Extensions)
+}
+
+// UnmarshalJSON decodes an Items and its extensions, also accepting the "collection_format" member written by
+// former versions.
+func (items *Items) UnmarshalJSON(data []byte) error {
+	type alias Items
+	var legacy struct {
+		CollectionFormat string `json:"collection_format"`
+	}
+	if err := unmarshalExtensible(data, (*alias)(items), &items.Extensions); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	if items.CollectionFormat == "" {
+		items.CollectionFormat = legacy.CollectionFormat
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the Tag and its extensions.
+func (tag Tag) MarshalJSON() ([]byte, error) {
+	type alias Tag
+	return marshalExtensible(alias(tag), tag.Extensions)
+}
+
+// UnmarshalJSON decodes a Tag and its extensions.
+func (tag *Tag) UnmarshalJSON(data []byte) error {
+	type alias Tag
+	return unmarshalExtensible(data, (*alias)(tag), &tag.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the SecurityScheme and its extensions.
+func (securityScheme SecurityScheme) MarshalJSON() ([]byte, error) {
+	type alias SecurityScheme
+	return marshalExtensible(alias(securityScheme), securityScheme.Extensions)
+}
+
+// UnmarshalJSON decodes a SecurityScheme and its extensions.
+func (securityScheme *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type alias SecurityScheme
+	return unmarshalExtensible(data, (*alias)(securityScheme), &securityScheme.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Header and its extensions.
+func (header Header) MarshalJSON() ([]byte, error) {
+	type alias Header
+	return marshalExtensible(alias(header), header.Extensions)
+}
+
+// UnmarshalJSON decodes a Header and its extensions.
+func (header *Header) UnmarshalJSON(data []byte) error {
+	type alias Header
+	return unmarshalExtensible(data, (*alias)(header), &header.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Info and its extensions.
+func (info Info) MarshalJSON() ([]byte, error) {
+	type alias Info
+	return marshalExtensible(alias(info), info.Extensions)
+}
+
+// UnmarshalJSON decodes an Info and its extensions.
+func (info *Info) UnmarshalJSON(data []byte) error {
+	type alias Info
+	return unmarshalExtensible(data, (*alias)(info), &info.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Contact and its extensions.
+func (contact Contact) MarshalJSON() ([]byte, error) {
+	type alias Contact
+	return marshalExtensible(alias(contact), contact.Extensions)
+}
+
+// UnmarshalJSON decodes a Contact and its extensions.
+func (contact *Contact) UnmarshalJSON(data []byte) error {
+	type alias Contact
+	return unmarshalExtensible(data, (*alias)(contact), &contact.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the License and its extensions.
+func (license License) MarshalJSON() ([]byte, error) {
+	type alias License
+	return marshalExtensible(alias(license), license.Extensions)
+}
+
+// UnmarshalJSON decodes a License and its extensions.
+func (license *License) UnmarshalJSON(data []byte) error {
+	type alias License
+	return unmarshalExtensible(data, (*alias)(license), &license.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the PathItem and its extensions.
+func (pathItem PathItem) MarshalJSON() ([]byte, error) {
+	type alias PathItem
+	return marshalExtensible(alias(pathItem), pathItem.Extensions)
+}
+
+// UnmarshalJSON decodes a PathItem and its extensions.
+func (pathItem *PathItem) UnmarshalJSON(data []byte) error {
+	type alias PathItem
+	return unmarshalExtensible(data, (*alias)(pathItem), &pathItem.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the XML and its extensions.
+func (xml XML) MarshalJSON() ([]byte, error) {
+	type alias XML
+	return marshalExtensible(alias(xml), xml.Extensions)
+}
+
+// UnmarshalJSON decodes an XML and its extensions.
+func (xml *XML) UnmarshalJSON(data []byte) error {
+	type alias XML
+	return unmarshalExtensible(data, (*alias)(xml), &xml.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the ExternalDocumentation and its extensions.
+func (externalDocumentation ExternalDocumentation) MarshalJSON() ([]byte, error) {
+	type alias ExternalDocumentation
+	return marshalExtensible(alias(externalDocumentation), externalDocumentation.Extensions)
+}
+
+// UnmarshalJSON decodes an ExternalDocumentation and its extensions.
+func (externalDocumentation *ExternalDocumentation) UnmarshalJSON(data []byte) error {
+	type alias ExternalDocumentation
+	return unmarshalExtensible(data, (*alias)(externalDocumentation), &externalDocumentation.Extensions)
+}
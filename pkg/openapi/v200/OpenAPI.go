@@ -1,214 +1,264 @@
 package v200
 
+import "encoding/json"
+
 type Swagger struct {
+	Extensions          `json:"-"`
 	Swagger             string                     `json:"swagger"`
 	Info                *Info                      `json:"info"`
-	Host                string                     `json:"host"`
-	BasePath            string                     `json:"basePath"`
-	Schemes             []string                   `json:"schemes"`
-	Consumes            []string                   `json:"consumes"`
-	Produces            []string                   `json:"produces"`
+	Host                string                     `json:"host,omitempty"`
+	BasePath            string                     `json:"basePath,omitempty"`
+	Schemes             []string                   `json:"schemes,omitempty"`
+	Consumes            []string                   `json:"consumes,omitempty"`
+	Produces            []string                   `json:"produces,omitempty"`
 	Paths               map[string]*PathItem       `json:"paths"`
-	Definitions         map[string]*Schema         `json:"definitions"`
-	Parameters          map[string]*Parameter      `json:"parameters"`
-	Responses           map[string]*Response       `json:"responses"`
-	SecurityDefinitions map[string]*SecurityScheme `json:"securityDefinitions"`
-	Security            map[string][]string        `json:"security"`
-	Tags                []*Tag                     `json:"tags"`
-	ExternalDocs        *ExternalDocumentation     `json:"externalDocs"`
+	Definitions         map[string]*Schema         `json:"definitions,omitempty"`
+	Parameters          map[string]*Parameter      `json:"parameters,omitempty"`
+	Responses           map[string]*Response       `json:"responses,omitempty"`
+	SecurityDefinitions map[string]*SecurityScheme `json:"securityDefinitions,omitempty"`
+	Security            []SecurityRequirement      `json:"security,omitempty"`
+	Tags                []*Tag                     `json:"tags,omitempty"`
+	ExternalDocs        *ExternalDocumentation     `json:"externalDocs,omitempty"`
+
+	// PathsExtensions are the specification extensions of the Paths Object.
+	PathsExtensions Extensions `json:"-"`
+
+	pathsOrder []string
 }
 
 type Tag struct {
+	Extensions   `json:"-"`
 	Name         string                 `json:"name"`
-	Description  string                 `json:"description"`
-	ExternalDocs *ExternalDocumentation `json:"externalDocs"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
 }
 
+// SecurityRequirement lists the security schemes required to execute an operation, with the scopes of the oauth2
+// ones. The document and each operation hold a list of alternative requirements.
+// https://swagger.io/specification/v2/#security-requirement-object
+type SecurityRequirement map[string][]string
+
 type SecurityScheme struct {
 	Reference
+	Extensions       `json:"-"`
 	Type             string            `json:"type"`
-	Description      string            `json:"description"`
-	Name             string            `json:"name"`
-	In               string            `json:"in"`
-	Flow             string            `json:"flow"`
-	AuthorizationURL string            `json:"authorizationUrl"`
-	TokenURL         string            `json:"tokenUrl"`
-	Scopes           map[string]string `json:"scopes"`
+	Description      string            `json:"description,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	In               string            `json:"in,omitempty"`
+	Flow             string            `json:"flow,omitempty"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"`
 }
 
 type Response struct {
 	Reference
-	Description string                 `json:"description"`
-	Schema      *Schema                `json:"schema"`
-	Headers     map[string]*Header     `json:"headers"`
-	Example     map[string]interface{} `json:"example"`
+	Extensions  `json:"-"`
+	Description string                     `json:"description"`
+	Schema      *Schema                    `json:"schema,omitempty"`
+	Headers     map[string]*Header         `json:"headers,omitempty"`
+	Example     map[string]json.RawMessage `json:"example,omitempty"`
 }
 
 type Header struct {
 	Reference
-	Description      string        `json:"description"`
-	Type             string        `json:"type"`
-	Format           string        `json:"format"`
-	Items            *Items        `json:"items"`
-	CollectionFormat string        `json:"collectionFormat"`
-	Default          interface{}   `json:"default"`
-	Maximum          int           `json:"maximum"`
-	ExclusiveMaximum bool          `json:"exclusiveMaximum"`
-	Minimum          int           `json:"minimum"`
-	ExclusiveMinimum bool          `json:"exclusiveMinimum"`
-	MaxLength        int           `json:"maxLength"`
-	MinLength        int           `json:"minLength"`
-	Pattern          string        `json:"pattern"`
-	MaxItems         int           `json:"maxItems"`
-	MinItems         int           `json:"minItems"`
-	UniqueItems      bool          `json:"uniqueItems"`
-	Enum             []interface{} `json:"enum"`
-	MultipleOf       int           `json:"multipleOf"`
+	Extensions       `json:"-"`
+	Description      string            `json:"description,omitempty"`
+	Type             string            `json:"type"`
+	Format           string            `json:"format,omitempty"`
+	Items            *Items            `json:"items,omitempty"`
+	CollectionFormat string            `json:"collectionFormat,omitempty"`
+	Default          json.RawMessage   `json:"default,omitempty"`
+	Maximum          json.Number       `json:"maximum,omitempty"`
+	ExclusiveMaximum bool              `json:"exclusiveMaximum,omitempty"`
+	Minimum          json.Number       `json:"minimum,omitempty"`
+	ExclusiveMinimum bool              `json:"exclusiveMinimum,omitempty"`
+	MaxLength        *int              `json:"maxLength,omitempty"`
+	MinLength        *int              `json:"minLength,omitempty"`
+	Pattern          string            `json:"pattern,omitempty"`
+	MaxItems         *int              `json:"maxItems,omitempty"`
+	MinItems         *int              `json:"minItems,omitempty"`
+	UniqueItems      bool              `json:"uniqueItems,omitempty"`
+	Enum             []json.RawMessage `json:"enum,omitempty"`
+	MultipleOf       json.Number       `json:"multipleOf,omitempty"`
 }
 
 type Parameter struct {
 	Reference
-	Name             string        `json:"name"`
-	In               string        `json:"in"`
-	Description      string        `json:"description"`
-	Required         bool          `json:"required"`
-	Schema           *Schema       `json:"schema"`
-	Type             string        `json:"type"`
-	Format           string        `json:"format"`
-	AllowEmptyValue  bool          `json:"allowEmptyValue"`
-	Items            *Items        `json:"items"`
-	CollectionFormat string        `json:"collectionFormat"`
-	Default          interface{}   `json:"default"`
-	Maximum          int           `json:"maximum"`
-	ExclusiveMaximum bool          `json:"exclusiveMaximum"`
-	Minimum          int           `json:"minimum"`
-	ExclusiveMinimum bool          `json:"exclusiveMinimum"`
-	MaxLength        int           `json:"maxLength"`
-	MinLength        int           `json:"minLength"`
-	Pattern          string        `json:"pattern"`
-	MaxItems         int           `json:"maxItems"`
-	MinItems         int           `json:"minItems"`
-	UniqueItems      bool          `json:"uniqueItems"`
-	Enum             []interface{} `json:"enum"`
-	MultipleOf       int           `json:"multipleOf"`
+	Extensions       `json:"-"`
+	Name             string            `json:"name"`
+	In               string            `json:"in"`
+	Description      string            `json:"description,omitempty"`
+	Required         bool              `json:"required,omitempty"`
+	Schema           *Schema           `json:"schema,omitempty"`
+	Type             string            `json:"type,omitempty"`
+	Format           string            `json:"format,omitempty"`
+	AllowEmptyValue  bool              `json:"allowEmptyValue,omitempty"`
+	Items            *Items            `json:"items,omitempty"`
+	CollectionFormat string            `json:"collectionFormat,omitempty"`
+	Default          json.RawMessage   `json:"default,omitempty"`
+	Maximum          json.Number       `json:"maximum,omitempty"`
+	ExclusiveMaximum bool              `json:"exclusiveMaximum,omitempty"`
+	Minimum          json.Number       `json:"minimum,omitempty"`
+	ExclusiveMinimum bool              `json:"exclusiveMinimum,omitempty"`
+	MaxLength        *int              `json:"maxLength,omitempty"`
+	MinLength        *int              `json:"minLength,omitempty"`
+	Pattern          string            `json:"pattern,omitempty"`
+	MaxItems         *int              `json:"maxItems,omitempty"`
+	MinItems         *int              `json:"minItems,omitempty"`
+	UniqueItems      bool              `json:"uniqueItems,omitempty"`
+	Enum             []json.RawMessage `json:"enum,omitempty"`
+	MultipleOf       json.Number       `json:"multipleOf,omitempty"`
 }
 
 type Items struct {
-	Type             string        `json:"type"`
-	Format           string        `json:"format"`
-	AllowEmptyValue  bool          `json:"allowEmptyValue"`
-	Items            *Items        `json:"items"`
-	CollectionFormat string        `json:"collection_format"`
-	Default          interface{}   `json:"default"`
-	Maximum          int           `json:"maximum"`
-	ExclusiveMaximum bool          `json:"exclusiveMaximum"`
-	Minimum          int           `json:"minimum"`
-	ExclusiveMinimum bool          `json:"exclusiveMinimum"`
-	MaxLength        int           `json:"maxLength"`
-	MinLength        int           `json:"minLength"`
-	Pattern          string        `json:"pattern"`
-	MaxItems         int           `json:"maxItems"`
-	MinItems         int           `json:"minItems"`
-	UniqueItems      bool          `json:"uniqueItems"`
-	Enum             []interface{} `json:"enum"`
-	MultipleOf       int           `json:"multipleOf"`
+	Extensions       `json:"-"`
+	Type             string            `json:"type"`
+	Format           string            `json:"format,omitempty"`
+	AllowEmptyValue  bool              `json:"allowEmptyValue,omitempty"`
+	Items            *Items            `json:"items,omitempty"`
+	CollectionFormat string            `json:"collectionFormat,omitempty"`
+	Default          json.RawMessage   `json:"default,omitempty"`
+	Maximum          json.Number       `json:"maximum,omitempty"`
+	ExclusiveMaximum bool              `json:"exclusiveMaximum,omitempty"`
+	Minimum          json.Number       `json:"minimum,omitempty"`
+	ExclusiveMinimum bool              `json:"exclusiveMinimum,omitempty"`
+	MaxLength        *int              `json:"maxLength,omitempty"`
+	MinLength        *int              `json:"minLength,omitempty"`
+	Pattern          string            `json:"pattern,omitempty"`
+	MaxItems         *int              `json:"maxItems,omitempty"`
+	MinItems         *int              `json:"minItems,omitempty"`
+	UniqueItems      bool              `json:"uniqueItems,omitempty"`
+	Enum             []json.RawMessage `json:"enum,omitempty"`
+	MultipleOf       json.Number       `json:"multipleOf,omitempty"`
 }
 
 type Info struct {
+	Extensions     `json:"-"`
 	Title          string   `json:"title"`
-	Description    string   `json:"description"`
-	TermsOfService string   `json:"termsOfService"`
-	Contact        *Contact `json:"contact"`
-	License        *License `json:"license"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
 	Version        string   `json:"version"`
 }
 
 type Contact struct {
-	Name  string `json:"name"`
-	Url   string `json:"url"`
-	Email string `json:"email"`
+	Extensions `json:"-"`
+	Name       string `json:"name,omitempty"`
+	Url        string `json:"url,omitempty"`
+	Email      string `json:"email,omitempty"`
 }
 
 type License struct {
-	Name string `json:"name"`
-	Url  string `json:"url"`
+	Extensions `json:"-"`
+	Name       string `json:"name"`
+	Url        string `json:"url,omitempty"`
 }
 
 type PathItem struct {
 	Reference
-	Summary     string       `json:"summary"`
-	Description string       `json:"description"`
-	Get         *Operation   `json:"get"`
-	Put         *Operation   `json:"put"`
-	Post        *Operation   `json:"post"`
-	Delete      *Operation   `json:"delete"`
-	Options     *Operation   `json:"options"`
-	Head        *Operation   `json:"head"`
-	Patch       *Operation   `json:"patch"`
-	Parameters  []*Parameter `json:"parameters"`
+	Extensions  `json:"-"`
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Get         *Operation   `json:"get,omitempty"`
+	Put         *Operation   `json:"put,omitempty"`
+	Post        *Operation   `json:"post,omitempty"`
+	Delete      *Operation   `json:"delete,omitempty"`
+	Options     *Operation   `json:"options,omitempty"`
+	Head        *Operation   `json:"head,omitempty"`
+	Patch       *Operation   `json:"patch,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
 }
 
 type Reference struct {
-	Ref string `json:"$ref"`
+	Ref string `json:"$ref,omitempty"`
 }
 
 type Operation struct {
-	Tags         []string               `json:"tags"`
-	Summary      string                 `json:"summary"`
-	Description  string                 `json:"description"`
-	ExternalDocs *ExternalDocumentation `json:"externalDocs"`
-	OperationID  string                 `json:"operationId"`
-	Consumes     []string               `json:"consumes"`
-	Produces     []string               `json:"produces"`
-	Parameters   []*Parameter           `json:"parameters"`
+	Extensions   `json:"-"`
+	Tags         []string               `json:"tags,omitempty"`
+	Summary      string                 `json:"summary,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+	OperationID  string                 `json:"operationId,omitempty"`
+	Consumes     []string               `json:"consumes,omitempty"`
+	Produces     []string               `json:"produces,omitempty"`
+	Parameters   []*Parameter           `json:"parameters,omitempty"`
 	Responses    map[string]*Response   `json:"responses"`
-	Schemes      []string               `json:"schemes"`
-	Deprecated   bool                   `json:"deprecated"`
-	Security     map[string][]string    `json:"security"`
+	Schemes      []string               `json:"schemes,omitempty"`
+	Deprecated   bool                   `json:"deprecated,omitempty"`
+	Security     *[]SecurityRequirement `json:"security,omitempty"`
+
+	// ResponsesExtensions are the specification extensions of the Responses Object.
+	ResponsesExtensions Extensions `json:"-"`
+
+	responsesOrder []string
 }
 
 type Schema struct {
 	Reference
-	Format               string                 `json:"format"` //date-time,email, hostname,ipv4, ipv6,uri,uriref
-	Title                string                 `json:"title"`
-	Description          string                 `json:"description"`
-	Default              string                 `json:"default"`
-	MultipleOf           int                    `json:"multipleOf"`
-	Maximum              int                    `json:"maximum"`
-	ExclusiveMaximum     bool                   `json:"exclusiveMaximum"`
-	Minimum              int                    `json:"minimum"`
-	ExclusiveMinimum     bool                   `json:"exclusiveMinimum"`
-	MaxLength            int                    `json:"maxLength"`
-	MinLength            int                    `json:"minLength"`
-	Pattern              string                 `json:"pattern"`
-	MaxItems             int                    `json:"maxItems"`
-	MinItems             int                    `json:"minItems"`
-	UniqueItems          bool                   `json:"uniqueItems"`
-	MaxProperties        int                    `json:"maxProperties"`
-	MinProperties        int                    `json:"minProperties"`
-	Required             []string               `json:"required"`
-	Enum                 []interface{}          `json:"enum"`
-	Type                 string                 `json:"type"`
-	Items                []*Schema              `json:"items"`
-	AllOf                []*Schema              `json:"allOf"`
-	Properties           map[string]*Schema     `json:"properties"`
-	AdditionalProperties map[string]*Schema     `json:"additionalProperties"`
-	Discriminator        string                 `json:"discriminator"`
-	ReadOnly             bool                   `json:"readOnly"`
-	Xml                  *XML                   `json:"xml"`
-	ExternalDocs         *ExternalDocumentation `json:"externalDocs"`
-	Example              map[string]interface{} `json:"example"`
+	Extensions           `json:"-"`
+	Format               string                 `json:"format,omitempty"` //date-time,email, hostname,ipv4, ipv6,uri,uriref
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Default              json.RawMessage        `json:"default,omitempty"`
+	MultipleOf           json.Number            `json:"multipleOf,omitempty"`
+	Maximum              json.Number            `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool                   `json:"exclusiveMaximum,omitempty"`
+	Minimum              json.Number            `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool                   `json:"exclusiveMinimum,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
+	MaxProperties        *int                   `json:"maxProperties,omitempty"`
+	MinProperties        *int                   `json:"minProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Enum                 []json.RawMessage      `json:"enum,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Items                *Schema                `json:"items,omitempty"`
+	AllOf                []*Schema              `json:"allOf,omitempty"`
+	Properties           map[string]*Schema     `json:"properties,omitempty"`
+	AdditionalProperties *AdditionalProperties  `json:"additionalProperties,omitempty"`
+	Discriminator        string                 `json:"discriminator,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+	Xml                  *XML                   `json:"xml,omitempty"`
+	ExternalDocs         *ExternalDocumentation `json:"externalDocs,omitempty"`
+	Example              json.RawMessage        `json:"example,omitempty"`
+
+	propertiesOrder []string
+}
+
+// AdditionalProperties is the value of additionalProperties, either a boolean or a schema. A nil
+// AdditionalProperties is absent, which allows any additional property.
+// https://swagger.io/specification/v2/#schema-object
+type AdditionalProperties struct {
+	// Allowed is the boolean form, ignored when Schema is set: false forbids the properties which are not listed
+	// in properties.
+	Allowed bool
+	// Schema, when set, is the schema of the properties which are not listed in properties.
+	Schema *Schema
+}
+
+// Forbidden reports whether additionalProperties is false.
+func (additionalProperties *AdditionalProperties) Forbidden() bool {
+	return additionalProperties != nil && additionalProperties.Schema == nil && !additionalProperties.Allowed
 }
 
 type XML struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Prefix    string `json:"prefix"`
-	Attribute bool   `json:"attribute"`
-	Wrapped   bool   `json:"wrapped"`
+	Extensions `json:"-"`
+	Name       string `json:"name,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Prefix     string `json:"prefix,omitempty"`
+	Attribute  bool   `json:"attribute,omitempty"`
+	Wrapped    bool   `json:"wrapped,omitempty"`
 }
 
 type ExternalDocumentation struct {
-	Description string `json:"description"`
+	Extensions  `json:"-"`
+	Description string `json:"description,omitempty"`
 	URL         string `json:"url"`
 }
@@ -0,0 +1,483 @@
+package v200
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ValidationError is a violation of the specification, located by a JSON pointer into the document.
+type ValidationError struct {
+	Location string
+	Message  string
+}
+
+func (validationError ValidationError) Error() string {
+	return validationError.Location + ": " + validationError.Message
+}
+
+// collectionFormats are the valid values of collectionFormat, multi being limited to query and formData
+// parameters.
+// https://swagger.io/specification/v2/#parameter-object
+var collectionFormats = map[string]bool{"csv": true, "ssv": true, "tsv": true, "pipes": true, "multi": true}
+
+// parameterLocations are the valid values of the in field of a Parameter.
+var parameterLocations = map[string]bool{"query": true, "header": true, "path": true, "formData": true, "body": true}
+
+// Validate checks the document against the rules of the specification which the Go types cannot express:
+// the swagger version, body and formData parameters, file parameters, array items, collection formats, security
+// definitions and the types of default, enum and example values. Parameters and responses reached through a $ref are
+// validated where they are declared.
+func (swagger *Swagger) Validate() []ValidationError {
+	validator := &validator{swagger: swagger, schemas: map[*Schema]bool{}}
+	validator.validateSwagger()
+	return validator.errors
+}
+
+type validator struct {
+	swagger *Swagger
+	errors  []ValidationError
+	// schemas are the schemas validated, which recursive schemas reach again.
+	schemas map[*Schema]bool
+}
+
+func (validator *validator) report(location, format string, args ...interface{}) {
+	validator.errors = append(validator.errors, ValidationError{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// escape escapes a JSON pointer reference token.
+// https://tools.ietf.org/html/rfc6901#section-3
+func escape(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func unescape(token string) string {
+	return strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+}
+
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+func (validator *validator) validateSwagger() {
+	swagger := validator.swagger
+	if swagger.Swagger != "2.0" {
+		validator.report("#/swagger", "swagger must be \"2.0\", not %q", swagger.Swagger)
+	}
+	if swagger.Info == nil {
+		validator.report("#/info", "info is required")
+	} else {
+		if swagger.Info.Title == "" {
+			validator.report("#/info/title", "title is required")
+		}
+		if swagger.Info.Version == "" {
+			validator.report("#/info/version", "version is required")
+		}
+	}
+	paths := make([]string, 0, len(swagger.Paths))
+	for path := range swagger.Paths {
+		paths = append(paths, path)
+	}
+	for _, path := range sortedKeys(paths) {
+		if pathItem := swagger.Paths[path]; pathItem != nil {
+			validator.validatePathItem(pathItem, "#/paths/"+escape(path))
+		}
+	}
+	names := make([]string, 0, len(swagger.Definitions))
+	for name := range swagger.Definitions {
+		names = append(names, name)
+	}
+	for _, name := range sortedKeys(names) {
+		if schema := swagger.Definitions[name]; schema != nil {
+			validator.validateSchema(schema, "#/definitions/"+escape(name))
+		}
+	}
+	names = make([]string, 0, len(swagger.Parameters))
+	for name := range swagger.Parameters {
+		names = append(names, name)
+	}
+	for _, name := range sortedKeys(names) {
+		if parameter := swagger.Parameters[name]; parameter != nil {
+			validator.validateParameter(parameter, "#/parameters/"+escape(name))
+		}
+	}
+	names = make([]string, 0, len(swagger.Responses))
+	for name := range swagger.Responses {
+		names = append(names, name)
+	}
+	for _, name := range sortedKeys(names) {
+		if response := swagger.Responses[name]; response != nil {
+			validator.validateResponse(response, "#/responses/"+escape(name))
+		}
+	}
+	names = make([]string, 0, len(swagger.SecurityDefinitions))
+	for name := range swagger.SecurityDefinitions {
+		names = append(names, name)
+	}
+	for _, name := range sortedKeys(names) {
+		if securityScheme := swagger.SecurityDefinitions[name]; securityScheme != nil {
+			validator.validateSecurityScheme(securityScheme, "#/securityDefinitions/"+escape(name))
+		}
+	}
+}
+
+// resolveParameter returns the Parameter parameter refers to in the parameters of the document.
+func (validator *validator) resolveParameter(parameter *Parameter) *Parameter {
+	for depth := 0; parameter != nil && parameter.Ref != "" && depth < 64; depth++ {
+		const prefix = "#/parameters/"
+		if !strings.HasPrefix(parameter.Ref, prefix) {
+			return nil
+		}
+		parameter = validator.swagger.Parameters[unescape(parameter.Ref[len(prefix):])]
+	}
+	return parameter
+}
+
+// located is a resolved parameter along with its location in the document.
+type located struct {
+	parameter *Parameter
+	location  string
+}
+
+func (validator *validator) validatePathItem(pathItem *PathItem, location string) {
+	shared := validator.validateParameters(pathItem.Parameters, location+"/parameters")
+	for _, method := range []struct {
+		name      string
+		operation *Operation
+	}{
+		{"get", pathItem.Get}, {"put", pathItem.Put}, {"post", pathItem.Post}, {"delete", pathItem.Delete},
+		{"options", pathItem.Options}, {"head", pathItem.Head}, {"patch", pathItem.Patch},
+	} {
+		if method.operation == nil {
+			continue
+		}
+		operationLocation := location + "/" + method.name
+		parameters := validator.validateParameters(method.operation.Parameters, operationLocation+"/parameters")
+		for _, parameter := range shared {
+			if !containsParameter(parameters, parameter.parameter) {
+				parameters = append(parameters, parameter)
+			}
+		}
+		validator.validateOperation(method.operation, parameters, operationLocation)
+	}
+}
+
+func containsParameter(parameters []located, parameter *Parameter) bool {
+	for _, candidate := range parameters {
+		if candidate.parameter.Name == parameter.Name && candidate.parameter.In == parameter.In {
+			return true
+		}
+	}
+	return false
+}
+
+// validateParameters validates a list of parameters and returns the resolved ones.
+func (validator *validator) validateParameters(parameters []*Parameter, location string) []located {
+	var resolved []located
+	for i, parameter := range parameters {
+		if parameter == nil {
+			continue
+		}
+		parameterLocation := fmt.Sprintf("%s/%d", location, i)
+		if parameter.Ref != "" {
+			if parameter = validator.resolveParameter(parameter); parameter == nil {
+				validator.report(parameterLocation+"/$ref", "$ref %q cannot be resolved", parameters[i].Ref)
+				continue
+			}
+		} else {
+			validator.validateParameter(parameter, parameterLocation)
+		}
+		if containsParameter(resolved, parameter) {
+			validator.report(parameterLocation, "parameter %q in %s is already defined", parameter.Name, parameter.In)
+			continue
+		}
+		resolved = append(resolved, located{parameter, parameterLocation})
+	}
+	return resolved
+}
+
+// validateOperation checks the parameters of an operation, path level parameters included, against each other
+// and against its consumes.
+func (validator *validator) validateOperation(operation *Operation, parameters []located, location string) {
+	consumes := operation.Consumes
+	if consumes == nil {
+		consumes = validator.swagger.Consumes
+	}
+	var body, formData []located
+	for _, parameter := range parameters {
+		switch parameter.parameter.In {
+		case "body":
+			body = append(body, parameter)
+		case "formData":
+			formData = append(formData, parameter)
+		}
+	}
+	if len(body) > 1 {
+		validator.report(body[1].location, "an operation has at most one body parameter")
+	}
+	if len(body) > 0 && len(formData) > 0 {
+		validator.report(location+"/parameters", "body and formData parameters are mutually exclusive")
+	}
+	for _, parameter := range formData {
+		if parameter.parameter.Type == "file" && !containsString(consumes, "multipart/form-data") {
+			validator.report(parameter.location, "file parameter %q requires the operation to consume multipart/form-data", parameter.parameter.Name)
+		}
+	}
+	codes := make([]string, 0, len(operation.Responses))
+	for code := range operation.Responses {
+		codes = append(codes, code)
+	}
+	if len(codes) == 0 {
+		validator.report(location+"/responses", "at least one response is required")
+	}
+	for _, code := range sortedKeys(codes) {
+		if response := operation.Responses[code]; response != nil {
+			validator.validateResponse(response, location+"/responses/"+escape(code))
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (validator *validator) validateParameter(parameter *Parameter, location string) {
+	if parameter.Ref != "" {
+		if validator.resolveParameter(parameter) == nil {
+			validator.report(location+"/$ref", "$ref %q cannot be resolved", parameter.Ref)
+		}
+		return
+	}
+	if parameter.Name == "" {
+		validator.report(location+"/name", "name is required")
+	}
+	if !parameterLocations[parameter.In] {
+		validator.report(location+"/in", "in must be one of query, header, path, formData or body, not %q", parameter.In)
+	}
+	if parameter.In == "path" && !parameter.Required {
+		validator.report(location+"/required", "path parameter %q must be required", parameter.Name)
+	}
+	if parameter.In == "body" {
+		if parameter.Schema == nil {
+			validator.report(location+"/schema", "schema is required for a body parameter")
+		} else {
+			validator.validateSchema(parameter.Schema, location+"/schema")
+		}
+		return
+	}
+	if parameter.Type == "" {
+		validator.report(location+"/type", "type is required")
+	}
+	if parameter.Type == "file" && parameter.In != "formData" {
+		validator.report(location+"/type", "type file is only allowed on formData parameters")
+	}
+	if parameter.Type == "array" && parameter.Items == nil {
+		validator.report(location+"/items", "items is required when type is array")
+	}
+	validator.validateCollectionFormat(parameter.CollectionFormat, parameter.In, location)
+	validator.validateValues(parameter.Type, parameter.Default, parameter.Enum, location)
+	if parameter.Items != nil {
+		validator.validateItems(parameter.Items, parameter.In, location+"/items")
+	}
+}
+
+func (validator *validator) validateItems(items *Items, in, location string) {
+	if items.Type == "array" && items.Items == nil {
+		validator.report(location+"/items", "items is required when type is array")
+	}
+	// multi only applies to the parameter itself, the values of nested arrays are a single string.
+	if items.CollectionFormat == "multi" {
+		validator.report(location+"/collectionFormat", "collectionFormat multi is only allowed on query and formData parameters")
+	} else {
+		validator.validateCollectionFormat(items.CollectionFormat, in, location)
+	}
+	validator.validateValues(items.Type, items.Default, items.Enum, location)
+	if items.Items != nil {
+		validator.validateItems(items.Items, in, location+"/items")
+	}
+}
+
+func (validator *validator) validateCollectionFormat(collectionFormat, in, location string) {
+	switch {
+	case collectionFormat == "":
+	case !collectionFormats[collectionFormat]:
+		validator.report(location+"/collectionFormat", "collectionFormat must be one of csv, ssv, tsv, pipes or multi, not %q", collectionFormat)
+	case collectionFormat == "multi" && in != "query" && in != "formData":
+		validator.report(location+"/collectionFormat", "collectionFormat multi is only allowed on query and formData parameters")
+	}
+}
+
+func (validator *validator) validateResponse(response *Response, location string) {
+	if response.Ref != "" {
+		const prefix = "#/responses/"
+		if !strings.HasPrefix(response.Ref, prefix) || validator.swagger.Responses[unescape(response.Ref[len(prefix):])] == nil {
+			validator.report(location+"/$ref", "$ref %q cannot be resolved", response.Ref)
+		}
+		return
+	}
+	if response.Description == "" {
+		validator.report(location+"/description", "description is required")
+	}
+	if response.Schema != nil {
+		validator.validateSchema(response.Schema, location+"/schema")
+	}
+	mediaTypes := make([]string, 0, len(response.Example))
+	for mediaType := range response.Example {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	for _, mediaType := range sortedKeys(mediaTypes) {
+		validator.validateValue("", response.Example[mediaType], location+"/example/"+escape(mediaType))
+	}
+	names := make([]string, 0, len(response.Headers))
+	for name := range response.Headers {
+		names = append(names, name)
+	}
+	for _, name := range sortedKeys(names) {
+		header, headerLocation := response.Headers[name], location+"/headers/"+escape(name)
+		if header == nil {
+			continue
+		}
+		if header.Type == "" {
+			validator.report(headerLocation+"/type", "type is required")
+		}
+		if header.Type == "array" && header.Items == nil {
+			validator.report(headerLocation+"/items", "items is required when type is array")
+		}
+		if header.CollectionFormat == "multi" {
+			validator.report(headerLocation+"/collectionFormat", "collectionFormat multi is only allowed on query and formData parameters")
+		} else {
+			validator.validateCollectionFormat(header.CollectionFormat, "header", headerLocation)
+		}
+		validator.validateValues(header.Type, header.Default, header.Enum, headerLocation)
+		if header.Items != nil {
+			validator.validateItems(header.Items, "header", headerLocation+"/items")
+		}
+	}
+}
+
+// validateSchema checks the default, enum and example values of a schema and of the schemas it holds.
+func (validator *validator) validateSchema(schema *Schema, location string) {
+	if schema.Ref != "" || validator.schemas[schema] {
+		return
+	}
+	validator.schemas[schema] = true
+	validator.validateValues(schema.Type, schema.Default, schema.Enum, location)
+	validator.validateValue(schema.Type, schema.Example, location+"/example")
+	if schema.Items != nil {
+		validator.validateSchema(schema.Items, location+"/items")
+	}
+	for i, allOf := range schema.AllOf {
+		if allOf != nil {
+			validator.validateSchema(allOf, fmt.Sprintf("%s/allOf/%d", location, i))
+		}
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	for _, name := range sortedKeys(names) {
+		if property := schema.Properties[name]; property != nil {
+			validator.validateSchema(property, location+"/properties/"+escape(name))
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		validator.validateSchema(schema.AdditionalProperties.Schema, location+"/additionalProperties")
+	}
+}
+
+// validateValues checks the default and enum values of an object of schemaType.
+func (validator *validator) validateValues(schemaType string, defaultValue json.RawMessage, enum []json.RawMessage, location string) {
+	validator.validateValue(schemaType, defaultValue, location+"/default")
+	for i, value := range enum {
+		validator.validateValue(schemaType, value, fmt.Sprintf("%s/enum/%d", location, i))
+	}
+}
+
+// validateValue checks that a value is JSON of schemaType, when it is set. null is accepted, for the x-nullable
+// extension.
+func (validator *validator) validateValue(schemaType string, value json.RawMessage, location string) {
+	if len(value) == 0 {
+		return
+	}
+	if !json.Valid(value) {
+		validator.report(location, "value is not valid JSON")
+		return
+	}
+	value = bytes.TrimSpace(value)
+	var valueType string
+	switch value[0] {
+	case 'n':
+		return
+	case '"':
+		valueType = "string"
+	case 't', 'f':
+		valueType = "boolean"
+	case '[':
+		valueType = "array"
+	case '{':
+		valueType = "object"
+	default:
+		valueType = "number"
+		if number, ok := new(big.Rat).SetString(string(value)); ok && number.IsInt() {
+			valueType = "integer"
+		}
+	}
+	switch {
+	case schemaType == "" || schemaType == "file" || schemaType == valueType:
+	case schemaType == "number" && valueType == "integer":
+	default:
+		validator.report(location, "value must be of type %s, not %s", schemaType, valueType)
+	}
+}
+
+// validateSecurityScheme checks that the fields of a security scheme match its type and OAuth2 flow.
+// https://swagger.io/specification/v2/#security-scheme-object
+func (validator *validator) validateSecurityScheme(securityScheme *SecurityScheme, location string) {
+	switch securityScheme.Type {
+	case "basic":
+	case "apiKey":
+		if securityScheme.Name == "" {
+			validator.report(location+"/name", "name is required")
+		}
+		if securityScheme.In != "query" && securityScheme.In != "header" {
+			validator.report(location+"/in", "in must be query or header, not %q", securityScheme.In)
+		}
+	case "oauth2":
+		var authorizationURL, tokenURL bool
+		switch securityScheme.Flow {
+		case "implicit":
+			authorizationURL = true
+		case "password", "application":
+			tokenURL = true
+		case "accessCode":
+			authorizationURL, tokenURL = true, true
+		default:
+			validator.report(location+"/flow", "flow must be one of implicit, password, application or accessCode, not %q", securityScheme.Flow)
+			return
+		}
+		if authorizationURL && securityScheme.AuthorizationURL == "" {
+			validator.report(location+"/authorizationUrl", "authorizationUrl is required by the %s flow", securityScheme.Flow)
+		} else if !authorizationURL && securityScheme.AuthorizationURL != "" {
+			validator.report(location+"/authorizationUrl", "authorizationUrl does not apply to the %s flow", securityScheme.Flow)
+		}
+		if tokenURL && securityScheme.TokenURL == "" {
+			validator.report(location+"/tokenUrl", "tokenUrl is required by the %s flow", securityScheme.Flow)
+		} else if !tokenURL && securityScheme.TokenURL != "" {
+			validator.report(location+"/tokenUrl", "tokenUrl does not apply to the %s flow", securityScheme.Flow)
+		}
+		if securityScheme.Scopes == nil {
+			validator.report(location+"/scopes", "scopes is required")
+		}
+	default:
+		validator.report(location+"/type", "type must be one of basic, apiKey or oauth2, not %q", securityScheme.Type)
+	}
+}
@@ -0,0 +1,196 @@
+package v303
+
+import (
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// invalidComponentName matches the characters not allowed in a Components key.
+// http://spec.openapis.org/oas/v3.0.3#fixed-fields-5
+var invalidComponentName = regexp.MustCompile(`[^a-zA-Z0-9.\-_]+`)
+
+// Bundle turns a document loaded by the Loader into a single self-contained document. Every target living in
+// another file is added to Components, under a name derived from its location and made unique, and each $ref
+// is rewritten to the local #/components/... pointer, as are the $ref values of the mapping of discriminators. Path
+// items are inlined since Components cannot hold them.
+func (loader *Loader) Bundle(openAPI *OpenAPI) error {
+	bundler := newBundler(loader, openAPI)
+	visited := map[interface{}]bool{}
+	walker := &refWalker{mappings: loader.mappings, visit: func(holder refHolder, descend func() error) error {
+		value := holder.target()
+		if value == nil {
+			return nil
+		}
+		if ref := holder.reference(); *ref != "" {
+			if holder.section() == "" {
+				*ref = ""
+			} else {
+				*ref = bundler.local(holder)
+			}
+		}
+		if visited[value] {
+			return nil
+		}
+		visited[value] = true
+		return descend()
+	}}
+	return walker.walkOpenAPI(openAPI)
+}
+
+// Dereference inlines the target of every $ref of a document loaded by the Loader. Targets which contain
+// themselves cannot be inlined: every $ref to them points to a local component instead, bundled from its file
+// when needed. So do the $ref values of the mapping of discriminators, which cannot hold a schema.
+func (loader *Loader) Dereference(openAPI *OpenAPI) error {
+	recursive, err := recursiveValues(openAPI)
+	if err != nil {
+		return err
+	}
+	bundler := newBundler(loader, openAPI)
+	visited := map[interface{}]bool{}
+	walker := &refWalker{mappings: loader.mappings, visit: func(holder refHolder, descend func() error) error {
+		value := holder.target()
+		if value == nil {
+			return nil
+		}
+		_, mapping := holder.(*mappingRef)
+		if (recursive[value] || mapping) && !bundler.entries[holder] {
+			*holder.reference() = bundler.local(holder)
+		} else {
+			*holder.reference() = ""
+		}
+		if visited[value] {
+			return nil
+		}
+		visited[value] = true
+		return descend()
+	}}
+	return walker.walkOpenAPI(openAPI)
+}
+
+// recursiveValues returns the values of the document reachable from themselves. A depth first walk meets at
+// least one of them on every cycle, so turning the refs to them into local refs leaves no cycle to inline.
+func recursiveValues(openAPI *OpenAPI) (map[interface{}]bool, error) {
+	const walking, walked = 1, 2
+	recursive := map[interface{}]bool{}
+	state := map[interface{}]int{}
+	walker := &refWalker{visit: func(holder refHolder, descend func() error) error {
+		value := holder.target()
+		if value == nil {
+			return nil
+		}
+		switch state[value] {
+		case walking:
+			recursive[value] = true
+			return nil
+		case walked:
+			return nil
+		}
+		state[value] = walking
+		err := descend()
+		state[value] = walked
+		return err
+	}}
+	return recursive, walker.walkOpenAPI(openAPI)
+}
+
+// bundler assigns local component locations to the values of a document.
+type bundler struct {
+	loader    *Loader
+	openAPI   *OpenAPI
+	entries   map[refHolder]bool
+	locations map[interface{}]string
+	taken     map[string]map[string]bool
+}
+
+func newBundler(loader *Loader, openAPI *OpenAPI) *bundler {
+	bundler := &bundler{
+		loader:    loader,
+		openAPI:   openAPI,
+		entries:   map[refHolder]bool{},
+		locations: map[interface{}]string{},
+		taken:     map[string]map[string]bool{},
+	}
+	components := componentsOf(openAPI.Components)
+	for _, component := range components {
+		if component.holder == nil || reflect.ValueOf(component.holder).IsNil() {
+			continue
+		}
+		bundler.entries[component.holder] = true
+		bundler.take(component.holder.section(), component.name)
+	}
+	// Inline components come first, so the components which are only a $ref to them become aliases.
+	for _, inline := range []bool{true, false} {
+		for _, component := range components {
+			if !bundler.entries[component.holder] || (*component.holder.reference() == "") != inline {
+				continue
+			}
+			value := component.holder.target()
+			if value == nil {
+				continue
+			}
+			if _, ok := bundler.locations[value]; ok {
+				continue
+			}
+			// A component which is a $ref to another file is bundled by inlining its target in place.
+			*component.holder.reference() = ""
+			bundler.locations[value] = "#/components/" + component.holder.section() + "/" + escapePointerToken(component.name)
+		}
+	}
+	return bundler
+}
+
+func (bundler *bundler) take(section, name string) {
+	if bundler.taken[section] == nil {
+		bundler.taken[section] = map[string]bool{}
+	}
+	bundler.taken[section][name] = true
+}
+
+// local returns the local location of the target of holder, storing the target into Components when it
+// is not already one of them.
+func (bundler *bundler) local(holder refHolder) string {
+	value := holder.target()
+	if location, ok := bundler.locations[value]; ok {
+		return location
+	}
+	section := holder.section()
+	base := componentName(bundler.loader.origins[value])
+	name := base
+	for i := 2; bundler.taken[section][name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	bundler.take(section, name)
+	if bundler.openAPI.Components == nil {
+		bundler.openAPI.Components = &Components{}
+	}
+	bundler.entries[holder.addTo(bundler.openAPI.Components, name)] = true
+	location := "#/components/" + section + "/" + escapePointerToken(name)
+	bundler.locations[value] = location
+	return location
+}
+
+// componentName derives a component name from the location a value was loaded from: the last token of the
+// JSON pointer, or the file name without extension when the whole file is the value.
+func componentName(location string) string {
+	file, pointer := location, ""
+	if i := strings.IndexByte(location, '#'); i >= 0 {
+		file, pointer = location[:i], location[i+1:]
+	}
+	var name string
+	if tokens := strings.Split(pointer, "/"); pointer != "" {
+		name = unescapePointerToken(tokens[len(tokens)-1])
+		if _, err := strconv.Atoi(name); err == nil && len(tokens) > 1 {
+			name = unescapePointerToken(tokens[len(tokens)-2]) + "_" + name
+		}
+	} else {
+		name = strings.TrimSuffix(path.Base(file), path.Ext(file))
+	}
+	name = invalidComponentName.ReplaceAllString(name, "_")
+	if name == "" || name == "." || name == "_" {
+		name = "Component"
+	}
+	return name
+}
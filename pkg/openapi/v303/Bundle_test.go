@@ -0,0 +1,140 @@
+package v303
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// loadBundle loads the document of testdata/bundle, whose refs point to other files.
+func loadBundle(t *testing.T) (*Loader, *OpenAPI) {
+	t.Helper()
+	loader := NewLoader(Dir("testdata/bundle"))
+	openAPI, err := loader.LoadFile("main.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loader, openAPI
+}
+
+// encodeDocument returns the JSON encoding of a document decoded into generic values.
+func encodeDocument(t *testing.T, openAPI *OpenAPI) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(openAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatal(err)
+	}
+	return document
+}
+
+// member returns the member of a generic value at path.
+func member(value interface{}, path ...interface{}) interface{} {
+	for _, key := range path {
+		switch key := key.(type) {
+		case string:
+			object, _ := value.(map[string]interface{})
+			value = object[key]
+		case int:
+			array, _ := value.([]interface{})
+			if key >= len(array) {
+				return nil
+			}
+			value = array[key]
+		}
+	}
+	return value
+}
+
+func componentNames(document map[string]interface{}) []string {
+	var names []string
+	for name := range member(document, "components", "schemas").(map[string]interface{}) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestBundle(t *testing.T) {
+	loader, openAPI := loadBundle(t)
+	if err := loader.Bundle(openAPI); err != nil {
+		t.Fatal(err)
+	}
+	document := encodeDocument(t, openAPI)
+
+	// The targets sharing the name Pet are given distinct names.
+	if names, want := componentNames(document), []string{"Animal", "Bird", "Fish", "Node", "Pet", "Pet2", "Pet3"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("components = %v, want %v", names, want)
+	}
+	pet := member(document, "components", "schemas", "Pet")
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{member(pet, "oneOf", 0, "$ref"), "#/components/schemas/Pet2"},
+		{member(pet, "oneOf", 1, "$ref"), "#/components/schemas/Pet3"},
+		{member(pet, "oneOf", 2, "$ref"), "#/components/schemas/Bird"},
+		{member(document, "components", "schemas", "Pet2", "properties", "lives", "type"), "integer"},
+		{member(document, "components", "schemas", "Pet3", "properties", "bark", "type"), "boolean"},
+		// The mapping values which are a $ref point to the components of their targets.
+		{member(pet, "discriminator", "mapping", "cat"), "#/components/schemas/Pet2"},
+		{member(pet, "discriminator", "mapping", "dog"), "#/components/schemas/Pet3"},
+		{member(pet, "discriminator", "mapping", "bird"), "Bird"},
+		// A target only a mapping refers to is bundled as well.
+		{member(document, "components", "schemas", "Animal", "discriminator", "mapping", "fish"), "#/components/schemas/Fish"},
+		{member(document, "components", "schemas", "Fish", "allOf", 0, "$ref"), "#/components/schemas/Animal"},
+		// A path item of another file is inlined, with its refs made local.
+		{member(document, "paths", "/pets", "$ref"), ""},
+		{member(document, "paths", "/pets", "get", "responses", "200", "content", "application/json", "schema", "items", "$ref"),
+			"#/components/schemas/Pet"},
+		{member(document, "paths", "/trees", "get", "responses", "200", "content", "application/json", "schema", "$ref"),
+			"#/components/schemas/Node"},
+		{member(document, "components", "schemas", "Node", "properties", "children", "items", "$ref"), "#/components/schemas/Node"},
+	}
+	for i, test := range tests {
+		if got, _ := test.value.(string); got != test.want {
+			t.Errorf("%d: got %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestDereference(t *testing.T) {
+	loader, openAPI := loadBundle(t)
+	if err := loader.Dereference(openAPI); err != nil {
+		t.Fatal(err)
+	}
+	document := encodeDocument(t, openAPI)
+
+	items := member(document, "paths", "/pets", "get", "responses", "200", "content", "application/json", "schema", "items")
+	if ref := member(items, "$ref"); ref != nil {
+		t.Errorf("items = %v, want the Pet schema inlined", ref)
+	}
+	if lives := member(items, "oneOf", 0, "properties", "lives", "type"); lives != "integer" {
+		t.Errorf("oneOf[0] = %v, want the Pet of a/pet.yaml inlined", member(items, "oneOf", 0))
+	}
+	// The mapping values cannot be inlined and point to components.
+	if cat := member(items, "discriminator", "mapping", "cat"); cat != "#/components/schemas/Pet2" {
+		t.Errorf("mapping cat = %v, want #/components/schemas/Pet2", cat)
+	}
+	if lives := member(document, "components", "schemas", "Pet2", "properties", "lives", "type"); lives != "integer" {
+		t.Errorf("Pet2 = %v, want the Pet of a/pet.yaml", member(document, "components", "schemas", "Pet2"))
+	}
+	// A recursive schema stays a ref to a component.
+	tree := member(document, "paths", "/trees", "get", "responses", "200", "content", "application/json", "schema")
+	if ref := member(tree, "$ref"); ref != "#/components/schemas/Node" {
+		t.Errorf("tree = %v, want a ref to the recursive Node", tree)
+	}
+	if ref := member(document, "components", "schemas", "Node", "properties", "children", "items", "$ref"); ref != "#/components/schemas/Node" {
+		t.Errorf("Node children items = %v, want a ref to Node", ref)
+	}
+	if allOf := member(document, "components", "schemas", "Fish", "allOf", 0, "required", 0); allOf != "kind" {
+		t.Errorf("Fish allOf = %v, want Animal inlined", member(document, "components", "schemas", "Fish", "allOf"))
+	}
+	if openAPI.Paths["/pets"].Ref != "" {
+		t.Errorf("/pets = %q, want the path item inlined", openAPI.Paths["/pets"].Ref)
+	}
+}
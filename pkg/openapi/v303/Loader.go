@@ -0,0 +1,755 @@
+package v303
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+)
+
+// ErrCircularRef is returned when a chain of $ref leads back to itself without ever reaching a value,
+// such as a schema whose $ref points to a second schema that is only a $ref to the first one.
+// Recursive structures, like a schema whose property refers back to the schema, are not an error: every $ref
+// to the same location is resolved to the same value, so the resolved document may contain cycles.
+var ErrCircularRef = errors.New("circular $ref")
+
+// FileSystem is the source a Loader reads documents from. Names are slash separated paths, as with io/fs,
+// and an fs.FS can be adapted by wrapping fs.ReadFile.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+}
+
+// Dir is a FileSystem reading files below a directory of the local file system.
+type Dir string
+
+// ReadFile reads the named file relative to the directory.
+func (dir Dir) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(string(dir), filepath.FromSlash(name)))
+}
+
+// Loader parses OpenAPI documents and resolves their $ref. Local refs (#/components/schemas/Pet), refs to other
+// files relative to the referencing document (./common.json#/Error) and refs nested inside those files are followed.
+// The original Ref strings are kept and the target is stored in Value next to them.
+// Every load reads its files anew, so that a Loader sees the changes made to them between loads.
+// A Loader is not safe for concurrent use.
+type Loader struct {
+	// FS is where the documents are read from, it defaults to the current working directory.
+	FS FileSystem
+
+	files     map[string][]byte
+	values    map[string]interface{}
+	origins   map[interface{}]string
+	following map[string]bool
+	// mappings are the schemas the $ref values of the mapping of discriminators point to.
+	mappings map[*Discriminator]map[string]*Schema
+}
+
+// NewLoader creates a Loader reading documents from fs.
+func NewLoader(fs FileSystem) *Loader {
+	return &Loader{FS: fs}
+}
+
+// LoadFile parses the named JSON or YAML file from the Loader FileSystem and resolves its refs.
+func (loader *Loader) LoadFile(name string) (*OpenAPI, error) {
+	name = path.Clean(name)
+	loader.reset()
+	data, err := loader.readFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return loader.load(data, name)
+}
+
+// LoadData parses JSON or YAML data and resolves its refs. Refs to other files are relative to the root of the Loader FileSystem.
+func (loader *Loader) LoadData(data []byte) (*OpenAPI, error) {
+	loader.reset()
+	return loader.load(data, "")
+}
+
+// ResolveRefs resolves the refs of a document which was not read by the Loader, such as one built in code.
+// location is the name of the document in the Loader FileSystem, relative refs are resolved from it.
+func (loader *Loader) ResolveRefs(openAPI *OpenAPI, location string) error {
+	data, err := json.Marshal(openAPI)
+	if err != nil {
+		return err
+	}
+	loader.reset()
+	loader.files[location] = data
+	return loader.resolveOpenAPI(openAPI, location)
+}
+
+func (loader *Loader) load(data []byte, location string) (*OpenAPI, error) {
+	if !codec.IsJSON(data) {
+		var err error
+		if data, err = codec.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+	}
+	openAPI := &OpenAPI{}
+	if err := json.Unmarshal(data, openAPI); err != nil {
+		return nil, fmt.Errorf("%s: %w", location, err)
+	}
+	loader.files[location] = data
+	if err := loader.resolveOpenAPI(openAPI, location); err != nil {
+		return nil, err
+	}
+	return openAPI, nil
+}
+
+// reset forgets the files and the values of the previous load.
+func (loader *Loader) reset() {
+	loader.files = map[string][]byte{}
+	loader.values = map[string]interface{}{}
+	loader.origins = map[interface{}]string{}
+	loader.following = map[string]bool{}
+	loader.mappings = map[*Discriminator]map[string]*Schema{}
+}
+
+func (loader *Loader) readFile(name string) ([]byte, error) {
+	if data, ok := loader.files[name]; ok {
+		return data, nil
+	}
+	fs := loader.FS
+	if fs == nil {
+		fs = Dir(".")
+	}
+	data, err := fs.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if !codec.IsJSON(data) {
+		if data, err = codec.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	loader.files[name] = data
+	return data, nil
+}
+
+// locate returns the file holding the target of ref, relative to the document base, and the absolute location
+// of the target made of that file and the JSON pointer inside it.
+func locate(base, ref string) (file, location string, err error) {
+	file, fragment := ref, ""
+	if i := strings.IndexByte(ref, '#'); i >= 0 {
+		file, fragment = ref[:i], ref[i+1:]
+	}
+	if strings.Contains(file, "://") {
+		return "", "", fmt.Errorf("$ref %q: remote references are not supported", ref)
+	}
+	if fragment, err = url.PathUnescape(fragment); err != nil {
+		return "", "", fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	if file == "" {
+		file = base
+	} else {
+		file = path.Join(path.Dir(base), file)
+	}
+	return file, file + "#" + fragment, nil
+}
+
+// follow resolves ref found in the document base. A location which was already resolved yields the same value,
+// otherwise the raw target is handed to decode along with the file it lives in, so nested refs resolve against it.
+func (loader *Loader) follow(base, ref string, decode func(raw []byte, file, location string) (interface{}, error)) (interface{}, error) {
+	file, location, err := locate(base, ref)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := loader.values[location]; ok {
+		return value, nil
+	}
+	if loader.following[location] {
+		return nil, fmt.Errorf("%w: %s", ErrCircularRef, location)
+	}
+	loader.following[location] = true
+	defer delete(loader.following, location)
+
+	data, err := loader.readFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	raw, err := lookupPointer(data, location[len(file)+1:])
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q: %w", ref, err)
+	}
+	value, err := decode(raw, file, location)
+	if err != nil {
+		return nil, err
+	}
+	loader.values[location] = value
+	return value, nil
+}
+
+// register records the value found at location, which is not itself a $ref.
+func (loader *Loader) register(location string, value interface{}) {
+	loader.values[location] = value
+	loader.origins[value] = location
+}
+
+// seed registers the inline components of the document at location, so local refs to them resolve to the
+// very same values instead of copies.
+func (loader *Loader) seed(components *Components, location string) {
+	if components == nil {
+		return
+	}
+	prefix := location + "#/components/"
+	for name, schemaRef := range components.Schema {
+		if schemaRef != nil && schemaRef.Ref == "" && schemaRef.Value != nil {
+			loader.register(prefix+"schemas/"+escapePointerToken(name), schemaRef.Value)
+		}
+	}
+	for name, responseRef := range components.Responses {
+		if responseRef != nil && responseRef.Ref == "" && responseRef.Value != nil {
+			loader.register(prefix+"responses/"+escapePointerToken(name), responseRef.Value)
+		}
+	}
+	for name, parameterRef := range components.Parameters {
+		if parameterRef != nil && parameterRef.Ref == "" && parameterRef.Value != nil {
+			loader.register(prefix+"parameters/"+escapePointerToken(name), parameterRef.Value)
+		}
+	}
+	for name, exampleRef := range components.Examples {
+		if exampleRef != nil && exampleRef.Ref == "" && exampleRef.Value != nil {
+			loader.register(prefix+"examples/"+escapePointerToken(name), exampleRef.Value)
+		}
+	}
+	for name, requestBodyRef := range components.RequestBodies {
+		if requestBodyRef != nil && requestBodyRef.Ref == "" && requestBodyRef.Value != nil {
+			loader.register(prefix+"requestBodies/"+escapePointerToken(name), requestBodyRef.Value)
+		}
+	}
+	for name, headerRef := range components.Headers {
+		if headerRef != nil && headerRef.Ref == "" && headerRef.Value != nil {
+			loader.register(prefix+"headers/"+escapePointerToken(name), headerRef.Value)
+		}
+	}
+	for name, securitySchemeRef := range components.SecuritySchemes {
+		if securitySchemeRef != nil && securitySchemeRef.Ref == "" && securitySchemeRef.Value != nil {
+			loader.register(prefix+"securitySchemes/"+escapePointerToken(name), securitySchemeRef.Value)
+		}
+	}
+	for name, linkRef := range components.Links {
+		if linkRef != nil && linkRef.Ref == "" && linkRef.Value != nil {
+			loader.register(prefix+"links/"+escapePointerToken(name), linkRef.Value)
+		}
+	}
+	for name, callbackRef := range components.Callbacks {
+		if callbackRef != nil && callbackRef.Ref == "" && callbackRef.Value != nil {
+			loader.register(prefix+"callbacks/"+escapePointerToken(name), callbackRef.Value)
+		}
+	}
+}
+
+func (loader *Loader) resolveOpenAPI(openAPI *OpenAPI, location string) error {
+	loader.seed(openAPI.Components, location)
+	for _, pathItem := range openAPI.Paths {
+		if err := loader.resolvePathItem(pathItem, location); err != nil {
+			return err
+		}
+	}
+	return loader.resolveComponents(openAPI.Components, location)
+}
+
+func (loader *Loader) resolveComponents(components *Components, base string) error {
+	if components == nil {
+		return nil
+	}
+	for _, schemaRef := range components.Schema {
+		if err := loader.resolveSchemaRef(schemaRef, base); err != nil {
+			return err
+		}
+	}
+	for _, responseRef := range components.Responses {
+		if err := loader.resolveResponseRef(responseRef, base); err != nil {
+			return err
+		}
+	}
+	for _, parameterRef := range components.Parameters {
+		if err := loader.resolveParameterRef(parameterRef, base); err != nil {
+			return err
+		}
+	}
+	for _, exampleRef := range components.Examples {
+		if err := loader.resolveExampleRef(exampleRef, base); err != nil {
+			return err
+		}
+	}
+	for _, requestBodyRef := range components.RequestBodies {
+		if err := loader.resolveRequestBodyRef(requestBodyRef, base); err != nil {
+			return err
+		}
+	}
+	for _, headerRef := range components.Headers {
+		if err := loader.resolveHeaderRef(headerRef, base); err != nil {
+			return err
+		}
+	}
+	for _, securitySchemeRef := range components.SecuritySchemes {
+		if err := loader.resolveSecuritySchemeRef(securitySchemeRef, base); err != nil {
+			return err
+		}
+	}
+	for _, linkRef := range components.Links {
+		if err := loader.resolveLinkRef(linkRef, base); err != nil {
+			return err
+		}
+	}
+	for _, callbackRef := range components.Callbacks {
+		if err := loader.resolveCallbackRef(callbackRef, base); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (loader *Loader) resolvePathItem(pathItem *PathItem, base string) error {
+	if pathItem == nil {
+		return nil
+	}
+	if pathItem.Ref != "" {
+		value, err := loader.follow(base, pathItem.Ref, func(raw []byte, file, location string) (interface{}, error) {
+			target := &PathItem{}
+			if err := json.Unmarshal(raw, target); err != nil {
+				return nil, fmt.Errorf("%s: %w", location, err)
+			}
+			if target.Ref == "" {
+				loader.register(location, target)
+			}
+			return target, loader.resolvePathItem(target, file)
+		})
+		if err != nil {
+			return err
+		}
+		target, ok := value.(*PathItem)
+		if !ok {
+			return fmt.Errorf("$ref %q does not point to a path item", pathItem.Ref)
+		}
+		ref := pathItem.Ref
+		*pathItem = *target
+		pathItem.Ref = ref
+		return nil
+	}
+	for _, operation := range pathItem.Operations() {
+		if err := loader.resolveOperation(operation, base); err != nil {
+			return err
+		}
+	}
+	for _, parameterRef := range pathItem.Parameters {
+		if err := loader.resolveParameterRef(parameterRef, base); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (loader *Loader) resolveOperation(operation *Operation, base string) error {
+	for _, parameterRef := range operation.Parameters {
+		if err := loader.resolveParameterRef(parameterRef, base); err != nil {
+			return err
+		}
+	}
+	if err := loader.resolveRequestBodyRef(operation.RequestBody, base); err != nil {
+		return err
+	}
+	for _, responseRef := range operation.Responses {
+		if err := loader.resolveResponseRef(responseRef, base); err != nil {
+			return err
+		}
+	}
+	for _, callbackRef := range operation.Callbacks {
+		if err := loader.resolveCallbackRef(callbackRef, base); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (loader *Loader) resolveSchemaRef(schemaRef *SchemaRef, base string) error {
+	if schemaRef == nil {
+		return nil
+	}
+	if schemaRef.Ref == "" {
+		return loader.resolveSchema(schemaRef.Value, base)
+	}
+	value, err := loader.follow(base, schemaRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &SchemaRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveSchemaRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Schema)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a schema", schemaRef.Ref)
+	}
+	schemaRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveSchema(schema *Schema, base string) error {
+	if schema == nil {
+		return nil
+	}
+	for _, schemaRefs := range [][]*SchemaRef{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, schemaRef := range schemaRefs {
+			if err := loader.resolveSchemaRef(schemaRef, base); err != nil {
+				return err
+			}
+		}
+	}
+	if err := loader.resolveSchemaRef(schema.Not, base); err != nil {
+		return err
+	}
+	if err := loader.resolveSchemaRef(schema.Items, base); err != nil {
+		return err
+	}
+	for _, schemaRef := range schema.Properties {
+		if err := loader.resolveSchemaRef(schemaRef, base); err != nil {
+			return err
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		if err := loader.resolveSchemaRef(schema.AdditionalProperties.Schema, base); err != nil {
+			return err
+		}
+	}
+	return loader.resolveMapping(schema.Discriminator, base)
+}
+
+// resolveMapping resolves the values of the mapping of a discriminator which are a $ref, holding a '#' or a '/',
+// the other values being the names of schema components.
+// http://spec.openapis.org/oas/v3.0.3#discriminator-object
+func (loader *Loader) resolveMapping(discriminator *Discriminator, base string) error {
+	if discriminator == nil || loader.mappings[discriminator] != nil {
+		return nil
+	}
+	targets := map[string]*Schema{}
+	loader.mappings[discriminator] = targets
+	for _, key := range sortedKeys(discriminator.Mapping) {
+		ref := discriminator.Mapping[key]
+		if !strings.ContainsAny(ref, "#/") {
+			continue
+		}
+		schemaRef := &SchemaRef{Ref: ref}
+		if err := loader.resolveSchemaRef(schemaRef, base); err != nil {
+			return fmt.Errorf("discriminator mapping %q: %w", key, err)
+		}
+		targets[key] = schemaRef.Value
+	}
+	return nil
+}
+
+func (loader *Loader) resolveParameterRef(parameterRef *ParameterRef, base string) error {
+	if parameterRef == nil {
+		return nil
+	}
+	if parameterRef.Ref == "" {
+		return loader.resolveParameter(parameterRef.Value, base)
+	}
+	value, err := loader.follow(base, parameterRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &ParameterRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveParameterRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Parameter)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a parameter", parameterRef.Ref)
+	}
+	parameterRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveParameter(parameter *Parameter, base string) error {
+	if parameter == nil {
+		return nil
+	}
+	if err := loader.resolveSchemaRef(parameter.Schema, base); err != nil {
+		return err
+	}
+	for _, exampleRef := range parameter.Examples {
+		if err := loader.resolveExampleRef(exampleRef, base); err != nil {
+			return err
+		}
+	}
+	return loader.resolveContent(parameter.Content, base)
+}
+
+func (loader *Loader) resolveContent(content map[string]*MediaType, base string) error {
+	for _, mediaType := range content {
+		if mediaType == nil {
+			continue
+		}
+		if err := loader.resolveSchemaRef(mediaType.Schema, base); err != nil {
+			return err
+		}
+		for _, exampleRef := range mediaType.Examples {
+			if err := loader.resolveExampleRef(exampleRef, base); err != nil {
+				return err
+			}
+		}
+		for _, encoding := range mediaType.Encoding {
+			if encoding == nil {
+				continue
+			}
+			for _, headerRef := range encoding.Headers {
+				if err := loader.resolveHeaderRef(headerRef, base); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (loader *Loader) resolveRequestBodyRef(requestBodyRef *RequestBodyRef, base string) error {
+	if requestBodyRef == nil {
+		return nil
+	}
+	if requestBodyRef.Ref == "" {
+		if requestBodyRef.Value == nil {
+			return nil
+		}
+		return loader.resolveContent(requestBodyRef.Value.Content, base)
+	}
+	value, err := loader.follow(base, requestBodyRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &RequestBodyRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveRequestBodyRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*RequestBody)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a request body", requestBodyRef.Ref)
+	}
+	requestBodyRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveResponseRef(responseRef *ResponseRef, base string) error {
+	if responseRef == nil {
+		return nil
+	}
+	if responseRef.Ref == "" {
+		return loader.resolveResponse(responseRef.Value, base)
+	}
+	value, err := loader.follow(base, responseRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &ResponseRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveResponseRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Response)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a response", responseRef.Ref)
+	}
+	responseRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveResponse(response *Response, base string) error {
+	if response == nil {
+		return nil
+	}
+	for _, headerRef := range response.Headers {
+		if err := loader.resolveHeaderRef(headerRef, base); err != nil {
+			return err
+		}
+	}
+	for _, linkRef := range response.Links {
+		if err := loader.resolveLinkRef(linkRef, base); err != nil {
+			return err
+		}
+	}
+	return loader.resolveContent(response.Content, base)
+}
+
+func (loader *Loader) resolveHeaderRef(headerRef *HeaderRef, base string) error {
+	if headerRef == nil {
+		return nil
+	}
+	if headerRef.Ref == "" {
+		return loader.resolveHeader(headerRef.Value, base)
+	}
+	value, err := loader.follow(base, headerRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &HeaderRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveHeaderRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Header)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a header", headerRef.Ref)
+	}
+	headerRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveHeader(header *Header, base string) error {
+	if header == nil {
+		return nil
+	}
+	if err := loader.resolveSchemaRef(header.Schema, base); err != nil {
+		return err
+	}
+	for _, exampleRef := range header.Examples {
+		if err := loader.resolveExampleRef(exampleRef, base); err != nil {
+			return err
+		}
+	}
+	return loader.resolveContent(header.Content, base)
+}
+
+func (loader *Loader) resolveExampleRef(exampleRef *ExampleRef, base string) error {
+	if exampleRef == nil || exampleRef.Ref == "" {
+		return nil
+	}
+	value, err := loader.follow(base, exampleRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &ExampleRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveExampleRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Example)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to an example", exampleRef.Ref)
+	}
+	exampleRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveLinkRef(linkRef *LinkRef, base string) error {
+	if linkRef == nil || linkRef.Ref == "" {
+		return nil
+	}
+	value, err := loader.follow(base, linkRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &LinkRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveLinkRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Link)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a link", linkRef.Ref)
+	}
+	linkRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveSecuritySchemeRef(securitySchemeRef *SecuritySchemeRef, base string) error {
+	if securitySchemeRef == nil || securitySchemeRef.Ref == "" {
+		return nil
+	}
+	value, err := loader.follow(base, securitySchemeRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &SecuritySchemeRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveSecuritySchemeRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*SecurityScheme)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a security scheme", securitySchemeRef.Ref)
+	}
+	securitySchemeRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveCallbackRef(callbackRef *CallbackRef, base string) error {
+	if callbackRef == nil {
+		return nil
+	}
+	if callbackRef.Ref == "" {
+		return loader.resolveCallback(callbackRef.Value, base)
+	}
+	value, err := loader.follow(base, callbackRef.Ref, func(raw []byte, file, location string) (interface{}, error) {
+		target := &CallbackRef{}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+		if target.Ref == "" {
+			loader.register(location, target.Value)
+		}
+		return target.Value, loader.resolveCallbackRef(target, file)
+	})
+	if err != nil {
+		return err
+	}
+	target, ok := value.(*Callback)
+	if !ok {
+		return fmt.Errorf("$ref %q does not point to a callback", callbackRef.Ref)
+	}
+	callbackRef.Value = target
+	return nil
+}
+
+func (loader *Loader) resolveCallback(callback *Callback, base string) error {
+	if callback == nil {
+		return nil
+	}
+	for _, pathItem := range callback.Paths {
+		if err := loader.resolvePathItem(pathItem, base); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package v303
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoaderCrossFileRefs(t *testing.T) {
+	openAPI, err := NewLoader(Dir("testdata/loader")).LoadFile("main.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listPets := openAPI.Paths["/pets"].Get
+
+	limit := listPets.Parameters[0]
+	if limit.Ref != "./components/parameters.json#/limit" || limit.Value == nil || limit.Value.Name != "limit" {
+		t.Fatalf("parameter = %+v, want the limit of parameters.json", limit)
+	}
+	// A ref inside another file is relative to that file.
+	if schema := limit.Value.Schema; schema.Ref != "schemas.yaml#/Limit" || schema.Value == nil || schema.Value.Type != "integer" {
+		t.Errorf("limit schema = %+v, want the Limit of schemas.yaml", schema)
+	}
+
+	pet := listPets.Responses["200"].Value.Content["application/json"].Schema.Value.Items
+	if pet.Ref != "./components/schemas.yaml#/Pet" || pet.Value == nil || pet.Value.Required[0] != "name" {
+		t.Fatalf("items = %+v, want the Pet of schemas.yaml", pet)
+	}
+	// The local refs of another file point into that file, and recursive schemas resolve to the same values.
+	owner := pet.Value.Properties["owner"]
+	if owner.Ref != "#/Owner" || owner.Value == nil {
+		t.Fatalf("owner = %+v, want the Owner of schemas.yaml", owner)
+	}
+	if owner.Value.Properties["pets"].Value.Items.Value != pet.Value {
+		t.Error("Owner.pets.items is not the Pet its ref points to")
+	}
+
+	// Escaped tokens and percent-encoded characters are decoded.
+	owners := openAPI.Paths["/owners"].Get.Responses["200"].Value.Content["application/json"].Schema
+	if owners.Value == nil || owners.Value.Items.Value != owner.Value {
+		t.Errorf("owners = %+v, want the array of Owner under Cat/Dog Owners", owners)
+	}
+	errorResponse := listPets.Responses["default"]
+	if errorResponse.Ref != "#/components/responses/Error" || errorResponse.Value != openAPI.Components.Responses["Error"].Value {
+		t.Fatalf("default response = %+v, want the Error component", errorResponse)
+	}
+	if message := errorResponse.Value.Content["application/json"].Schema.Value.Properties["message"]; message == nil {
+		t.Error("the schema of the Error response is not the Tilde~Error of schemas.yaml")
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"cycle.yaml", `circular $ref: cycle-b.yaml#/B`},
+		{"missing-file.yaml", `$ref "./components/pets.yaml#/Pet": open testdata/loader/components/pets.yaml`},
+		{"missing-pointer.yaml", `$ref "./components/schemas.yaml#/Cat": JSON pointer "/Cat": member "Cat" not found`},
+		{"missing.yaml", `open testdata/loader/missing.yaml`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewLoader(Dir("testdata/loader")).LoadFile(test.name)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("LoadFile() = %v, want %s", err, test.want)
+			}
+			if circular := errors.Is(err, ErrCircularRef); circular != (test.name == "cycle.yaml") {
+				t.Errorf("errors.Is(%v, ErrCircularRef) = %t", err, circular)
+			}
+		})
+	}
+}
+
+// mapFS is a FileSystem of files held in memory.
+type mapFS map[string]string
+
+func (fs mapFS) ReadFile(name string) ([]byte, error) {
+	data, ok := fs[name]
+	if !ok {
+		return nil, errors.New(name + ": file does not exist")
+	}
+	return []byte(data), nil
+}
+
+func TestLoaderReadsFilesAnew(t *testing.T) {
+	fs := mapFS{
+		"openapi.yaml": `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1.0.0"}, "paths": {},
+			"components": {"schemas": {"Pet": {"$ref": "pet.json"}}}}`,
+		"pet.json": `{"type": "object"}`,
+	}
+	loader := NewLoader(fs)
+	for _, want := range []string{"object", "string"} {
+		openAPI, err := loader.LoadFile("openapi.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := openAPI.Components.Schema["Pet"].Value.Type; got != want {
+			t.Errorf("Pet type = %q, want %q", got, want)
+		}
+		fs["pet.json"] = `{"type": "string"}`
+	}
+	fs["openapi.yaml"] = `{"openapi": "3.0.3", "info": {"title": "Owners", "version": "1.0.0"}, "paths": {}}`
+	if openAPI, err := loader.LoadFile("openapi.yaml"); err != nil || openAPI.Info.Title != "Owners" {
+		t.Errorf("LoadFile() = %v, want the changed document", err)
+	}
+}
@@ -0,0 +1,497 @@
+package v303
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+)
+
+// marshalRef returns the JSON encoding of a Reference Object, which cannot be extended with additional properties.
+// http://spec.openapis.org/oas/v3.0.3#reference-object
+func marshalRef(ref string) ([]byte, error) {
+	return json.Marshal(Reference{Ref: ref})
+}
+
+// unmarshalRef looks for a "$ref" member in data. It returns true when data is a Reference Object,
+// in which case the remaining members SHALL be ignored by the caller.
+func unmarshalRef(data []byte) (string, bool, error) {
+	var probe struct {
+		Ref *string `json:"$ref"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return "", false, err
+	}
+	if probe.Ref == nil {
+		return "", false, nil
+	}
+	return *probe.Ref, true, nil
+}
+
+// unmarshalArray decodes raw into the slice pointed by dst. A lone object is decoded as a one-element array,
+// which is how servers, tags and security were serialized before they were modelled as arrays.
+func unmarshalArray(raw json.RawMessage, dst interface{}) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if raw[0] == '{' {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+	return json.Unmarshal(raw, dst)
+}
+
+// MarshalJSON returns the JSON encoding of the OpenAPI, always emitting the required paths object.
+// Paths keep the order they were decoded in and are followed by their extensions.
+func (openAPI OpenAPI) MarshalJSON() ([]byte, error) {
+	type alias OpenAPI
+	if openAPI.Paths == nil {
+		openAPI.Paths = map[string]*PathItem{}
+	}
+	data, err := json.Marshal(alias(openAPI))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = codec.Reorder(data, "paths", openAPI.pathsOrder); err != nil {
+		return nil, err
+	}
+	if data, err = codec.AppendMemberExtensions(data, "paths", openAPI.PathsExtensions); err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, openAPI.Extensions)
+}
+
+// UnmarshalJSON decodes an OpenAPI and its extensions, also accepting the legacy single object form of servers,
+// tags and security.
+func (openAPI *OpenAPI) UnmarshalJSON(data []byte) error {
+	type alias OpenAPI
+	aux := struct {
+		*alias
+		Paths    json.RawMessage `json:"paths"`
+		Servers  json.RawMessage `json:"servers"`
+		Tags     json.RawMessage `json:"tags"`
+		Security json.RawMessage `json:"security"`
+	}{alias: (*alias)(openAPI)}
+	if err := unmarshalExtensible(data, &aux, &openAPI.Extensions); err != nil {
+		return err
+	}
+	if err := unmarshalExtensibleMap(aux.Paths, &openAPI.Paths, &openAPI.PathsExtensions); err != nil {
+		return err
+	}
+	if err := unmarshalArray(aux.Servers, &openAPI.Servers); err != nil {
+		return err
+	}
+	if err := unmarshalArray(aux.Tags, &openAPI.Tags); err != nil {
+		return err
+	}
+	if err := unmarshalArray(aux.Security, &openAPI.Security); err != nil {
+		return err
+	}
+	openAPI.pathsOrder = codec.MemberKeys(data, "paths")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the PathItem, or only its "$ref" when Ref is set since fields next
+// to the reference have an undefined behavior and are filled from the referenced path item once loaded.
+func (pathItem PathItem) MarshalJSON() ([]byte, error) {
+	if pathItem.Ref != "" {
+		return marshalRef(pathItem.Ref)
+	}
+	type alias PathItem
+	return marshalExtensible(alias(pathItem), pathItem.Extensions)
+}
+
+// UnmarshalJSON decodes a PathItem and its extensions, also accepting servers under the legacy "server" key.
+func (pathItem *PathItem) UnmarshalJSON(data []byte) error {
+	type alias PathItem
+	aux := struct {
+		*alias
+		LegacyServers []*Server `json:"server"`
+	}{alias: (*alias)(pathItem)}
+	if err := unmarshalExtensible(data, &aux, &pathItem.Extensions); err != nil {
+		return err
+	}
+	if pathItem.Servers == nil {
+		pathItem.Servers = aux.LegacyServers
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the Operation, always emitting the required responses object.
+// Responses keep the order they were decoded in and are followed by their extensions.
+func (operation Operation) MarshalJSON() ([]byte, error) {
+	type alias Operation
+	if operation.Responses == nil {
+		operation.Responses = map[string]*ResponseRef{}
+	}
+	data, err := json.Marshal(alias(operation))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = codec.Reorder(data, "responses", operation.responsesOrder); err != nil {
+		return nil, err
+	}
+	if data, err = codec.AppendMemberExtensions(data, "responses", operation.ResponsesExtensions); err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, operation.Extensions)
+}
+
+// UnmarshalJSON decodes an Operation and its extensions, also accepting the legacy single object form of security.
+// An absent security leaves Security nil while an empty array removes the top-level security.
+func (operation *Operation) UnmarshalJSON(data []byte) error {
+	type alias Operation
+	aux := struct {
+		*alias
+		Responses json.RawMessage `json:"responses"`
+		Security  json.RawMessage `json:"security"`
+	}{alias: (*alias)(operation)}
+	if err := unmarshalExtensible(data, &aux, &operation.Extensions); err != nil {
+		return err
+	}
+	if err := unmarshalExtensibleMap(aux.Responses, &operation.Responses, &operation.ResponsesExtensions); err != nil {
+		return err
+	}
+	if err := unmarshalArray(aux.Security, &operation.Security); err != nil {
+		return err
+	}
+	operation.responsesOrder = codec.MemberKeys(data, "responses")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the path items of the Callback followed by its extensions.
+func (callback Callback) MarshalJSON() ([]byte, error) {
+	paths := callback.Paths
+	if paths == nil {
+		paths = map[string]*PathItem{}
+	}
+	data, err := json.Marshal(paths)
+	if err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, callback.Extensions)
+}
+
+// UnmarshalJSON decodes the path items of a Callback and its extensions.
+func (callback *Callback) UnmarshalJSON(data []byte) error {
+	*callback = Callback{}
+	return unmarshalExtensibleMap(data, &callback.Paths, &callback.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the RequestBody and its extensions, always emitting the required content
+// map.
+func (requestBody RequestBody) MarshalJSON() ([]byte, error) {
+	type alias RequestBody
+	if requestBody.Content == nil {
+		requestBody.Content = map[string]*MediaType{}
+	}
+	return marshalExtensible(alias(requestBody), requestBody.Extensions)
+}
+
+// UnmarshalJSON decodes a RequestBody and its extensions.
+func (requestBody *RequestBody) UnmarshalJSON(data []byte) error {
+	type alias RequestBody
+	return unmarshalExtensible(data, (*alias)(requestBody), &requestBody.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Schema and its extensions, properties keep the order they were
+// decoded in.
+func (schema Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+	data, err := json.Marshal(alias(schema))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = codec.Reorder(data, "properties", schema.propertiesOrder); err != nil {
+		return nil, err
+	}
+	return codec.AppendExtensions(data, schema.Extensions)
+}
+
+// UnmarshalJSON decodes a Schema and its extensions, recording the order of its properties. items is also accepted
+// as a one-element array, which is how it was serialized before it was modelled as a single schema.
+func (schema *Schema) UnmarshalJSON(data []byte) error {
+	type alias Schema
+	aux := struct {
+		*alias
+		Items json.RawMessage `json:"items"`
+	}{alias: (*alias)(schema)}
+	if err := unmarshalExtensible(data, &aux, &schema.Extensions); err != nil {
+		return err
+	}
+	if err := unmarshalItems(aux.Items, &schema.Items); err != nil {
+		return err
+	}
+	schema.propertiesOrder = codec.MemberKeys(data, "properties")
+	return nil
+}
+
+// unmarshalItems decodes raw into the schema pointed by dst, unwrapping a one-element array.
+func unmarshalItems(raw json.RawMessage, dst interface{}) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+	if raw[0] == '[' {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return err
+		}
+		if len(elements) != 1 {
+			return fmt.Errorf("items must be a single schema, not an array of %d", len(elements))
+		}
+		raw = elements[0]
+	}
+	return json.Unmarshal(raw, dst)
+}
+
+// MarshalJSON returns the JSON encoding of additionalProperties: its schema when Schema is set, otherwise the
+// boolean Allowed.
+func (additionalProperties AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if additionalProperties.Schema != nil {
+		return json.Marshal(additionalProperties.Schema)
+	}
+	return json.Marshal(additionalProperties.Allowed)
+}
+
+// UnmarshalJSON decodes a boolean into Allowed, or a schema into Schema with Allowed set.
+func (additionalProperties *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		*additionalProperties = AdditionalProperties{Allowed: trimmed[0] == 't'}
+		return nil
+	}
+	*additionalProperties = AdditionalProperties{Allowed: true, Schema: new(SchemaRef)}
+	return json.Unmarshal(data, additionalProperties.Schema)
+}
+
+// MarshalJSON returns the JSON encoding of the OAuthFlow and its extensions, always emitting the required scopes map.
+func (oauthFlow OAuthFlow) MarshalJSON() ([]byte, error) {
+	type alias OAuthFlow
+	if oauthFlow.Scopes == nil {
+		oauthFlow.Scopes = map[string]string{}
+	}
+	return marshalExtensible(alias(oauthFlow), oauthFlow.Extensions)
+}
+
+// UnmarshalJSON decodes an OAuthFlow and its extensions.
+func (oauthFlow *OAuthFlow) UnmarshalJSON(data []byte) error {
+	type alias OAuthFlow
+	return unmarshalExtensible(data, (*alias)(oauthFlow), &oauthFlow.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Info and its extensions.
+func (info Info) MarshalJSON() ([]byte, error) {
+	type alias Info
+	return marshalExtensible(alias(info), info.Extensions)
+}
+
+// UnmarshalJSON decodes an Info and its extensions.
+func (info *Info) UnmarshalJSON(data []byte) error {
+	type alias Info
+	return unmarshalExtensible(data, (*alias)(info), &info.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Tag and its extensions.
+func (tag Tag) MarshalJSON() ([]byte, error) {
+	type alias Tag
+	return marshalExtensible(alias(tag), tag.Extensions)
+}
+
+// UnmarshalJSON decodes a Tag and its extensions.
+func (tag *Tag) UnmarshalJSON(data []byte) error {
+	type alias Tag
+	return unmarshalExtensible(data, (*alias)(tag), &tag.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Contact and its extensions.
+func (contact Contact) MarshalJSON() ([]byte, error) {
+	type alias Contact
+	return marshalExtensible(alias(contact), contact.Extensions)
+}
+
+// UnmarshalJSON decodes a Contact and its extensions.
+func (contact *Contact) UnmarshalJSON(data []byte) error {
+	type alias Contact
+	return unmarshalExtensible(data, (*alias)(contact), &contact.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the License and its extensions.
+func (license License) MarshalJSON() ([]byte, error) {
+	type alias License
+	return marshalExtensible(alias(license), license.Extensions)
+}
+
+// UnmarshalJSON decodes a License and its extensions.
+func (license *License) UnmarshalJSON(data []byte) error {
+	type alias License
+	return unmarshalExtensible(data, (*alias)(license), &license.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Server and its extensions.
+func (server Server) MarshalJSON() ([]byte, error) {
+	type alias Server
+	return marshalExtensible(alias(server), server.Extensions)
+}
+
+// UnmarshalJSON decodes a Server and its extensions.
+func (server *Server) UnmarshalJSON(data []byte) error {
+	type alias Server
+	return unmarshalExtensible(data, (*alias)(server), &server.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the ServerVariable and its extensions.
+func (serverVariable ServerVariable) MarshalJSON() ([]byte, error) {
+	type alias ServerVariable
+	return marshalExtensible(alias(serverVariable), serverVariable.Extensions)
+}
+
+// UnmarshalJSON decodes a ServerVariable and its extensions.
+func (serverVariable *ServerVariable) UnmarshalJSON(data []byte) error {
+	type alias ServerVariable
+	return unmarshalExtensible(data, (*alias)(serverVariable), &serverVariable.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the ExternalDocumentation and its extensions.
+func (externalDocumentation ExternalDocumentation) MarshalJSON() ([]byte, error) {
+	type alias ExternalDocumentation
+	return marshalExtensible(alias(externalDocumentation), externalDocumentation.Extensions)
+}
+
+// UnmarshalJSON decodes an ExternalDocumentation and its extensions.
+func (externalDocumentation *ExternalDocumentation) UnmarshalJSON(data []byte) error {
+	type alias ExternalDocumentation
+	return unmarshalExtensible(data, (*alias)(externalDocumentation), &externalDocumentation.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the MediaType and its extensions.
+func (mediaType MediaType) MarshalJSON() ([]byte, error) {
+	type alias MediaType
+	return marshalExtensible(alias(mediaType), mediaType.Extensions)
+}
+
+// UnmarshalJSON decodes a MediaType and its extensions.
+func (mediaType *MediaType) UnmarshalJSON(data []byte) error {
+	type alias MediaType
+	return unmarshalExtensible(data, (*alias)(mediaType), &mediaType.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Encoding and its extensions.
+func (encoding Encoding) MarshalJSON() ([]byte, error) {
+	type alias Encoding
+	return marshalExtensible(alias(encoding), encoding.Extensions)
+}
+
+// UnmarshalJSON decodes an Encoding and its extensions.
+func (encoding *Encoding) UnmarshalJSON(data []byte) error {
+	type alias Encoding
+	return unmarshalExtensible(data, (*alias)(encoding), &encoding.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Parameter and its extensions.
+func (parameter Parameter) MarshalJSON() ([]byte, error) {
+	type alias Parameter
+	return marshalExtensible(alias(parameter), parameter.Extensions)
+}
+
+// UnmarshalJSON decodes a Parameter and its extensions.
+func (parameter *Parameter) UnmarshalJSON(data []byte) error {
+	type alias Parameter
+	return unmarshalExtensible(data, (*alias)(parameter), &parameter.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Example and its extensions.
+func (example Example) MarshalJSON() ([]byte, error) {
+	type alias Example
+	return marshalExtensible(alias(example), example.Extensions)
+}
+
+// UnmarshalJSON decodes an Example and its extensions.
+func (example *Example) UnmarshalJSON(data []byte) error {
+	type alias Example
+	return unmarshalExtensible(data, (*alias)(example), &example.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the XML and its extensions.
+func (xml XML) MarshalJSON() ([]byte, error) {
+	type alias XML
+	return marshalExtensible(alias(xml), xml.Extensions)
+}
+
+// UnmarshalJSON decodes an XML and its extensions.
+func (xml *XML) UnmarshalJSON(data []byte) error {
+	type alias XML
+	return unmarshalExtensible(data, (*alias)(xml), &xml.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Components and its extensions.
+func (components Components) MarshalJSON() ([]byte, error) {
+	type alias Components
+	return marshalExtensible(alias(components), components.Extensions)
+}
+
+// UnmarshalJSON decodes a Components and its extensions.
+func (components *Components) UnmarshalJSON(data []byte) error {
+	type alias Components
+	return unmarshalExtensible(data, (*alias)(components), &components.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the SecurityScheme and its extensions.
+func (securityScheme SecurityScheme) MarshalJSON() ([]byte, error) {
+	type alias SecurityScheme
+	return marshalExtensible(alias(securityScheme), securityScheme.Extensions)
+}
+
+// UnmarshalJSON decodes a SecurityScheme and its extensions.
+func (securityScheme *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type alias SecurityScheme
+	return unmarshalExtensible(data, (*alias)(securityScheme), &securityScheme.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the OAuthFlows and its extensions.
+func (oauthFlows OAuthFlows) MarshalJSON() ([]byte, error) {
+	type alias OAuthFlows
+	return marshalExtensible(alias(oauthFlows), oauthFlows.Extensions)
+}
+
+// UnmarshalJSON decodes an OAuthFlows and its extensions.
+func (oauthFlows *OAuthFlows) UnmarshalJSON(data []byte) error {
+	type alias OAuthFlows
+	return unmarshalExtensible(data, (*alias)(oauthFlows), &oauthFlows.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Response and its extensions.
+func (response Response) MarshalJSON() ([]byte, error) {
+	type alias Response
+	return marshalExtensible(alias(response), response.Extensions)
+}
+
+// UnmarshalJSON decodes a Response and its extensions.
+func (response *Response) UnmarshalJSON(data []byte) error {
+	type alias Response
+	return unmarshalExtensible(data, (*alias)(response), &response.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Link and its extensions.
+func (link Link) MarshalJSON() ([]byte, error) {
+	type alias Link
+	return marshalExtensible(alias(link), link.Extensions)
+}
+
+// UnmarshalJSON decodes a Link and its extensions.
+func (link *Link) UnmarshalJSON(data []byte) error {
+	type alias Link
+	return unmarshalExtensible(data, (*alias)(link), &link.Extensions)
+}
+
+// MarshalJSON returns the JSON encoding of the Header and its extensions.
+func (header Header) MarshalJSON() ([]byte, error) {
+	type alias Header
+	return marshalExtensible(alias(header), header.Extensions)
+}
+
+// UnmarshalJSON decodes a Header and its extensions.
+func (header *Header) UnmarshalJSON(data []byte) error {
+	type alias Header
+	return unmarshalExtensible(data, (*alias)(header), &header.Extensions)
+}
@@ -0,0 +1,183 @@
+package v303
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLinkServer(t *testing.T) {
+	data := []byte(`{"operationId":"getUser","server":{"url":"https://users.example.com"}}`)
+	var link Link
+	if err := json.Unmarshal(data, &link); err != nil {
+		t.Fatal(err)
+	}
+	if link.Server == nil || link.Server.Url != "https://users.example.com" {
+		t.Fatalf("server = %+v, want https://users.example.com", link.Server)
+	}
+	encoded, err := json.Marshal(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != string(data) {
+		t.Errorf("encoded %s, want %s", encoded, data)
+	}
+}
+
+func TestSchemaZeroBounds(t *testing.T) {
+	data := []byte(`{"maxLength":0,"minLength":0,"maxItems":0,"minItems":0,"maxProperties":0,"minProperties":0}`)
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	for name, bound := range map[string]*int{"maxLength": schema.MaxLength, "minLength": schema.MinLength,
+		"maxItems": schema.MaxItems, "minItems": schema.MinItems, "maxProperties": schema.MaxProperties,
+		"minProperties": schema.MinProperties} {
+		if bound == nil || *bound != 0 {
+			t.Errorf("%s = %v, want 0", name, bound)
+		}
+	}
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != string(data) {
+		t.Errorf("encoded %s, want %s", encoded, data)
+	}
+}
+
+// defaultFalse lists the boolean fields whose false value is their default and is thus omitted when encoding.
+var defaultFalse = map[string]bool{
+	"required": true, "deprecated": true, "allowEmptyValue": true, "allowReserved": true, "nullable": true,
+	"readOnly": true, "writeOnly": true, "uniqueItems": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+}
+
+// semantic decodes data into a generic value, dropping the members set to their default false value.
+func semantic(t *testing.T, data []byte) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+	return dropDefaults(value)
+}
+
+func dropDefaults(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, member := range value {
+			if member == false && defaultFalse[key] {
+				delete(value, key)
+				continue
+			}
+			value[key] = dropDefaults(member)
+		}
+	case []interface{}:
+		for i, element := range value {
+			value[i] = dropDefaults(element)
+		}
+	}
+	return value
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, name := range []string{"petstore.yaml", "uspto.yaml", "link-example.yaml", "callback-example.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var openAPI OpenAPI
+			if err := yaml.Unmarshal(data, &openAPI); err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(openAPI)
+			if err != nil {
+				t.Fatal(err)
+			}
+			original, err := codec.YAMLToJSON(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := semantic(t, original), semantic(t, encoded); !reflect.DeepEqual(got, want) {
+				t.Errorf("re-encoded document differs\n got: %s\nwant: %s", encoded, original)
+			}
+		})
+	}
+}
+
+func TestLegacyShapes(t *testing.T) {
+	data := []byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Legacy", "version": "1.0.0"},
+		"servers": {"url": "https://api.example.com"},
+		"tags": {"name": "pets"},
+		"security": {"apiKey": []},
+		"paths": {
+			"/pets": {
+				"server": [{"url": "https://pets.example.com"}],
+				"get": {
+					"security": {"oauth": ["read"]},
+					"responses": {"200": {"description": "pets"}}
+				}
+			}
+		}
+	}`)
+	var openAPI OpenAPI
+	if err := json.Unmarshal(data, &openAPI); err != nil {
+		t.Fatal(err)
+	}
+	if len(openAPI.Servers) != 1 || openAPI.Servers[0].Url != "https://api.example.com" {
+		t.Errorf("servers = %v, want a single https://api.example.com server", openAPI.Servers)
+	}
+	if len(openAPI.Tags) != 1 || openAPI.Tags[0].Name != "pets" {
+		t.Errorf("tags = %v, want a single pets tag", openAPI.Tags)
+	}
+	if want := []SecurityRequirement{{"apiKey": {}}}; !reflect.DeepEqual(openAPI.Security, want) {
+		t.Errorf("security = %v, want %v", openAPI.Security, want)
+	}
+	pathItem := openAPI.Paths["/pets"]
+	if len(pathItem.Servers) != 1 || pathItem.Servers[0].Url != "https://pets.example.com" {
+		t.Errorf("path item servers = %v, want a single https://pets.example.com server", pathItem.Servers)
+	}
+	security := pathItem.Get.Security
+	if want := []SecurityRequirement{{"oauth": {"read"}}}; security == nil || !reflect.DeepEqual(*security, want) {
+		t.Errorf("operation security = %v, want %v", security, want)
+	}
+
+	encoded, err := json.Marshal(pathItem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"servers":[{"url":"https://pets.example.com"}]`; !bytes.Contains(encoded, []byte(want)) {
+		t.Errorf("encoded path item %s, want it to contain %s", encoded, want)
+	}
+}
+
+func TestCallbackExtensions(t *testing.T) {
+	data := []byte(`{"{$request.query.callbackUrl}/data":{"post":{"responses":{"202":{"description":"received"}}}},"x-retries":3}`)
+	var callback Callback
+	if err := json.Unmarshal(data, &callback); err != nil {
+		t.Fatal(err)
+	}
+	if len(callback.Paths) != 1 || callback.Paths["{$request.query.callbackUrl}/data"] == nil {
+		t.Errorf("paths = %v, want the {$request.query.callbackUrl}/data path item alone", callback.Paths)
+	}
+	var retries int
+	if found, err := callback.GetExtension("x-retries", &retries); !found || err != nil || retries != 3 {
+		t.Errorf("GetExtension(x-retries) = %d, %v, %v, want 3", retries, found, err)
+	}
+	encoded, err := json.Marshal(callback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != string(data) {
+		t.Errorf("encoded %s, want %s", encoded, data)
+	}
+}
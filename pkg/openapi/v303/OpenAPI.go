@@ -1,314 +1,389 @@
 // http://spec.openapis.org/oas/v3.0.3
 package v303
 
+import "encoding/json"
+
 // OpenAPI is the root document object of the OpenAPI document.
 // http://spec.openapis.org/oas/v3.0.3#openapi-object
 type OpenAPI struct {
+	Extensions   `json:"-"`
 	OpenAPI      string                 `json:"openapi"`
 	Info         *Info                  `json:"info"`
-	Servers      *Server                `json:"servers"`
+	Servers      []*Server              `json:"servers,omitempty"`
 	Paths        map[string]*PathItem   `json:"paths"`
-	Components   *Components            `json:"components"`
-	Security     map[string][]string    `json:"security"`
-	Tags         *Tag                   `json:"tags"`
-	ExternalDocs *ExternalDocumentation `json:"externalDocs"`
-	Schemes      []string               `json:"schemes"`
+	Components   *Components            `json:"components,omitempty"`
+	Security     []SecurityRequirement  `json:"security,omitempty"`
+	Tags         []*Tag                 `json:"tags,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+	// Deprecated: schemes is not part of OAS 3.0.3 and is never serialized, declare the scheme in the Servers url instead.
+	Schemes []string `json:"-"`
+
+	// PathsExtensions are the specification extensions of the Paths Object.
+	PathsExtensions Extensions `json:"-"`
+
+	pathsOrder []string
 }
 
 // Info provides metadata about the API. The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.
 // http://spec.openapis.org/oas/v3.0.3#info-object
 type Info struct {
+	Extensions     `json:"-"`
 	Title          string   `json:"title"`
-	Description    string   `json:"description"`
-	TermsOfService string   `json:"termsOfService"`
-	Contact        *Contact `json:"contact"`
-	License        *License `json:"license"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
 	Version        string   `json:"version"`
 }
 
 // Tag Adds metadata to a single tag that is used by the Operation Object. It is not mandatory to have a Tag Object per tag defined in the Operation Object instances.
 // http://spec.openapis.org/oas/v3.0.3#tag-object
 type Tag struct {
+	Extensions   `json:"-"`
 	Name         string                 `json:"name"`
-	Description  string                 `json:"description"`
-	ExternalDocs *ExternalDocumentation `json:"externalDocs"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
 }
 
 // Contact information for the exposed API.
 // http://spec.openapis.org/oas/v3.0.3#contact-object
 type Contact struct {
-	Name  string `json:"name"`
-	Url   string `json:"url"`
-	Email string `json:"email"`
+	Extensions `json:"-"`
+	Name       string `json:"name,omitempty"`
+	Url        string `json:"url,omitempty"`
+	Email      string `json:"email,omitempty"`
 }
 
 // License information for the exposed API.
 // http://spec.openapis.org/oas/v3.0.3#license-object
 type License struct {
-	Name string `json:"name"`
-	Url  string `json:"url"`
+	Extensions `json:"-"`
+	Name       string `json:"name"`
+	Url        string `json:"url,omitempty"`
 }
 
 // Server An object representing a Server.
 // http://spec.openapis.org/oas/v3.0.3#server-object
 type Server struct {
+	Extensions  `json:"-"`
 	Url         string                     `json:"url"`
-	Description string                     `json:"description"`
-	Variables   map[string]*ServerVariable `json:"variables"`
+	Description string                     `json:"description,omitempty"`
+	Variables   map[string]*ServerVariable `json:"variables,omitempty"`
 }
 
 // ServerVariable An object representing a Server Variable for server URL template substitution.
 // http://spec.openapis.org/oas/v3.0.3#server-variable-object
 type ServerVariable struct {
-	Enum        []string `json:"enum"`
+	Extensions  `json:"-"`
+	Enum        []string `json:"enum,omitempty"`
 	Default     string   `json:"default"`
-	Description string   `json:"description"`
+	Description string   `json:"description,omitempty"`
 }
 
 // Reference A simple object to allow referencing other components in the specification, internally and externally.
 // http://spec.openapis.org/oas/v3.0.3#reference-object
 type Reference struct {
-	Ref string `json:"$ref"`
+	Ref string `json:"$ref,omitempty"`
 }
 
 // PathItem Describes the operations available on a single path. A Path Item MAY be empty, due to ACL constraints. The path itself is still exposed to the documentation viewer but they will not know which operations and parameters are available.
 // http://spec.openapis.org/oas/v3.0.3#path-item-object
 type PathItem struct {
 	Reference
-	Summary     string       `json:"summary"`
-	Description string       `json:"description"`
-	Get         *Operation   `json:"get"`
-	Put         *Operation   `json:"put"`
-	Post        *Operation   `json:"post"`
-	Delete      *Operation   `json:"delete"`
-	Options     *Operation   `json:"options"`
-	Head        *Operation   `json:"head"`
-	Patch       *Operation   `json:"patch"`
-	Trace       *Operation   `json:"trace"`
-	Servers     []*Server    `json:"server"`
-	Parameters  []*Parameter `json:"parameters"`
+	Extensions  `json:"-"`
+	Summary     string          `json:"summary,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Get         *Operation      `json:"get,omitempty"`
+	Put         *Operation      `json:"put,omitempty"`
+	Post        *Operation      `json:"post,omitempty"`
+	Delete      *Operation      `json:"delete,omitempty"`
+	Options     *Operation      `json:"options,omitempty"`
+	Head        *Operation      `json:"head,omitempty"`
+	Patch       *Operation      `json:"patch,omitempty"`
+	Trace       *Operation      `json:"trace,omitempty"`
+	Servers     []*Server       `json:"servers,omitempty"`
+	Parameters  []*ParameterRef `json:"parameters,omitempty"`
+}
+
+// Operations returns the operations defined on the path item keyed by their upper case HTTP method.
+func (pathItem *PathItem) Operations() map[string]*Operation {
+	operations := map[string]*Operation{}
+	for method, operation := range map[string]*Operation{
+		"GET": pathItem.Get, "PUT": pathItem.Put, "POST": pathItem.Post, "DELETE": pathItem.Delete,
+		"OPTIONS": pathItem.Options, "HEAD": pathItem.Head, "PATCH": pathItem.Patch, "TRACE": pathItem.Trace,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
 }
 
 // Operation Describes a single API operation on a path.
 // http://spec.openapis.org/oas/v3.0.3#operation-object
 type Operation struct {
-	Tags         []string                        `json:"tags"`
-	Summary      string                          `json:"summary"`
-	Description  string                          `json:"description"`
-	ExternalDocs *ExternalDocumentation          `json:"externalDocs"`
-	OperationID  string                          `json:"operationId"`
-	Parameters   []*Parameter                    `json:"parameters"`
-	RequestBody  *RequestBody                    `json:"requestBody"`
-	Responses    map[string]*Response            `json:"responses"`
-	Callbacks    map[string]map[string]*PathItem `json:"callbacks"`
-	Deprecated   bool                            `json:"deprecated"`
-	Security     map[string][]string             `json:"security"`
-	Servers      []*Server                       `json:"servers"`
+	Extensions   `json:"-"`
+	Tags         []string                `json:"tags,omitempty"`
+	Summary      string                  `json:"summary,omitempty"`
+	Description  string                  `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation  `json:"externalDocs,omitempty"`
+	OperationID  string                  `json:"operationId,omitempty"`
+	Parameters   []*ParameterRef         `json:"parameters,omitempty"`
+	RequestBody  *RequestBodyRef         `json:"requestBody,omitempty"`
+	Responses    map[string]*ResponseRef `json:"responses"`
+	Callbacks    map[string]*CallbackRef `json:"callbacks,omitempty"`
+	Deprecated   bool                    `json:"deprecated,omitempty"`
+	Security     *[]SecurityRequirement  `json:"security,omitempty"`
+	Servers      []*Server               `json:"servers,omitempty"`
+
+	// ResponsesExtensions are the specification extensions of the Responses Object.
+	ResponsesExtensions Extensions `json:"-"`
+
+	responsesOrder []string
 }
 
 // ExternalDocumentation Allows referencing an external resource for extended documentation.
 // http://spec.openapis.org/oas/v3.0.3#external-documentation-object
 type ExternalDocumentation struct {
-	Description string `json:"description"`
+	Extensions  `json:"-"`
+	Description string `json:"description,omitempty"`
 	URL         string `json:"url"`
 }
 
 // Describes a single request body.
 // http://spec.openapis.org/oas/v3.0.3#request-body-object
 type RequestBody struct {
-	Reference
-	Description string                `json:"description"`
+	Extensions  `json:"-"`
+	Description string                `json:"description,omitempty"`
 	Content     map[string]*MediaType `json:"content"`
-	Required    bool                  `json:"required"`
+	Required    bool                  `json:"required,omitempty"`
 }
 
 // MediaType Each Media Type Object provides schema and examples for the media type identified by its key.
 // http://spec.openapis.org/oas/v3.0.3#media-type-object
 type MediaType struct {
-	Schema   *Schema              `json:"schema"`
-	Example  *Example             `json:"example"`
-	Examples map[string]*Example  `json:"examples"`
-	Encoding map[string]*Encoding `json:"encoding"`
+	Extensions `json:"-"`
+	Schema     *SchemaRef             `json:"schema,omitempty"`
+	Example    json.RawMessage        `json:"example,omitempty"`
+	Examples   map[string]*ExampleRef `json:"examples,omitempty"`
+	Encoding   map[string]*Encoding   `json:"encoding,omitempty"`
 }
 
 // Encoding A single encoding definition applied to a single schema property.
 // http://spec.openapis.org/oas/v3.0.3#encoding-object
 type Encoding struct {
-	ContentType   string             `json:"content_type"`
-	Headers       map[string]*Header `json:"headers"`
-	Style         string             `json:"style"`
-	Explode       bool               `json:"explode"`
-	AllowReserved bool               `json:"allowReserved"`
+	Extensions    `json:"-"`
+	ContentType   string                `json:"contentType,omitempty"`
+	Headers       map[string]*HeaderRef `json:"headers,omitempty"`
+	Style         string                `json:"style,omitempty"`
+	Explode       *bool                 `json:"explode,omitempty"`
+	AllowReserved bool                  `json:"allowReserved,omitempty"`
 }
 
 // Parameter Describes a single operation parameter.
 // http://spec.openapis.org/oas/v3.0.3#parameter-object
 type Parameter struct {
-	Reference
-	Name            string                `json:"name"`
-	In              string                `json:"in"`
-	Description     string                `json:"description"`
-	Required        bool                  `json:"required"`
-	Deprecated      bool                  `json:"deprecated"`
-	AllowEmptyValue bool                  `json:"allowEmptyValue"`
-	Style           string                `json:"style"`
-	Explode         bool                  `json:"explode"`
-	AllowReserved   bool                  `json:"allowReserved"`
-	Schema          *Schema               `json:"schema"`
-	Example         string                `json:"example"`
-	Examples        map[string]*Example   `json:"examples"`
-	Content         map[string]*MediaType `json:"content"`
+	Extensions      `json:"-"`
+	Name            string                 `json:"name"`
+	In              string                 `json:"in"`
+	Description     string                 `json:"description,omitempty"`
+	Required        bool                   `json:"required,omitempty"`
+	Deprecated      bool                   `json:"deprecated,omitempty"`
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty"`
+	Style           string                 `json:"style,omitempty"`
+	Explode         *bool                  `json:"explode,omitempty"`
+	AllowReserved   bool                   `json:"allowReserved,omitempty"`
+	Schema          *SchemaRef             `json:"schema,omitempty"`
+	Example         json.RawMessage        `json:"example,omitempty"`
+	Examples        map[string]*ExampleRef `json:"examples,omitempty"`
+	Content         map[string]*MediaType  `json:"content,omitempty"`
 }
 
 // Example is simply an example
 // http://spec.openapis.org/oas/v3.0.3#example-object
 type Example struct {
-	Reference
-	Summary       string      `json:"summary"`
-	Description   string      `json:"description"`
-	Value         interface{} `json:"value"`
-	ExternalValue string      `json:"externalValue"`
+	Extensions    `json:"-"`
+	Summary       string          `json:"summary,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	Value         json.RawMessage `json:"value,omitempty"`
+	ExternalValue string          `json:"externalValue,omitempty"`
 }
 
 // Schema Object allows the definition of input and output data types. These types can be objects, but also primitives and arrays.
+// Numeric keywords are json.Number so that they keep their exact value, an empty one is absent. The values of
+// default, example and enum are raw JSON, an empty one is absent.
 // http://spec.openapis.org/oas/v3.0.3#schema-object
 type Schema struct {
-	Reference
-	Title                string                 `json:"title"`
-	MultipleOf           int                    `json:"multipleOf"`
-	Maximum              int                    `json:"maximum"`
-	ExclusiveMaximum     bool                   `json:"exclusiveMaximum"`
-	Minimum              int                    `json:"minimum"`
-	ExclusiveMinimum     bool                   `json:"exclusiveMinimum"`
-	MaxLength            int                    `json:"maxLength"`
-	MinLength            int                    `json:"minLength"`
-	Pattern              string                 `json:"pattern"`
-	MaxItems             int                    `json:"maxItems"`
-	MinItems             int                    `json:"minItems"`
-	UniqueItems          bool                   `json:"uniqueItems"`
-	MaxProperties        int                    `json:"maxProperties"`
-	MinProperties        int                    `json:"minProperties"`
-	Required             []string               `json:"required"`
-	Enum                 []interface{}          `json:"enum"`
-	Type                 string                 `json:"type"`
-	AllOf                []*Schema              `json:"allOf"`
-	OneOf                []*Schema              `json:"oneOf"`
-	AnyOf                []*Schema              `json:"anyOf"`
-	Not                  *Schema                `json:"not"`
-	Items                []*Schema              `json:"items"`
-	Properties           map[string]*Schema     `json:"properties"`
-	AdditionalProperties map[string]*Schema     `json:"additionalProperties"`
-	Description          string                 `json:"description"`
-	Format               string                 `json:"format"` //date-time,email, hostname,ipv4, ipv6,uri,uriref
-	Default              string                 `json:"default"`
-	Nullable             bool                   `json:"nullable"`
-	Discriminator        *Discriminator         `json:"discriminator"`
-	ReadOnly             bool                   `json:"readOnly"`
-	WriteOnly            bool                   `json:"writeOnly"`
-	Xml                  *XML                   `json:"xml"`
-	ExternalDocs         *ExternalDocumentation `json:"externalDocs"`
-	Example              *Example               `json:"example"`
-	Deprecated           bool                   `json:"deprecated"`
+	Extensions           `json:"-"`
+	Title                string                 `json:"title,omitempty"`
+	MultipleOf           json.Number            `json:"multipleOf,omitempty"`
+	Maximum              json.Number            `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool                   `json:"exclusiveMaximum,omitempty"`
+	Minimum              json.Number            `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool                   `json:"exclusiveMinimum,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
+	MaxProperties        *int                   `json:"maxProperties,omitempty"`
+	MinProperties        *int                   `json:"minProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Enum                 []json.RawMessage      `json:"enum,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	AllOf                []*SchemaRef           `json:"allOf,omitempty"`
+	OneOf                []*SchemaRef           `json:"oneOf,omitempty"`
+	AnyOf                []*SchemaRef           `json:"anyOf,omitempty"`
+	Not                  *SchemaRef             `json:"not,omitempty"`
+	Items                *SchemaRef             `json:"items,omitempty"`
+	Properties           map[string]*SchemaRef  `json:"properties,omitempty"`
+	AdditionalProperties *AdditionalProperties  `json:"additionalProperties,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Format               string                 `json:"format,omitempty"` //date-time,email, hostname,ipv4, ipv6,uri,uriref
+	Default              json.RawMessage        `json:"default,omitempty"`
+	Nullable             bool                   `json:"nullable,omitempty"`
+	Discriminator        *Discriminator         `json:"discriminator,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+	Xml                  *XML                   `json:"xml,omitempty"`
+	ExternalDocs         *ExternalDocumentation `json:"externalDocs,omitempty"`
+	Example              json.RawMessage        `json:"example,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+
+	propertiesOrder []string
+}
+
+// AdditionalProperties is the value of additionalProperties, either a boolean or a schema. A nil
+// AdditionalProperties is absent, which allows any additional property.
+// http://spec.openapis.org/oas/v3.0.3#properties
+type AdditionalProperties struct {
+	// Allowed is the boolean form, ignored when Schema is set: false forbids the properties which are not listed
+	// in properties.
+	Allowed bool
+	// Schema, when set, is the schema of the properties which are not listed in properties.
+	Schema *SchemaRef
+}
+
+// Forbidden reports whether additionalProperties is false.
+func (additionalProperties *AdditionalProperties) Forbidden() bool {
+	return additionalProperties != nil && additionalProperties.Schema == nil && !additionalProperties.Allowed
 }
 
 // XML A metadata object that allows for more fine-tuned XML model definitions.
 // http://spec.openapis.org/oas/v3.0.3#xml-object
 type XML struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Prefix    string `json:"prefix"`
-	Attribute bool   `json:"attribute"`
-	Wrapped   bool   `json:"wrapped"`
+	Extensions `json:"-"`
+	Name       string `json:"name,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Prefix     string `json:"prefix,omitempty"`
+	Attribute  bool   `json:"attribute,omitempty"`
+	Wrapped    bool   `json:"wrapped,omitempty"`
 }
 
 // Discriminator When request bodies or response payloads may be one of a number of different schemas, a discriminator object can be used to aid in serialization, deserialization, and validation.
 // http://spec.openapis.org/oas/v3.0.3#discriminator-object
 type Discriminator struct {
-	PropertyName string
-	Mapping      map[string]string
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
 }
 
 // Componenets Holds a set of reusable objects for different aspects of the OAS
 // http://spec.openapis.org/oas/v3.0.3#components-object
 type Components struct {
-	Schema          map[string]*Schema              `json:"schema"`
-	Responses       map[string]*Response            `json:"responses"`
-	Parameters      map[string]*Parameter           `json:"parameters"`
-	Examples        map[string]*Example             `json:"examples"`
-	RequestBodies   map[string]*RequestBody         `json:"requestBodies"`
-	Headers         map[string]*Header              `json:"headers"`
-	SecuritySchemes map[string]*SecurityScheme      `json:"securitySchemes"`
-	Links           map[string]*Link                `json:"links"`
-	Callbacks       map[string]map[string]*PathItem `json:"callbacks"`
+	Extensions      `json:"-"`
+	Schema          map[string]*SchemaRef         `json:"schemas,omitempty"`
+	Responses       map[string]*ResponseRef       `json:"responses,omitempty"`
+	Parameters      map[string]*ParameterRef      `json:"parameters,omitempty"`
+	Examples        map[string]*ExampleRef        `json:"examples,omitempty"`
+	RequestBodies   map[string]*RequestBodyRef    `json:"requestBodies,omitempty"`
+	Headers         map[string]*HeaderRef         `json:"headers,omitempty"`
+	SecuritySchemes map[string]*SecuritySchemeRef `json:"securitySchemes,omitempty"`
+	Links           map[string]*LinkRef           `json:"links,omitempty"`
+	Callbacks       map[string]*CallbackRef       `json:"callbacks,omitempty"`
 }
 
 // SecurityScheme Defines a security scheme that can be used by the operations
 // http://spec.openapis.org/oas/v3.0.3#security-scheme-object
 type SecurityScheme struct {
-	Reference
+	Extensions       `json:"-"`
 	Type             string      `json:"type"`
-	Description      string      `json:"description"`
-	Name             string      `json:"name"`
-	In               string      `json:"in"`
-	Scheme           string      `json:"scheme"`
-	BearerFormat     string      `json:"bearerFormat"`
-	Flows            *OAuthFlows `json:"flows"`
-	OpenIDConnectURL string      `json:"openIdConnectUrl"`
+	Description      string      `json:"description,omitempty"`
+	Name             string      `json:"name,omitempty"`
+	In               string      `json:"in,omitempty"`
+	Scheme           string      `json:"scheme,omitempty"`
+	BearerFormat     string      `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlows `json:"flows,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"`
+}
+
+// Callback A map of possible out-of band callbacks related to the parent operation. Each value is a Path Item Object keyed by a runtime expression.
+// http://spec.openapis.org/oas/v3.0.3#callback-object
+type Callback struct {
+	Extensions `json:"-"`
+	// Paths are the path items of the callback, keyed by runtime expression.
+	Paths map[string]*PathItem `json:"-"`
 }
 
+// SecurityRequirement Lists the required security schemes to execute this operation. The name used for each property MUST correspond to a security scheme declared in the Security Schemes under the Components Object.
+// http://spec.openapis.org/oas/v3.0.3#security-requirement-object
+type SecurityRequirement map[string][]string
+
 // OAuthFlows Allows configuration of the supported OAuth Flows.
 // http://spec.openapis.org/oas/v3.0.3#oauth-flows-object
 type OAuthFlows struct {
-	Implicit          *OAuthFlow `json:"implicit"`
-	Password          *OAuthFlow `json:"password"`
-	ClientCredentials *OAuthFlow `json:"clientCredentials"`
-	AuthorizationCode *OAuthFlow `json:"authorizationCode"`
+	Extensions        `json:"-"`
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
 }
 
 // OAuthFlow Configuration details for a supported OAuth Flow
 // http://spec.openapis.org/oas/v3.0.3#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationURL string            `json:"authorizationUrl"`
-	TokenURL         string            `json:"tokenUrl"`
-	RefreshURL       string            `json:"refreshUrl"`
+	Extensions       `json:"-"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
 	Scopes           map[string]string `json:"scopes"`
 }
 
 // Response A container for the expected responses of an operation
 // http://spec.openapis.org/oas/v3.0.3#responses-object
 type Response struct {
-	Reference
+	Extensions  `json:"-"`
 	Description string                `json:"description"`
-	Headers     map[string]*Header    `json:"headers"`
-	Content     map[string]*MediaType `json:"content"`
-	Links       map[string]*Link      `json:"links"`
+	Headers     map[string]*HeaderRef `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+	Links       map[string]*LinkRef   `json:"links,omitempty"`
 }
 
 // Link represents a possible design-time link for a response
 // http://spec.openapis.org/oas/v3.0.3#link-object
 type Link struct {
-	Reference
-	OperationRef string
-	OperationID  string
-	Parameters   map[string]interface{}
-	RequestBody  interface{}
-	Description  string
-	Server       *Server
+	Extensions   `json:"-"`
+	OperationRef string                 `json:"operationRef,omitempty"`
+	OperationID  string                 `json:"operationId,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	RequestBody  interface{}            `json:"requestBody,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	Server       *Server                `json:"server,omitempty"`
 }
 
 // Header follows the structure of the Parameter Object
 // http://spec.openapis.org/oas/v3.0.3#header-object
 type Header struct {
-	Reference
-	Description     string                `json:"description"`
-	Required        bool                  `json:"required"`
-	Deprecated      bool                  `json:"deprecated"`
-	AllowEmptyValue bool                  `json:"allowEmptyValue"`
-	Style           string                `json:"style"`
-	Explode         bool                  `json:"explode"`
-	AllowReserved   bool                  `json:"allowReserved"`
-	Schema          *Schema               `json:"schema"`
-	Example         string                `json:"example"`
-	Examples        map[string]*Example   `json:"examples"`
-	Content         map[string]*MediaType `json:"content"`
+	Extensions      `json:"-"`
+	Description     string                 `json:"description,omitempty"`
+	Required        bool                   `json:"required,omitempty"`
+	Deprecated      bool                   `json:"deprecated,omitempty"`
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty"`
+	Style           string                 `json:"style,omitempty"`
+	Explode         *bool                  `json:"explode,omitempty"`
+	AllowReserved   bool                   `json:"allowReserved,omitempty"`
+	Schema          *SchemaRef             `json:"schema,omitempty"`
+	Example         json.RawMessage        `json:"example,omitempty"`
+	Examples        map[string]*ExampleRef `json:"examples,omitempty"`
+	Content         map[string]*MediaType  `json:"content,omitempty"`
 }
@@ -0,0 +1,60 @@
+package v303
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// escapePointerToken escapes a single JSON Pointer reference token.
+// https://tools.ietf.org/html/rfc6901#section-3
+func escapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// unescapePointerToken reverses escapePointerToken.
+func unescapePointerToken(token string) string {
+	return strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+}
+
+// lookupPointer returns the JSON value found at pointer inside the JSON document data.
+// https://tools.ietf.org/html/rfc6901
+func lookupPointer(data []byte, pointer string) ([]byte, error) {
+	if pointer == "" {
+		return data, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	current := bytes.TrimSpace(data)
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointerToken(token)
+		switch {
+		case len(current) > 0 && current[0] == '{':
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(current, &object); err != nil {
+				return nil, err
+			}
+			value, ok := object[token]
+			if !ok {
+				return nil, fmt.Errorf("JSON pointer %q: member %q not found", pointer, token)
+			}
+			current = bytes.TrimSpace(value)
+		case len(current) > 0 && current[0] == '[':
+			var array []json.RawMessage
+			if err := json.Unmarshal(current, &array); err != nil {
+				return nil, err
+			}
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(array) {
+				return nil, fmt.Errorf("JSON pointer %q: index %q out of range", pointer, token)
+			}
+			current = bytes.TrimSpace(array[index])
+		default:
+			return nil, fmt.Errorf("JSON pointer %q: cannot descend into a scalar at %q", pointer, token)
+		}
+	}
+	return current, nil
+}
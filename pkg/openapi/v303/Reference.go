@@ -0,0 +1,262 @@
+package v303
+
+import (
+	"encoding/json"
+)
+
+// The *Ref types below hold either a Reference Object or an inline value of the referenced type.
+// When Ref is set the object is a reference and only Ref is serialized, Value may then carry the resolved
+// target alongside it. When Ref is empty Value is the inline object.
+// http://spec.openapis.org/oas/v3.0.3#reference-object
+
+// SchemaRef is either a Reference Object to a Schema or an inline Schema.
+type SchemaRef struct {
+	Ref   string
+	Value *Schema
+}
+
+// ParameterRef is either a Reference Object to a Parameter or an inline Parameter.
+type ParameterRef struct {
+	Ref   string
+	Value *Parameter
+}
+
+// ResponseRef is either a Reference Object to a Response or an inline Response.
+type ResponseRef struct {
+	Ref   string
+	Value *Response
+}
+
+// RequestBodyRef is either a Reference Object to a RequestBody or an inline RequestBody.
+type RequestBodyRef struct {
+	Ref   string
+	Value *RequestBody
+}
+
+// HeaderRef is either a Reference Object to a Header or an inline Header.
+type HeaderRef struct {
+	Ref   string
+	Value *Header
+}
+
+// ExampleRef is either a Reference Object to an Example or an inline Example.
+type ExampleRef struct {
+	Ref   string
+	Value *Example
+}
+
+// LinkRef is either a Reference Object to a Link or an inline Link.
+type LinkRef struct {
+	Ref   string
+	Value *Link
+}
+
+// SecuritySchemeRef is either a Reference Object to a SecurityScheme or an inline SecurityScheme.
+type SecuritySchemeRef struct {
+	Ref   string
+	Value *SecurityScheme
+}
+
+// CallbackRef is either a Reference Object to a Callback or an inline Callback.
+type CallbackRef struct {
+	Ref   string
+	Value *Callback
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Schema.
+func (schemaRef SchemaRef) MarshalJSON() ([]byte, error) {
+	if schemaRef.Ref != "" {
+		return marshalRef(schemaRef.Ref)
+	}
+	return json.Marshal(schemaRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Schema into Value.
+func (schemaRef *SchemaRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*schemaRef = SchemaRef{Ref: ref}
+		return nil
+	}
+	*schemaRef = SchemaRef{Value: new(Schema)}
+	return json.Unmarshal(data, schemaRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Parameter.
+func (parameterRef ParameterRef) MarshalJSON() ([]byte, error) {
+	if parameterRef.Ref != "" {
+		return marshalRef(parameterRef.Ref)
+	}
+	return json.Marshal(parameterRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Parameter into Value.
+func (parameterRef *ParameterRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*parameterRef = ParameterRef{Ref: ref}
+		return nil
+	}
+	*parameterRef = ParameterRef{Value: new(Parameter)}
+	return json.Unmarshal(data, parameterRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Response.
+func (responseRef ResponseRef) MarshalJSON() ([]byte, error) {
+	if responseRef.Ref != "" {
+		return marshalRef(responseRef.Ref)
+	}
+	return json.Marshal(responseRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Response into Value.
+func (responseRef *ResponseRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*responseRef = ResponseRef{Ref: ref}
+		return nil
+	}
+	*responseRef = ResponseRef{Value: new(Response)}
+	return json.Unmarshal(data, responseRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline RequestBody.
+func (requestBodyRef RequestBodyRef) MarshalJSON() ([]byte, error) {
+	if requestBodyRef.Ref != "" {
+		return marshalRef(requestBodyRef.Ref)
+	}
+	return json.Marshal(requestBodyRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline RequestBody into Value.
+func (requestBodyRef *RequestBodyRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*requestBodyRef = RequestBodyRef{Ref: ref}
+		return nil
+	}
+	*requestBodyRef = RequestBodyRef{Value: new(RequestBody)}
+	return json.Unmarshal(data, requestBodyRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Header.
+func (headerRef HeaderRef) MarshalJSON() ([]byte, error) {
+	if headerRef.Ref != "" {
+		return marshalRef(headerRef.Ref)
+	}
+	return json.Marshal(headerRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Header into Value.
+func (headerRef *HeaderRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*headerRef = HeaderRef{Ref: ref}
+		return nil
+	}
+	*headerRef = HeaderRef{Value: new(Header)}
+	return json.Unmarshal(data, headerRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Example.
+func (exampleRef ExampleRef) MarshalJSON() ([]byte, error) {
+	if exampleRef.Ref != "" {
+		return marshalRef(exampleRef.Ref)
+	}
+	return json.Marshal(exampleRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Example into Value.
+func (exampleRef *ExampleRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*exampleRef = ExampleRef{Ref: ref}
+		return nil
+	}
+	*exampleRef = ExampleRef{Value: new(Example)}
+	return json.Unmarshal(data, exampleRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Link.
+func (linkRef LinkRef) MarshalJSON() ([]byte, error) {
+	if linkRef.Ref != "" {
+		return marshalRef(linkRef.Ref)
+	}
+	return json.Marshal(linkRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Link into Value.
+func (linkRef *LinkRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*linkRef = LinkRef{Ref: ref}
+		return nil
+	}
+	*linkRef = LinkRef{Value: new(Link)}
+	return json.Unmarshal(data, linkRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline SecurityScheme.
+func (securitySchemeRef SecuritySchemeRef) MarshalJSON() ([]byte, error) {
+	if securitySchemeRef.Ref != "" {
+		return marshalRef(securitySchemeRef.Ref)
+	}
+	return json.Marshal(securitySchemeRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline SecurityScheme into Value.
+func (securitySchemeRef *SecuritySchemeRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*securitySchemeRef = SecuritySchemeRef{Ref: ref}
+		return nil
+	}
+	*securitySchemeRef = SecuritySchemeRef{Value: new(SecurityScheme)}
+	return json.Unmarshal(data, securitySchemeRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Callback.
+func (callbackRef CallbackRef) MarshalJSON() ([]byte, error) {
+	if callbackRef.Ref != "" {
+		return marshalRef(callbackRef.Ref)
+	}
+	return json.Marshal(callbackRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Callback into Value.
+func (callbackRef *CallbackRef) UnmarshalJSON(data []byte) error {
+	ref, isRef, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if isRef {
+		*callbackRef = CallbackRef{Ref: ref}
+		return nil
+	}
+	*callbackRef = CallbackRef{Value: new(Callback)}
+	return json.Unmarshal(data, callbackRef.Value)
+}
@@ -0,0 +1,605 @@
+package v303
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ValidationError is a violation of the specification, located by a JSON pointer into the document.
+type ValidationError struct {
+	Location string
+	Message  string
+}
+
+func (validationError ValidationError) Error() string {
+	return validationError.Location + ": " + validationError.Message
+}
+
+var (
+	// responseCode matches the keys of a Responses Object.
+	// http://spec.openapis.org/oas/v3.0.3#patterned-fields-0
+	responseCode = regexp.MustCompile(`^([1-5][0-9][0-9]|[1-5]XX|default)$`)
+	// pathTemplate matches the template expressions of a path.
+	// http://spec.openapis.org/oas/v3.0.3#path-templating
+	pathTemplate = regexp.MustCompile(`\{([^{}]+)\}`)
+	// openAPIVersion matches the versions of the specification this package models.
+	openAPIVersion = regexp.MustCompile(`^3\.0\.\d+$`)
+)
+
+// parameterLocations are the valid values of the in field of a Parameter.
+// http://spec.openapis.org/oas/v3.0.3#parameter-locations
+var parameterLocations = map[string]bool{"query": true, "header": true, "path": true, "cookie": true}
+
+// Validate checks the document against the rules of the specification which the Go types cannot express:
+// required fields, unique operationIds, path templates matching the path parameters, unique parameters,
+// schema and content being mutually exclusive, response status codes and $ref targets. Values reached through
+// a $ref are validated where they are declared. Validate stops early when ctx is done, the error of ctx is
+// then the last ValidationError.
+func (openAPI *OpenAPI) Validate(ctx context.Context) []ValidationError {
+	validator := &validator{ctx: ctx, openAPI: openAPI, operationIDs: map[string]string{}, schemas: map[*Schema]bool{}}
+	validator.validateOpenAPI()
+	return validator.errors
+}
+
+type validator struct {
+	ctx          context.Context
+	openAPI      *OpenAPI
+	errors       []ValidationError
+	operationIDs map[string]string
+	schemas      map[*Schema]bool
+	// values compiles the schemas default and example values are checked against, for the duration of the validation.
+	values *validatorCompiler
+	// document is the JSON encoding of the document, local $ref are looked up into it.
+	document []byte
+}
+
+func (validator *validator) report(location, format string, args ...interface{}) {
+	validator.errors = append(validator.errors, ValidationError{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// done reports whether the context is done, recording its error once.
+func (validator *validator) done() bool {
+	err := validator.ctx.Err()
+	if err == nil {
+		return false
+	}
+	if last := len(validator.errors) - 1; last < 0 || validator.errors[last].Message != err.Error() {
+		validator.report("#", "%s", err.Error())
+	}
+	return true
+}
+
+func (validator *validator) validateOpenAPI() {
+	openAPI := validator.openAPI
+	if !openAPIVersion.MatchString(openAPI.OpenAPI) {
+		validator.report("#/openapi", "version %q is not an OpenAPI 3.0 version", openAPI.OpenAPI)
+	}
+	if openAPI.Info == nil {
+		validator.report("#/info", "info is required")
+	} else {
+		if openAPI.Info.Title == "" {
+			validator.report("#/info/title", "title is required")
+		}
+		if openAPI.Info.Version == "" {
+			validator.report("#/info/version", "version is required")
+		}
+		if openAPI.Info.License != nil && openAPI.Info.License.Name == "" {
+			validator.report("#/info/license/name", "name is required")
+		}
+	}
+	validator.validateServers(openAPI.Servers, "#/servers")
+	for _, path := range sortedKeys(openAPI.Paths) {
+		if validator.done() {
+			return
+		}
+		location := "#/paths/" + escapePointerToken(path)
+		if !strings.HasPrefix(path, "/") {
+			validator.report(location, "path %q must begin with a slash", path)
+		}
+		if pathItem := openAPI.Paths[path]; pathItem != nil {
+			validator.validatePathItem(pathItem, path, location)
+		}
+	}
+	for i, tag := range openAPI.Tags {
+		if tag != nil && tag.Name == "" {
+			validator.report(fmt.Sprintf("#/tags/%d/name", i), "name is required")
+		}
+	}
+	validator.validateComponents(openAPI.Components)
+}
+
+func (validator *validator) validateServers(servers []*Server, location string) {
+	for i, server := range servers {
+		if server != nil && server.Url == "" {
+			validator.report(fmt.Sprintf("%s/%d/url", location, i), "url is required")
+		}
+	}
+}
+
+func (validator *validator) validateComponents(components *Components) {
+	for _, component := range componentsOf(components) {
+		if validator.done() {
+			return
+		}
+		location := "#/components/" + component.holder.section() + "/" + escapePointerToken(component.name)
+		if invalidComponentName.MatchString(component.name) {
+			validator.report(location, "component name %q must only contain letters, digits, '.', '-' and '_'", component.name)
+		}
+		switch holder := component.holder.(type) {
+		case *SchemaRef:
+			validator.validateSchemaRef(holder, location)
+		case *ResponseRef:
+			validator.validateResponseRef(holder, location)
+		case *ParameterRef:
+			validator.validateParameterRef(holder, location)
+		case *ExampleRef:
+			validator.validateExampleRef(holder, nil, location)
+		case *RequestBodyRef:
+			validator.validateRequestBodyRef(holder, location)
+		case *HeaderRef:
+			validator.validateHeaderRef(holder, location)
+		case *SecuritySchemeRef:
+			validator.validateSecuritySchemeRef(holder, location)
+		case *LinkRef:
+			validator.validateRef(holder.Ref, holder.Value != nil, location)
+		case *CallbackRef:
+			validator.validateCallbackRef(holder, location)
+		}
+	}
+}
+
+// validateRef reports a $ref whose target cannot be found. A target loaded by the Loader is resolved, otherwise
+// a local $ref is looked up in the document and a $ref to another file is unresolved.
+func (validator *validator) validateRef(ref string, resolved bool, location string) {
+	if ref == "" || resolved {
+		return
+	}
+	if !strings.HasPrefix(ref, "#") {
+		validator.report(location+"/$ref", "$ref %q to another file is not resolved, load the document with a Loader", ref)
+		return
+	}
+	if validator.document == nil {
+		data, err := json.Marshal(validator.openAPI)
+		if err != nil {
+			validator.report(location+"/$ref", "$ref %q cannot be looked up: %v", ref, err)
+			return
+		}
+		validator.document = data
+	}
+	pointer := ref[1:]
+	if unescaped, err := url.PathUnescape(pointer); err == nil {
+		pointer = unescaped
+	}
+	if _, err := lookupPointer(validator.document, pointer); err != nil {
+		validator.report(location+"/$ref", "$ref %q cannot be resolved", ref)
+	}
+}
+
+// resolveParameter returns the Parameter of parameterRef, following local refs to components.
+func (validator *validator) resolveParameter(parameterRef *ParameterRef) *Parameter {
+	for depth := 0; parameterRef != nil && depth < 64; depth++ {
+		if parameterRef.Value != nil {
+			return parameterRef.Value
+		}
+		const prefix = "#/components/parameters/"
+		if validator.openAPI.Components == nil || !strings.HasPrefix(parameterRef.Ref, prefix) {
+			return nil
+		}
+		parameterRef = validator.openAPI.Components.Parameters[unescapePointerToken(parameterRef.Ref[len(prefix):])]
+	}
+	return nil
+}
+
+func (validator *validator) validatePathItem(pathItem *PathItem, path, location string) {
+	if pathItem.Ref != "" && strings.HasPrefix(pathItem.Ref, "#") {
+		validator.validateRef(pathItem.Ref, false, location)
+	}
+	validator.validateServers(pathItem.Servers, location+"/servers")
+	shared, sharedUnresolved := validator.validateParameters(pathItem.Parameters, location+"/parameters")
+	for _, method := range []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} {
+		operation := pathItem.Operations()[strings.ToUpper(method)]
+		if operation == nil {
+			continue
+		}
+		operationLocation := location + "/" + method
+		parameters, unresolved := validator.validateParameters(operation.Parameters, operationLocation+"/parameters")
+		for key, parameter := range shared {
+			if _, ok := parameters[key]; !ok {
+				parameters[key] = parameter
+			}
+		}
+		if path != "" {
+			validator.validatePathParameters(path, parameters, unresolved || sharedUnresolved, operationLocation)
+		}
+		validator.validateOperation(operation, operationLocation)
+	}
+}
+
+// located is a value along with its location in the document.
+type located struct {
+	parameter *Parameter
+	location  string
+}
+
+// validateParameters validates a list of parameters and returns the resolved ones keyed by in and name, and
+// whether some of them could not be resolved, such as a $ref to another file the Loader did not load.
+func (validator *validator) validateParameters(parameterRefs []*ParameterRef, location string) (map[string]located, bool) {
+	unresolved := false
+	parameters := map[string]located{}
+	for i, parameterRef := range parameterRefs {
+		if parameterRef == nil {
+			continue
+		}
+		parameterLocation := fmt.Sprintf("%s/%d", location, i)
+		validator.validateParameterRef(parameterRef, parameterLocation)
+		parameter := validator.resolveParameter(parameterRef)
+		if parameter == nil {
+			unresolved = unresolved || parameterRef.Ref != ""
+			continue
+		}
+		key := parameter.In + " " + parameter.Name
+		if previous, ok := parameters[key]; ok {
+			validator.report(parameterLocation, "parameter %q in %s is already defined at %s", parameter.Name, parameter.In, previous.location)
+			continue
+		}
+		parameters[key] = located{parameter, parameterLocation}
+	}
+	return parameters, unresolved
+}
+
+// validatePathParameters checks that the template expressions of path and the path parameters of an operation
+// match each other. A template expression may be declared by an unresolved parameter, so it is only reported as
+// undeclared when all the parameters are resolved.
+func (validator *validator) validatePathParameters(path string, parameters map[string]located, unresolved bool, location string) {
+	templated := map[string]bool{}
+	for _, match := range pathTemplate.FindAllStringSubmatch(path, -1) {
+		templated[match[1]] = true
+		if _, ok := parameters["path "+match[1]]; !ok && !unresolved {
+			validator.report(location, "path parameter %q of %q is not declared", match[1], path)
+		}
+	}
+	for _, key := range sortedKeys(parameters) {
+		parameter := parameters[key]
+		if parameter.parameter.In == "path" && !templated[parameter.parameter.Name] {
+			validator.report(parameter.location, "path parameter %q does not appear in %q", parameter.parameter.Name, path)
+		}
+	}
+}
+
+func (validator *validator) validateOperation(operation *Operation, location string) {
+	if operation.OperationID != "" {
+		if previous, ok := validator.operationIDs[operation.OperationID]; ok {
+			validator.report(location+"/operationId", "operationId %q is already used at %s", operation.OperationID, previous)
+		} else {
+			validator.operationIDs[operation.OperationID] = location + "/operationId"
+		}
+	}
+	if operation.RequestBody != nil {
+		validator.validateRequestBodyRef(operation.RequestBody, location+"/requestBody")
+	}
+	if len(operation.Responses) == 0 {
+		validator.report(location+"/responses", "at least one response is required")
+	}
+	for _, code := range sortedKeys(operation.Responses) {
+		responseLocation := location + "/responses/" + escapePointerToken(code)
+		if !responseCode.MatchString(code) {
+			validator.report(responseLocation, "%q is not a valid response status code", code)
+		}
+		if responseRef := operation.Responses[code]; responseRef != nil {
+			validator.validateResponseRef(responseRef, responseLocation)
+		}
+	}
+	for _, name := range sortedKeys(operation.Callbacks) {
+		if callbackRef := operation.Callbacks[name]; callbackRef != nil {
+			validator.validateCallbackRef(callbackRef, location+"/callbacks/"+escapePointerToken(name))
+		}
+	}
+	validator.validateServers(operation.Servers, location+"/servers")
+}
+
+func (validator *validator) validateCallbackRef(callbackRef *CallbackRef, location string) {
+	if callbackRef.Ref != "" {
+		validator.validateRef(callbackRef.Ref, callbackRef.Value != nil, location)
+		return
+	}
+	if callbackRef.Value == nil {
+		return
+	}
+	for _, expression := range sortedKeys(callbackRef.Value.Paths) {
+		if pathItem := callbackRef.Value.Paths[expression]; pathItem != nil {
+			// Callback keys are runtime expressions rather than path templates.
+			validator.validatePathItem(pathItem, "", location+"/"+escapePointerToken(expression))
+		}
+	}
+}
+
+func (validator *validator) validateParameterRef(parameterRef *ParameterRef, location string) {
+	if parameterRef.Ref != "" {
+		validator.validateRef(parameterRef.Ref, parameterRef.Value != nil, location)
+		return
+	}
+	parameter := parameterRef.Value
+	if parameter == nil {
+		return
+	}
+	if parameter.Name == "" {
+		validator.report(location+"/name", "name is required")
+	}
+	if !parameterLocations[parameter.In] {
+		validator.report(location+"/in", "in must be one of query, header, path or cookie, not %q", parameter.In)
+	}
+	if parameter.In == "path" && !parameter.Required {
+		validator.report(location+"/required", "path parameter %q must be required", parameter.Name)
+	}
+	validator.validateSchemaOrContent(parameter.Schema, parameter.Content, location)
+	schema := valueSchema(parameter.Schema, parameter.Content)
+	validator.validateValue(parameter.Example, schema, location+"/example")
+	validator.validateExamples(parameter.Examples, schema, location+"/examples")
+}
+
+func (validator *validator) validateHeaderRef(headerRef *HeaderRef, location string) {
+	if headerRef.Ref != "" {
+		validator.validateRef(headerRef.Ref, headerRef.Value != nil, location)
+		return
+	}
+	if header := headerRef.Value; header != nil {
+		validator.validateSchemaOrContent(header.Schema, header.Content, location)
+		schema := valueSchema(header.Schema, header.Content)
+		validator.validateValue(header.Example, schema, location+"/example")
+		validator.validateExamples(header.Examples, schema, location+"/examples")
+	}
+}
+
+// validateSchemaOrContent checks that a parameter or header is described by either a schema or a content map
+// with a single entry.
+// http://spec.openapis.org/oas/v3.0.3#fixed-fields-10
+func (validator *validator) validateSchemaOrContent(schema *SchemaRef, content map[string]*MediaType, location string) {
+	switch {
+	case schema != nil && content != nil:
+		validator.report(location, "schema and content are mutually exclusive")
+	case schema == nil && content == nil:
+		validator.report(location, "either schema or content is required")
+	case content != nil && len(content) != 1:
+		validator.report(location+"/content", "content must contain a single media type, not %d", len(content))
+	}
+	if schema != nil {
+		validator.validateSchemaRef(schema, location+"/schema")
+	}
+	validator.validateContent(content, location+"/content")
+}
+
+func (validator *validator) validateRequestBodyRef(requestBodyRef *RequestBodyRef, location string) {
+	if requestBodyRef.Ref != "" {
+		validator.validateRef(requestBodyRef.Ref, requestBodyRef.Value != nil, location)
+		return
+	}
+	if requestBody := requestBodyRef.Value; requestBody != nil {
+		if requestBody.Content == nil {
+			validator.report(location+"/content", "content is required")
+		}
+		validator.validateContent(requestBody.Content, location+"/content")
+	}
+}
+
+func (validator *validator) validateResponseRef(responseRef *ResponseRef, location string) {
+	if responseRef.Ref != "" {
+		validator.validateRef(responseRef.Ref, responseRef.Value != nil, location)
+		return
+	}
+	response := responseRef.Value
+	if response == nil {
+		return
+	}
+	if response.Description == "" {
+		validator.report(location+"/description", "description is required")
+	}
+	for _, name := range sortedKeys(response.Headers) {
+		if headerRef := response.Headers[name]; headerRef != nil {
+			validator.validateHeaderRef(headerRef, location+"/headers/"+escapePointerToken(name))
+		}
+	}
+	validator.validateContent(response.Content, location+"/content")
+	for _, name := range sortedKeys(response.Links) {
+		if linkRef := response.Links[name]; linkRef != nil {
+			validator.validateRef(linkRef.Ref, linkRef.Value != nil, location+"/links/"+escapePointerToken(name))
+		}
+	}
+}
+
+func (validator *validator) validateContent(content map[string]*MediaType, location string) {
+	for _, name := range sortedKeys(content) {
+		mediaType, mediaTypeLocation := content[name], location+"/"+escapePointerToken(name)
+		if mediaType == nil {
+			continue
+		}
+		if mediaType.Schema != nil {
+			validator.validateSchemaRef(mediaType.Schema, mediaTypeLocation+"/schema")
+		}
+		validator.validateValue(mediaType.Example, mediaType.Schema, mediaTypeLocation+"/example")
+		validator.validateExamples(mediaType.Examples, mediaType.Schema, mediaTypeLocation+"/examples")
+		for _, encodingName := range sortedKeys(mediaType.Encoding) {
+			if encoding := mediaType.Encoding[encodingName]; encoding != nil {
+				for _, headerName := range sortedKeys(encoding.Headers) {
+					if headerRef := encoding.Headers[headerName]; headerRef != nil {
+						validator.validateHeaderRef(headerRef, mediaTypeLocation+"/encoding/"+escapePointerToken(encodingName)+"/headers/"+escapePointerToken(headerName))
+					}
+				}
+			}
+		}
+	}
+}
+
+func (validator *validator) validateExamples(examples map[string]*ExampleRef, schema *SchemaRef, location string) {
+	for _, name := range sortedKeys(examples) {
+		if exampleRef := examples[name]; exampleRef != nil {
+			validator.validateExampleRef(exampleRef, schema, location+"/"+escapePointerToken(name))
+		}
+	}
+}
+
+// validateExampleRef checks an example of the values of schema, nil for the examples of the components.
+func (validator *validator) validateExampleRef(exampleRef *ExampleRef, schema *SchemaRef, location string) {
+	if exampleRef.Ref != "" {
+		validator.validateRef(exampleRef.Ref, exampleRef.Value != nil, location)
+		return
+	}
+	example := exampleRef.Value
+	if example == nil {
+		return
+	}
+	if example.Value != nil && example.ExternalValue != "" {
+		validator.report(location, "value and externalValue are mutually exclusive")
+	}
+	validator.validateValue(example.Value, schema, location+"/value")
+}
+
+// valueSchema returns the schema of the values of a parameter or a header, described by a schema or a single media
+// type.
+func valueSchema(schema *SchemaRef, content map[string]*MediaType) *SchemaRef {
+	if schema != nil {
+		return schema
+	}
+	for _, mediaType := range content {
+		if mediaType != nil && len(content) == 1 {
+			return mediaType.Schema
+		}
+	}
+	return nil
+}
+
+// validateValue checks that a default or example value is JSON matching schemaRef, when it is not nil. The refs which
+// cannot be resolved are reported by validateRef.
+func (validator *validator) validateValue(value json.RawMessage, schemaRef *SchemaRef, location string) {
+	if len(value) == 0 {
+		return
+	}
+	decoded, err := jsonValue(value)
+	if err != nil {
+		validator.report(location, "value is not valid JSON")
+		return
+	}
+	if schemaRef == nil {
+		return
+	}
+	if validator.values == nil {
+		validator.values = &validatorCompiler{components: validator.openAPI.Components, validators: map[*Schema]*valueValidator{}}
+	}
+	validation := &valueValidation{ignoreUnresolved: true}
+	validator.values.compileRef(schemaRef).validate(validation, decoded, "")
+	for _, valueError := range validation.errors {
+		validator.report(location+valueError.Pointer, "%s", valueError.Message)
+	}
+}
+
+func (validator *validator) validateSecuritySchemeRef(securitySchemeRef *SecuritySchemeRef, location string) {
+	if securitySchemeRef.Ref != "" {
+		validator.validateRef(securitySchemeRef.Ref, securitySchemeRef.Value != nil, location)
+		return
+	}
+	securityScheme := securitySchemeRef.Value
+	if securityScheme == nil {
+		return
+	}
+	switch securityScheme.Type {
+	case "apiKey":
+		if securityScheme.Name == "" {
+			validator.report(location+"/name", "name is required")
+		}
+		if securityScheme.In != "query" && securityScheme.In != "header" && securityScheme.In != "cookie" {
+			validator.report(location+"/in", "in must be one of query, header or cookie, not %q", securityScheme.In)
+		}
+	case "http":
+		if securityScheme.Scheme == "" {
+			validator.report(location+"/scheme", "scheme is required")
+		}
+	case "oauth2":
+		if securityScheme.Flows == nil {
+			validator.report(location+"/flows", "flows is required")
+		}
+	case "openIdConnect":
+		if securityScheme.OpenIDConnectURL == "" {
+			validator.report(location+"/openIdConnectUrl", "openIdConnectUrl is required")
+		}
+	default:
+		validator.report(location+"/type", "type must be one of apiKey, http, oauth2 or openIdConnect, not %q", securityScheme.Type)
+	}
+}
+
+func (validator *validator) validateSchemaRef(schemaRef *SchemaRef, location string) {
+	if schemaRef.Ref != "" {
+		validator.validateRef(schemaRef.Ref, schemaRef.Value != nil, location)
+		return
+	}
+	schema := schemaRef.Value
+	if schema == nil || validator.schemas[schema] {
+		return
+	}
+	validator.schemas[schema] = true
+	for _, keyword := range []struct {
+		name    string
+		schemas []*SchemaRef
+	}{{"allOf", schema.AllOf}, {"oneOf", schema.OneOf}, {"anyOf", schema.AnyOf}} {
+		for i, item := range keyword.schemas {
+			if item != nil {
+				validator.validateSchemaRef(item, fmt.Sprintf("%s/%s/%d", location, keyword.name, i))
+			}
+		}
+	}
+	if schema.Not != nil {
+		validator.validateSchemaRef(schema.Not, location+"/not")
+	}
+	if schema.Items != nil {
+		validator.validateSchemaRef(schema.Items, location+"/items")
+	} else if schema.Type == "array" {
+		validator.report(location+"/items", "items is required when type is array")
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if property := schema.Properties[name]; property != nil {
+			validator.validateSchemaRef(property, location+"/properties/"+escapePointerToken(name))
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		validator.validateSchemaRef(schema.AdditionalProperties.Schema, location+"/additionalProperties")
+	}
+	if schema.Discriminator != nil && schema.Discriminator.PropertyName == "" {
+		validator.report(location+"/discriminator/propertyName", "propertyName is required")
+	}
+	for _, keyword := range []struct {
+		name   string
+		number json.Number
+	}{{"multipleOf", schema.MultipleOf}, {"maximum", schema.Maximum}, {"minimum", schema.Minimum}} {
+		if keyword.number == "" {
+			continue
+		}
+		if number, ok := toRat(keyword.number); !ok {
+			validator.report(location+"/"+keyword.name, "%s must be a number, not %q", keyword.name, keyword.number)
+		} else if keyword.name == "multipleOf" && number.Sign() <= 0 {
+			validator.report(location+"/multipleOf", "multipleOf must be greater than 0")
+		}
+	}
+	for _, keyword := range []struct {
+		name  string
+		bound *int
+	}{{"maxLength", schema.MaxLength}, {"minLength", schema.MinLength}, {"maxItems", schema.MaxItems},
+		{"minItems", schema.MinItems}, {"maxProperties", schema.MaxProperties}, {"minProperties", schema.MinProperties}} {
+		if keyword.bound != nil && *keyword.bound < 0 {
+			validator.report(location+"/"+keyword.name, "%s must not be negative", keyword.name)
+		}
+	}
+	if schema.Pattern != "" {
+		if _, err := compilePattern(schema.Pattern); err != nil {
+			validator.report(location+"/pattern", "%v", err)
+		}
+	}
+	for i, value := range schema.Enum {
+		if !json.Valid(value) {
+			validator.report(fmt.Sprintf("%s/enum/%d", location, i), "value is not valid JSON")
+		}
+	}
+	validator.validateValue(schema.Default, schemaRef, location+"/default")
+	validator.validateValue(schema.Example, schemaRef, location+"/example")
+}
@@ -0,0 +1,102 @@
+package v303
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	const ok = `"responses": {"200": {"description": "OK"}}`
+	tests := []struct {
+		name  string
+		paths string
+		want  []ValidationError
+	}{
+		{
+			"valid",
+			`{"/pets/{id}": {"get": {"parameters": [{"$ref": "#/components/parameters/id"}], ` + ok + `}}}`,
+			nil,
+		},
+		{
+			"undeclared path parameter",
+			`{"/pets/{id}": {"get": {` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets~1{id}/get", `path parameter "id" of "/pets/{id}" is not declared`}},
+		},
+		{
+			"path parameter missing from the path",
+			`{"/pets": {"get": {"parameters": [{"$ref": "#/components/parameters/id"}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets/get/parameters/0", `path parameter "id" does not appear in "/pets"`}},
+		},
+		{
+			"path parameter of the path item",
+			`{"/pets/{id}": {"parameters": [{"$ref": "#/components/parameters/id"}], "get": {` + ok + `}}}`,
+			nil,
+		},
+		{
+			"path parameter of another file",
+			`{"/pets/{id}": {"get": {"parameters": [{"$ref": "parameters.yaml#/id"}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets~1{id}/get/parameters/0/$ref", `$ref "parameters.yaml#/id" to another file is not resolved, load the document with a Loader`}},
+		},
+		{
+			"unresolved local parameter",
+			`{"/pets/{id}": {"get": {"parameters": [{"$ref": "#/components/parameters/missing"}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets~1{id}/get/parameters/0/$ref", `$ref "#/components/parameters/missing" cannot be resolved`}},
+		},
+		{
+			"optional path parameter",
+			`{"/pets/{id}": {"get": {"parameters": [{"name": "id", "in": "path", "schema": {"type": "string"}}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets~1{id}/get/parameters/0/required", `path parameter "id" must be required`}},
+		},
+		{
+			"duplicate parameter",
+			`{"/pets": {"get": {"parameters": [{"$ref": "#/components/parameters/limit"}, {"name": "limit", "in": "query", "schema": {"type": "integer"}}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets/get/parameters/1", `parameter "limit" in query is already defined at #/paths/~1pets/get/parameters/0`}},
+		},
+		{
+			"parameter location",
+			`{"/pets": {"get": {"parameters": [{"name": "limit", "in": "body", "schema": {"type": "integer"}}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets/get/parameters/0/in", `in must be one of query, header, path or cookie, not "body"`}},
+		},
+		{
+			"parameter example",
+			`{"/pets": {"get": {"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}, "example": "ten"}], ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets/get/parameters/0/example", `must be of type integer`}},
+		},
+		{
+			"duplicate operationId",
+			`{"/pets": {"get": {"operationId": "pets", ` + ok + `}, "post": {"operationId": "pets", ` + ok + `}}}`,
+			[]ValidationError{{"#/paths/~1pets/post/operationId", `operationId "pets" is already used at #/paths/~1pets/get/operationId`}},
+		},
+		{
+			"responses",
+			`{"/pets": {"get": {"responses": {}}, "post": {"responses": {"20": {"description": "OK"}, "201": {}}}}}`,
+			[]ValidationError{
+				{"#/paths/~1pets/get/responses", `at least one response is required`},
+				{"#/paths/~1pets/post/responses/20", `"20" is not a valid response status code`},
+				{"#/paths/~1pets/post/responses/201/description", `description is required`},
+			},
+		},
+		{
+			"relative path",
+			`{"pets": {"get": {` + ok + `}}}`,
+			[]ValidationError{{"#/paths/pets", `path "pets" must begin with a slash`}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var openAPI OpenAPI
+			document := `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1.0"}, "paths": ` + test.paths + `,
+				"components": {"parameters": {
+					"id": {"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}},
+					"limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}}}}`
+			if err := json.Unmarshal([]byte(document), &openAPI); err != nil {
+				t.Fatal(err)
+			}
+			if got := openAPI.Validate(context.Background()); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Validate() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,697 @@
+package v303
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValueError is a violation of a schema by a value, located by a JSON pointer into the value.
+type ValueError struct {
+	Pointer string
+	Message string
+}
+
+func (valueError ValueError) Error() string {
+	if valueError.Pointer == "" {
+		return valueError.Message
+	}
+	return valueError.Pointer + ": " + valueError.Message
+}
+
+// ValueErrors are the violations of a schema by a value, returned by Schema.ValidateValue and
+// ValueValidator.ValidateValue.
+type ValueErrors []ValueError
+
+func (valueErrors ValueErrors) Error() string {
+	messages := make([]string, len(valueErrors))
+	for i, valueError := range valueErrors {
+		messages[i] = valueError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValueOption configures Schema.ValidateValue and ValueValidator.ValidateValue.
+type ValueOption func(options *valueOptions)
+
+type valueOptions struct {
+	direction  direction
+	components *Components
+}
+
+// direction is the direction a value is sent in, which readOnly and writeOnly properties depend on.
+type direction int
+
+const (
+	anyDirection direction = iota
+	requestDirection
+	responseDirection
+)
+
+// InRequest validates a value sent in a request, where readOnly properties are not required and must not be sent.
+func InRequest() ValueOption {
+	return func(options *valueOptions) { options.direction = requestDirection }
+}
+
+// InResponse validates a value sent in a response, where writeOnly properties are not required and must not be sent.
+func InResponse() ValueOption {
+	return func(options *valueOptions) { options.direction = responseDirection }
+}
+
+// WithComponents resolves the local refs to schemas whose Value is not set against the schemas of components, such
+// as those of a document built in code rather than read by a Loader. A ValueValidator uses the components it was
+// compiled with instead.
+func WithComponents(components *Components) ValueOption {
+	return func(options *valueOptions) { options.components = components }
+}
+
+// ValidateValue checks v against the schema and returns the ValueErrors of its violations, nil when it is valid.
+//
+// v is a value decoded from JSON, preferably with json.Decoder.UseNumber so that numbers are compared exactly, and
+// other Go values are checked as their JSON encoding. Every keyword of Schema is evaluated: type, format, enum,
+// multipleOf, minimum and maximum, minLength, maxLength, pattern, minItems, maxItems, uniqueItems, required,
+// properties, additionalProperties, allOf, oneOf, anyOf, not and nullable, as well as readOnly and writeOnly with the
+// InRequest and InResponse options. A oneOf or anyOf with a discriminator only checks an object against the schema
+// its discriminator property selects, through its mapping or the component name of the schema, other values and
+// objects without the property being checked against every schema of oneOf or anyOf.
+// http://spec.openapis.org/oas/v3.0.3#properties
+//
+// The schema is compiled, with the regular expressions and the bounds of the schemas it uses, on each call: Compile
+// returns a ValueValidator which compiles it once to validate many values. The error of a schema which cannot be
+// compiled is returned instead of ValueErrors.
+func (schema *Schema) ValidateValue(v interface{}, options ...ValueOption) error {
+	valueOptions := valueOptions{}
+	for _, option := range options {
+		option(&valueOptions)
+	}
+	validator, err := schema.Compile(valueOptions.components)
+	if err != nil {
+		return err
+	}
+	return validator.ValidateValue(v, options...)
+}
+
+// ValueValidator is a compiled schema, which validates values as Schema.ValidateValue does. It is safe for
+// concurrent use, and the schema must not be modified while it is in use.
+type ValueValidator struct {
+	validator *valueValidator
+}
+
+// Compile returns the ValueValidator of the schema, resolving the local refs to schemas whose Value is not set
+// against the schemas of components, which may be nil. It returns an error when the schema or one it uses has a
+// pattern the regexp package cannot compile.
+func (schema *Schema) Compile(components *Components) (*ValueValidator, error) {
+	compiler := &validatorCompiler{components: components, validators: map[*Schema]*valueValidator{}}
+	validator := compiler.compile(schema)
+	if compiler.err != nil {
+		return nil, compiler.err
+	}
+	return &ValueValidator{validator: validator}, nil
+}
+
+// ValidateValue checks v against the compiled schema and returns the ValueErrors of its violations, nil when it is
+// valid.
+func (valueValidator *ValueValidator) ValidateValue(v interface{}, options ...ValueOption) error {
+	valueOptions := valueOptions{}
+	for _, option := range options {
+		option(&valueOptions)
+	}
+	validation := &valueValidation{direction: valueOptions.direction}
+	valueValidator.validator.validate(validation, v, "")
+	if len(validation.errors) > 0 {
+		return validation.errors
+	}
+	return nil
+}
+
+// valueValidation holds the state of a validation.
+type valueValidation struct {
+	direction direction
+	// ignoreUnresolved skips the schemas which cannot be resolved instead of reporting them.
+	ignoreUnresolved bool
+	errors           ValueErrors
+}
+
+func (validation *valueValidation) errorf(pointer, format string, args ...interface{}) {
+	validation.errors = append(validation.errors, ValueError{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+}
+
+// valueValidator is a compiled schema.
+type valueValidator struct {
+	schema *Schema
+	// unresolved is the ref of a schema which cannot be resolved.
+	unresolved           string
+	pattern              *regexp.Regexp
+	minimum, maximum     *big.Rat
+	multipleOf           *big.Rat
+	enum                 []interface{}
+	items                *valueValidator
+	properties           map[string]*valueValidator
+	additionalProperties *valueValidator
+	allOf, oneOf, anyOf  []*valueValidator
+	not                  *valueValidator
+	// discriminated are the schemas of oneOf or anyOf selected by the values of the discriminator property.
+	discriminated map[string]*valueValidator
+}
+
+var (
+	// jsonNumber matches the numbers of the JSON grammar.
+	// https://tools.ietf.org/html/rfc8259#section-6
+	jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+	// uuidPattern matches the uuid format.
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// validatorCompiler compiles a schema and the schemas it uses.
+type validatorCompiler struct {
+	components *Components
+	// validators are the validators compiled, which recursive schemas refer to before they are complete.
+	validators map[*Schema]*valueValidator
+	// err is the first error of the schemas compiled.
+	err error
+}
+
+func (compiler *validatorCompiler) compile(schema *Schema) *valueValidator {
+	if validator, ok := compiler.validators[schema]; ok {
+		return validator
+	}
+	validator := &valueValidator{schema: schema}
+	compiler.validators[schema] = validator
+	if schema.Pattern != "" {
+		var err error
+		if validator.pattern, err = compilePattern(schema.Pattern); err != nil && compiler.err == nil {
+			compiler.err = err
+		}
+	}
+	// Numbers which are not valid are reported by OpenAPI.Validate and not checked.
+	validator.minimum, _ = toRat(schema.Minimum)
+	validator.maximum, _ = toRat(schema.Maximum)
+	if multipleOf, ok := toRat(schema.MultipleOf); ok && multipleOf.Sign() > 0 {
+		validator.multipleOf = multipleOf
+	}
+	for _, raw := range schema.Enum {
+		// Values which are not valid JSON are reported by OpenAPI.Validate and never match.
+		if value, err := jsonValue(raw); err == nil {
+			validator.enum = append(validator.enum, normalizeValue(value))
+		}
+	}
+	validator.items = compiler.compileRef(schema.Items)
+	validator.properties = compiler.compileRefs(schema.Properties)
+	if schema.AdditionalProperties != nil {
+		validator.additionalProperties = compiler.compileRef(schema.AdditionalProperties.Schema)
+	}
+	for _, allOf := range schema.AllOf {
+		validator.allOf = append(validator.allOf, compiler.compileRef(allOf))
+	}
+	for _, oneOf := range schema.OneOf {
+		validator.oneOf = append(validator.oneOf, compiler.compileRef(oneOf))
+	}
+	for _, anyOf := range schema.AnyOf {
+		validator.anyOf = append(validator.anyOf, compiler.compileRef(anyOf))
+	}
+	validator.not = compiler.compileRef(schema.Not)
+	if schema.Discriminator != nil {
+		validator.discriminated = compiler.discriminated(schema)
+	}
+	return validator
+}
+
+// compilePattern compiles the regular expression of a pattern keyword. The regexp package supports the syntax of
+// RE2, and not the lookarounds nor the backreferences of ECMA 262.
+// http://spec.openapis.org/oas/v3.0.3#properties
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("the pattern %q cannot be compiled: %v", pattern, err)
+	}
+	return compiled, nil
+}
+
+// compileRef returns the validator of the schema of schemaRef, nil when schemaRef is nil.
+func (compiler *validatorCompiler) compileRef(schemaRef *SchemaRef) *valueValidator {
+	for depth := 0; schemaRef != nil && depth < 64; depth++ {
+		if schemaRef.Value != nil {
+			return compiler.compile(schemaRef.Value)
+		}
+		const prefix = "#/components/schemas/"
+		if compiler.components == nil || !strings.HasPrefix(schemaRef.Ref, prefix) {
+			break
+		}
+		next := compiler.components.Schema[unescapePointerToken(schemaRef.Ref[len(prefix):])]
+		if next == nil {
+			break
+		}
+		schemaRef = next
+	}
+	if schemaRef == nil {
+		return nil
+	}
+	return &valueValidator{unresolved: schemaRef.Ref}
+}
+
+func (compiler *validatorCompiler) compileRefs(schemaRefs map[string]*SchemaRef) map[string]*valueValidator {
+	if len(schemaRefs) == 0 {
+		return nil
+	}
+	validators := make(map[string]*valueValidator, len(schemaRefs))
+	for name, schemaRef := range schemaRefs {
+		if validator := compiler.compileRef(schemaRef); validator != nil {
+			validators[name] = validator
+		}
+	}
+	return validators
+}
+
+// discriminated returns the schemas of the oneOf or anyOf of schema selected by the values of its discriminator
+// property: the values of its mapping, then the component names of the schemas it refers to.
+// http://spec.openapis.org/oas/v3.0.3#discriminator-object
+func (compiler *validatorCompiler) discriminated(schema *Schema) map[string]*valueValidator {
+	members := schema.OneOf
+	if len(members) == 0 {
+		members = schema.AnyOf
+	}
+	discriminated := map[string]*valueValidator{}
+	for _, member := range members {
+		if member == nil || member.Ref == "" {
+			continue
+		}
+		name := unescapePointerToken(member.Ref[strings.LastIndex(member.Ref, "/")+1:])
+		discriminated[name] = compiler.compileRef(member)
+	}
+	for value, target := range schema.Discriminator.Mapping {
+		for _, member := range members {
+			if member != nil && member.Ref != "" && (member.Ref == target || strings.HasSuffix(member.Ref, "/"+target)) {
+				discriminated[value] = compiler.compileRef(member)
+			}
+		}
+	}
+	if len(discriminated) == 0 {
+		return nil
+	}
+	return discriminated
+}
+
+// validate reports the violations of validator by value, located at pointer.
+func (validator *valueValidator) validate(validation *valueValidation, value interface{}, pointer string) {
+	if validator == nil {
+		return
+	}
+	if validator.unresolved != "" {
+		if !validation.ignoreUnresolved {
+			validation.errorf(pointer, "$ref %q is not resolved", validator.unresolved)
+		}
+		return
+	}
+	value, err := jsonValue(value)
+	if err != nil {
+		validation.errorf(pointer, "has no JSON encoding: %v", err)
+		return
+	}
+	schema := validator.schema
+	if value == nil {
+		if schema.Nullable {
+			return
+		}
+		if schema.Type != "" {
+			validation.errorf(pointer, "must not be null")
+			return
+		}
+	}
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		validation.errorf(pointer, "must be of type %s", schema.Type)
+		return
+	}
+	if len(validator.enum) > 0 && !validator.inEnum(value) {
+		validation.errorf(pointer, "must be one of the values of the enum")
+	}
+	switch typed := value.(type) {
+	case string:
+		validator.validateString(validation, typed, pointer)
+	case []interface{}:
+		validator.validateArray(validation, typed, pointer)
+	case map[string]interface{}:
+		validator.validateObject(validation, typed, pointer)
+	default:
+		if number, ok := toRat(value); ok {
+			validator.validateNumber(validation, number, pointer)
+		}
+	}
+	for _, allOf := range validator.allOf {
+		allOf.validate(validation, value, pointer)
+	}
+	if !validator.validateDiscriminated(validation, value, pointer) {
+		validator.validateAnyOf(validation, value, pointer)
+		validator.validateOneOf(validation, value, pointer)
+	}
+	if validator.not != nil && validator.not.unresolved != "" {
+		validator.not.validate(validation, value, pointer)
+	} else if validator.not != nil && validator.not.matches(validation, value) {
+		validation.errorf(pointer, "must not match the schema of not")
+	}
+}
+
+// matches reports whether value is valid against validator, without reporting its violations.
+func (validator *valueValidator) matches(validation *valueValidation, value interface{}) bool {
+	inner := &valueValidation{direction: validation.direction, ignoreUnresolved: validation.ignoreUnresolved}
+	validator.validate(inner, value, "")
+	return len(inner.errors) == 0
+}
+
+func (validator *valueValidator) validateAnyOf(validation *valueValidation, value interface{}, pointer string) {
+	if len(validator.anyOf) == 0 {
+		return
+	}
+	for _, anyOf := range validator.anyOf {
+		if anyOf.matches(validation, value) {
+			return
+		}
+	}
+	validation.errorf(pointer, "must match at least one schema of anyOf")
+}
+
+func (validator *valueValidator) validateOneOf(validation *valueValidation, value interface{}, pointer string) {
+	if len(validator.oneOf) == 0 {
+		return
+	}
+	matched := 0
+	for _, oneOf := range validator.oneOf {
+		if oneOf.matches(validation, value) {
+			matched++
+		}
+	}
+	if matched != 1 {
+		validation.errorf(pointer, "must match exactly one schema of oneOf, matches %d", matched)
+	}
+}
+
+// validateDiscriminated checks an object against the schema selected by its discriminator property, reporting
+// whether it did. Values which are not objects or lack the discriminator property are left to oneOf and anyOf.
+func (validator *valueValidator) validateDiscriminated(validation *valueValidation, value interface{}, pointer string) bool {
+	if validator.discriminated == nil {
+		return false
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	propertyName := validator.schema.Discriminator.PropertyName
+	property, ok := object[propertyName].(string)
+	if !ok {
+		return false
+	}
+	selected, ok := validator.discriminated[property]
+	if !ok {
+		validation.errorf(pointer+"/"+escapePointerToken(propertyName), "%q does not select a schema", property)
+		return true
+	}
+	selected.validate(validation, value, pointer)
+	return true
+}
+
+// hasType reports whether value is of the JSON type of a schema.
+func hasType(value interface{}, schemaType string) bool {
+	switch value.(type) {
+	case string:
+		return schemaType == "string"
+	case bool:
+		return schemaType == "boolean"
+	case []interface{}:
+		return schemaType == "array"
+	case map[string]interface{}:
+		return schemaType == "object"
+	}
+	if number, ok := toRat(value); ok {
+		return schemaType == "number" || schemaType == "integer" && number.IsInt()
+	}
+	return false
+}
+
+func (validator *valueValidator) inEnum(value interface{}) bool {
+	normalized := normalizeValue(value)
+	for _, member := range validator.enum {
+		if reflect.DeepEqual(member, normalized) {
+			return true
+		}
+	}
+	return false
+}
+
+func (validator *valueValidator) validateString(validation *valueValidation, value, pointer string) {
+	schema := validator.schema
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		validation.errorf(pointer, "length must be at least %d", *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		validation.errorf(pointer, "length must be at most %d", *schema.MaxLength)
+	}
+	if validator.pattern != nil && !validator.pattern.MatchString(value) {
+		validation.errorf(pointer, "must match %s", schema.Pattern)
+	}
+	if !hasFormat(value, schema.Format) {
+		validation.errorf(pointer, "must be a valid %s", schema.Format)
+	}
+}
+
+// hasFormat reports whether value has format, unknown formats being accepted.
+// http://spec.openapis.org/oas/v3.0.3#data-types
+func hasFormat(value, format string) bool {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	case "byte":
+		_, err = base64.StdEncoding.DecodeString(value)
+	case "email":
+		_, err = mail.ParseAddress(value)
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() == nil
+	case "uri":
+		var parsed *url.URL
+		parsed, err = url.Parse(value)
+		return err == nil && parsed.IsAbs()
+	}
+	return err == nil
+}
+
+func (validator *valueValidator) validateNumber(validation *valueValidation, number *big.Rat, pointer string) {
+	schema := validator.schema
+	if validator.minimum != nil {
+		cmp := number.Cmp(validator.minimum)
+		if schema.ExclusiveMinimum && cmp <= 0 {
+			validation.errorf(pointer, "must be greater than %s", schema.Minimum)
+		} else if cmp < 0 {
+			validation.errorf(pointer, "must be at least %s", schema.Minimum)
+		}
+	}
+	if validator.maximum != nil {
+		cmp := number.Cmp(validator.maximum)
+		if schema.ExclusiveMaximum && cmp >= 0 {
+			validation.errorf(pointer, "must be less than %s", schema.Maximum)
+		} else if cmp > 0 {
+			validation.errorf(pointer, "must be at most %s", schema.Maximum)
+		}
+	}
+	if validator.multipleOf != nil && !new(big.Rat).Quo(number, validator.multipleOf).IsInt() {
+		validation.errorf(pointer, "must be a multiple of %s", schema.MultipleOf)
+	}
+}
+
+func (validator *valueValidator) validateArray(validation *valueValidation, value []interface{}, pointer string) {
+	schema := validator.schema
+	if schema.MinItems != nil && len(value) < *schema.MinItems {
+		validation.errorf(pointer, "must have at least %d items", *schema.MinItems)
+	}
+	if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+		validation.errorf(pointer, "must have at most %d items", *schema.MaxItems)
+	}
+	if schema.UniqueItems {
+		normalized := make([]interface{}, len(value))
+		for i, element := range value {
+			normalized[i] = normalizeValue(element)
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(normalized[i], normalized[j]) {
+					validation.errorf(fmt.Sprintf("%s/%d", pointer, i), "must be unique, equals item %d", j)
+					break
+				}
+			}
+		}
+	}
+	if validator.items != nil {
+		for i, element := range value {
+			validator.items.validate(validation, element, fmt.Sprintf("%s/%d", pointer, i))
+		}
+	}
+}
+
+func (validator *valueValidator) validateObject(validation *valueValidation, value map[string]interface{}, pointer string) {
+	schema := validator.schema
+	if schema.MinProperties != nil && len(value) < *schema.MinProperties {
+		validation.errorf(pointer, "must have at least %d properties", *schema.MinProperties)
+	}
+	if schema.MaxProperties != nil && len(value) > *schema.MaxProperties {
+		validation.errorf(pointer, "must have at most %d properties", *schema.MaxProperties)
+	}
+	for _, name := range schema.Required {
+		if _, ok := value[name]; ok {
+			continue
+		}
+		// readOnly properties are only required in responses, writeOnly ones in requests.
+		// http://spec.openapis.org/oas/v3.0.3#fixed-fields-19
+		if validation.hidden(validator.properties[name]) != "" {
+			continue
+		}
+		validation.errorf(pointer+"/"+escapePointerToken(name), "is required")
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propertyPointer := pointer + "/" + escapePointerToken(name)
+		property, ok := validator.properties[name]
+		if !ok {
+			if schema.AdditionalProperties.Forbidden() {
+				validation.errorf(propertyPointer, "is not allowed")
+				continue
+			}
+			if property = validator.additionalProperties; property == nil {
+				continue
+			}
+		}
+		if hidden := validation.hidden(property); hidden != "" {
+			validation.errorf(propertyPointer, "is %s", hidden)
+			continue
+		}
+		property.validate(validation, value[name], propertyPointer)
+	}
+}
+
+// hidden returns "read only" or "write only" when a property is not sent in the direction of the validation.
+func (validation *valueValidation) hidden(property *valueValidator) string {
+	switch {
+	case property == nil || property.schema == nil:
+		return ""
+	case validation.direction == requestDirection && property.schema.ReadOnly:
+		return "read only"
+	case validation.direction == responseDirection && property.schema.WriteOnly:
+		return "write only"
+	}
+	return ""
+}
+
+// jsonValue returns value as decoded from JSON: values which are not of the types of encoding/json, other than
+// numbers, are replaced by the decoding of their JSON encoding.
+func jsonValue(value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case nil, bool, string, []interface{}, map[string]interface{}:
+		return value, nil
+	}
+	if _, ok := toRat(value); ok {
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// toRat returns the exact value of a number.
+func toRat(value interface{}) (*big.Rat, bool) {
+	switch number := value.(type) {
+	case json.Number:
+		// big.Rat also parses fractions and hexadecimal numbers, which are not JSON numbers, and expands exponents,
+		// which are limited to 4 digits.
+		match := jsonNumber.FindStringSubmatch(string(number))
+		if match == nil || len(strings.TrimLeft(match[3], "eE+-0")) > 4 {
+			return nil, false
+		}
+		return new(big.Rat).SetString(string(number))
+	case float64:
+		// Floats are taken as the shortest decimal which parses to them, so that 0.29 is a multiple of 0.01.
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(number, 'g', -1, 64))
+	case float32:
+		if math.IsNaN(float64(number)) || math.IsInf(float64(number), 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(float64(number), 'g', -1, 32))
+	case int:
+		return big.NewRat(int64(number), 1), true
+	case int8:
+		return big.NewRat(int64(number), 1), true
+	case int16:
+		return big.NewRat(int64(number), 1), true
+	case int32:
+		return big.NewRat(int64(number), 1), true
+	case int64:
+		return big.NewRat(number, 1), true
+	case uint:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(number))), true
+	case uint8:
+		return big.NewRat(int64(number), 1), true
+	case uint16:
+		return big.NewRat(int64(number), 1), true
+	case uint32:
+		return big.NewRat(int64(number), 1), true
+	case uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(number)), true
+	}
+	return nil, false
+}
+
+// ratKey is a number normalized by normalizeValue, a type of its own so that it never equals a string.
+type ratKey string
+
+// normalizeValue returns value with its numbers as exact rationals, so that equal numbers of different Go types or
+// notations compare equal.
+func normalizeValue(value interface{}) interface{} {
+	if number, ok := toRat(value); ok {
+		return ratKey(number.RatString())
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		normalized := make([]interface{}, len(value))
+		for i, element := range value {
+			normalized[i] = normalizeValue(element)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(value))
+		for key, element := range value {
+			normalized[key] = normalizeValue(element)
+		}
+		return normalized
+	}
+	return value
+}
@@ -0,0 +1,192 @@
+package v303
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// decodeValue decodes data as the middleware does, with its numbers as json.Number.
+func decodeValue(t *testing.T, data string) interface{} {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestValidateValueNumbersAreNotStrings(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *Schema
+		value  string
+		valid  bool
+	}{
+		{"enum string rejects number", &Schema{Enum: []json.RawMessage{json.RawMessage(`"1"`)}}, `1`, false},
+		{"enum string accepts string", &Schema{Enum: []json.RawMessage{json.RawMessage(`"1"`)}}, `"1"`, true},
+		{"enum number rejects string", &Schema{Enum: []json.RawMessage{json.RawMessage(`1`)}}, `"1"`, false},
+		{"enum number accepts other notation", &Schema{Enum: []json.RawMessage{json.RawMessage(`1`)}}, `1.0`, true},
+		{"uniqueItems accepts string and number", &Schema{UniqueItems: true}, `["1", 1]`, true},
+		{"uniqueItems rejects equal numbers", &Schema{UniqueItems: true}, `[1, 1.0]`, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.schema.ValidateValue(decodeValue(t, test.value))
+			if valid := err == nil; valid != test.valid {
+				t.Errorf("ValidateValue(%s) = %v, want valid %v", test.value, err, test.valid)
+			}
+		})
+	}
+}
+
+func TestValidateValueZeroBounds(t *testing.T) {
+	zero := 0
+	tests := []struct {
+		schema *Schema
+		value  string
+		want   string
+	}{
+		{&Schema{Type: "string", MaxLength: &zero}, `""`, ""},
+		{&Schema{Type: "string", MaxLength: &zero}, `"a"`, "length must be at most 0"},
+		{&Schema{Type: "array", Items: &SchemaRef{Value: &Schema{}}, MaxItems: &zero}, `[1]`, "must have at most 0 items"},
+		{&Schema{Type: "object", MaxProperties: &zero}, `{"a": 1}`, "must have at most 0 properties"},
+		{&Schema{Type: "object", MinProperties: &zero}, `{}`, ""},
+	}
+	for _, test := range tests {
+		err := test.schema.ValidateValue(decodeValue(t, test.value))
+		if got := errorMessage(err); got != test.want {
+			t.Errorf("ValidateValue(%s) = %q, want %q", test.value, got, test.want)
+		}
+	}
+
+	negative := -1
+	openAPI := &OpenAPI{OpenAPI: "3.0.3", Info: &Info{Title: "Bounds", Version: "1.0"}, Paths: map[string]*PathItem{},
+		Components: &Components{Schema: map[string]*SchemaRef{"Name": {Value: &Schema{Type: "string", MinLength: &negative}}}}}
+	want := []ValidationError{{Location: "#/components/schemas/Name/minLength", Message: "minLength must not be negative"}}
+	if got := openAPI.Validate(context.Background()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+}
+
+// errorMessage returns the message of the single ValueError of err, empty when err is nil.
+func errorMessage(err error) string {
+	if valueErrors, ok := err.(ValueErrors); ok && len(valueErrors) == 1 {
+		return valueErrors[0].Message
+	} else if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func TestCompile(t *testing.T) {
+	components := &Components{Schema: map[string]*SchemaRef{
+		"Pet": {Value: &Schema{Type: "object", Required: []string{"name"}}},
+	}}
+	schema := &Schema{Type: "array", Items: &SchemaRef{Ref: "#/components/schemas/Pet"}}
+	validator, err := schema.Compile(components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := validator.ValidateValue(decodeValue(t, `[{"name": "rex"}]`)); err != nil {
+		t.Errorf("ValidateValue = %v, want valid", err)
+	}
+	if err := validator.ValidateValue(decodeValue(t, `[{}]`)); err == nil {
+		t.Error("ValidateValue accepts a pet without a name")
+	}
+
+	// Schemas are not cached: a schema modified after a validation is compiled again.
+	numbers := &Schema{Type: "array", Items: &SchemaRef{Value: &Schema{Type: "integer"}}}
+	if err := numbers.ValidateValue(decodeValue(t, `[1, 2]`)); err != nil {
+		t.Errorf("ValidateValue = %v, want valid", err)
+	}
+	maxItems := 1
+	numbers.MaxItems = &maxItems
+	if err := numbers.ValidateValue(decodeValue(t, `[1, 2]`)); err == nil {
+		t.Error("ValidateValue ignores the maxItems set after a previous validation")
+	}
+}
+
+func TestCompileInvalidPattern(t *testing.T) {
+	// The lookahead of ECMA 262 is not supported by the regexp package.
+	const pattern = `^(?=.*[0-9]).{8,}$`
+	components := &Components{Schema: map[string]*SchemaRef{
+		"Password": {Value: &Schema{Type: "string", Pattern: pattern}},
+	}}
+	schema := &Schema{Type: "object", Properties: map[string]*SchemaRef{"password": {Ref: "#/components/schemas/Password"}}}
+	want := "the pattern \"^(?=.*[0-9]).{8,}$\" cannot be compiled: error parsing regexp: invalid or unsupported Perl syntax: `(?=`"
+	if validator, err := schema.Compile(components); validator != nil || err == nil || err.Error() != want {
+		t.Errorf("Compile() = %v, %v, want the error %s", validator, err, want)
+	}
+	if err := schema.ValidateValue(decodeValue(t, `{"password": "secret"}`), WithComponents(components)); err == nil || err.Error() != want {
+		t.Errorf("ValidateValue() = %v, want the error %s", err, want)
+	}
+
+	openAPI := &OpenAPI{OpenAPI: "3.0.3", Info: &Info{Title: "Passwords", Version: "1.0"}, Paths: map[string]*PathItem{},
+		Components: components}
+	var found bool
+	for _, validationError := range openAPI.Validate(context.Background()) {
+		if validationError.Location == "#/components/schemas/Password/pattern" && validationError.Message == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Validate() = %v, want the error of the pattern", openAPI.Validate(context.Background()))
+	}
+}
+
+func TestValidateValueDiscriminatorFallsThrough(t *testing.T) {
+	components := &Components{Schema: map[string]*SchemaRef{
+		"Cat": {Value: &Schema{Type: "object", Required: []string{"petType", "lives"},
+			Properties: map[string]*SchemaRef{"petType": {Value: &Schema{Type: "string"}}, "lives": {Value: &Schema{Type: "integer"}}}}},
+		"Dog": {Value: &Schema{Type: "object", Required: []string{"petType", "bark"},
+			Properties: map[string]*SchemaRef{"petType": {Value: &Schema{Type: "string"}}, "bark": {Value: &Schema{Type: "boolean"}}}}},
+		"Name": {Value: &Schema{Type: "string"}},
+	}}
+	schema := &Schema{
+		OneOf: []*SchemaRef{
+			{Ref: "#/components/schemas/Cat"},
+			{Ref: "#/components/schemas/Dog"},
+			{Ref: "#/components/schemas/Name"},
+		},
+		Discriminator: &Discriminator{PropertyName: "petType"},
+	}
+	validator, err := schema.Compile(components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{`{"petType": "Cat", "lives": 9}`, true},
+		{`{"petType": "Cat", "bark": true}`, false},
+		{`{"petType": "Bird"}`, false},
+		// Values which are not objects are checked against every schema of oneOf.
+		{`"rex"`, true},
+		{`9`, false},
+		// So are objects without the discriminator property, which no schema of oneOf accepts here.
+		{`{"lives": 9}`, false},
+	}
+	for _, test := range tests {
+		err := validator.ValidateValue(decodeValue(t, test.value))
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("ValidateValue(%s) = %v, want valid %v", test.value, err, test.valid)
+		}
+	}
+
+	anyOf := &Schema{
+		AnyOf:         []*SchemaRef{{Ref: "#/components/schemas/Dog"}, {Value: &Schema{Type: "object", Required: []string{"name"}}}},
+		Discriminator: &Discriminator{PropertyName: "petType"},
+	}
+	if validator, err = anyOf.Compile(components); err != nil {
+		t.Fatal(err)
+	}
+	if err := validator.ValidateValue(decodeValue(t, `{"name": "rex"}`)); err != nil {
+		t.Errorf("ValidateValue of an object without the discriminator = %v, want valid through anyOf", err)
+	}
+}
@@ -0,0 +1,398 @@
+package v303
+
+import (
+	"reflect"
+	"sort"
+)
+
+// refHolder is implemented by the objects which may carry a $ref: the *Ref wrappers and PathItem.
+type refHolder interface {
+	// reference returns the address of the $ref string so that it can be rewritten.
+	reference() *string
+	// target returns the referenced or inline value, nil when it is unknown.
+	target() interface{}
+	// section returns the Components member the target can be stored in, "" for a PathItem.
+	section() string
+	// addTo stores the target into components under name and returns the new entry.
+	addTo(components *Components, name string) refHolder
+}
+
+func (schemaRef *SchemaRef) reference() *string { return &schemaRef.Ref }
+func (schemaRef *SchemaRef) section() string    { return "schemas" }
+func (schemaRef *SchemaRef) target() interface{} {
+	if schemaRef.Value == nil {
+		return nil
+	}
+	return schemaRef.Value
+}
+func (schemaRef *SchemaRef) addTo(components *Components, name string) refHolder {
+	if components.Schema == nil {
+		components.Schema = map[string]*SchemaRef{}
+	}
+	entry := &SchemaRef{Value: schemaRef.Value}
+	components.Schema[name] = entry
+	return entry
+}
+
+func (parameterRef *ParameterRef) reference() *string { return &parameterRef.Ref }
+func (parameterRef *ParameterRef) section() string    { return "parameters" }
+func (parameterRef *ParameterRef) target() interface{} {
+	if parameterRef.Value == nil {
+		return nil
+	}
+	return parameterRef.Value
+}
+func (parameterRef *ParameterRef) addTo(components *Components, name string) refHolder {
+	if components.Parameters == nil {
+		components.Parameters = map[string]*ParameterRef{}
+	}
+	entry := &ParameterRef{Value: parameterRef.Value}
+	components.Parameters[name] = entry
+	return entry
+}
+
+func (responseRef *ResponseRef) reference() *string { return &responseRef.Ref }
+func (responseRef *ResponseRef) section() string    { return "responses" }
+func (responseRef *ResponseRef) target() interface{} {
+	if responseRef.Value == nil {
+		return nil
+	}
+	return responseRef.Value
+}
+func (responseRef *ResponseRef) addTo(components *Components, name string) refHolder {
+	if components.Responses == nil {
+		components.Responses = map[string]*ResponseRef{}
+	}
+	entry := &ResponseRef{Value: responseRef.Value}
+	components.Responses[name] = entry
+	return entry
+}
+
+func (requestBodyRef *RequestBodyRef) reference() *string { return &requestBodyRef.Ref }
+func (requestBodyRef *RequestBodyRef) section() string    { return "requestBodies" }
+func (requestBodyRef *RequestBodyRef) target() interface{} {
+	if requestBodyRef.Value == nil {
+		return nil
+	}
+	return requestBodyRef.Value
+}
+func (requestBodyRef *RequestBodyRef) addTo(components *Components, name string) refHolder {
+	if components.RequestBodies == nil {
+		components.RequestBodies = map[string]*RequestBodyRef{}
+	}
+	entry := &RequestBodyRef{Value: requestBodyRef.Value}
+	components.RequestBodies[name] = entry
+	return entry
+}
+
+func (headerRef *HeaderRef) reference() *string { return &headerRef.Ref }
+func (headerRef *HeaderRef) section() string    { return "headers" }
+func (headerRef *HeaderRef) target() interface{} {
+	if headerRef.Value == nil {
+		return nil
+	}
+	return headerRef.Value
+}
+func (headerRef *HeaderRef) addTo(components *Components, name string) refHolder {
+	if components.Headers == nil {
+		components.Headers = map[string]*HeaderRef{}
+	}
+	entry := &HeaderRef{Value: headerRef.Value}
+	components.Headers[name] = entry
+	return entry
+}
+
+func (exampleRef *ExampleRef) reference() *string { return &exampleRef.Ref }
+func (exampleRef *ExampleRef) section() string    { return "examples" }
+func (exampleRef *ExampleRef) target() interface{} {
+	if exampleRef.Value == nil {
+		return nil
+	}
+	return exampleRef.Value
+}
+func (exampleRef *ExampleRef) addTo(components *Components, name string) refHolder {
+	if components.Examples == nil {
+		components.Examples = map[string]*ExampleRef{}
+	}
+	entry := &ExampleRef{Value: exampleRef.Value}
+	components.Examples[name] = entry
+	return entry
+}
+
+func (linkRef *LinkRef) reference() *string { return &linkRef.Ref }
+func (linkRef *LinkRef) section() string    { return "links" }
+func (linkRef *LinkRef) target() interface{} {
+	if linkRef.Value == nil {
+		return nil
+	}
+	return linkRef.Value
+}
+func (linkRef *LinkRef) addTo(components *Components, name string) refHolder {
+	if components.Links == nil {
+		components.Links = map[string]*LinkRef{}
+	}
+	entry := &LinkRef{Value: linkRef.Value}
+	components.Links[name] = entry
+	return entry
+}
+
+func (securitySchemeRef *SecuritySchemeRef) reference() *string { return &securitySchemeRef.Ref }
+func (securitySchemeRef *SecuritySchemeRef) section() string    { return "securitySchemes" }
+func (securitySchemeRef *SecuritySchemeRef) target() interface{} {
+	if securitySchemeRef.Value == nil {
+		return nil
+	}
+	return securitySchemeRef.Value
+}
+func (securitySchemeRef *SecuritySchemeRef) addTo(components *Components, name string) refHolder {
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = map[string]*SecuritySchemeRef{}
+	}
+	entry := &SecuritySchemeRef{Value: securitySchemeRef.Value}
+	components.SecuritySchemes[name] = entry
+	return entry
+}
+
+func (callbackRef *CallbackRef) reference() *string { return &callbackRef.Ref }
+func (callbackRef *CallbackRef) section() string    { return "callbacks" }
+func (callbackRef *CallbackRef) target() interface{} {
+	if callbackRef.Value == nil {
+		return nil
+	}
+	return callbackRef.Value
+}
+func (callbackRef *CallbackRef) addTo(components *Components, name string) refHolder {
+	if components.Callbacks == nil {
+		components.Callbacks = map[string]*CallbackRef{}
+	}
+	entry := &CallbackRef{Value: callbackRef.Value}
+	components.Callbacks[name] = entry
+	return entry
+}
+
+func (pathItem *PathItem) reference() *string                                  { return &pathItem.Ref }
+func (pathItem *PathItem) section() string                                     { return "" }
+func (pathItem *PathItem) target() interface{}                                 { return pathItem }
+func (pathItem *PathItem) addTo(components *Components, name string) refHolder { return nil }
+
+// sortedKeys returns the keys of a map with string keys in order, so that walks are deterministic.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.String()
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mappingRef is a value of the mapping of a discriminator which is a $ref, written back to the mapping once it is
+// visited.
+type mappingRef struct {
+	SchemaRef
+}
+
+// refWalker visits every refHolder of a document in a deterministic order. visit decides whether to walk
+// into the target of the holder by calling descend.
+type refWalker struct {
+	visit func(holder refHolder, descend func() error) error
+	// mappings are the targets of the mappings of discriminators, which are visited as *mappingRef when set.
+	mappings map[*Discriminator]map[string]*Schema
+}
+
+func (walker *refWalker) walk(holder refHolder) error {
+	return walker.visit(holder, func() error {
+		return walker.walkValue(holder.target())
+	})
+}
+
+func (walker *refWalker) walkOpenAPI(openAPI *OpenAPI) error {
+	for _, name := range sortedKeys(openAPI.Paths) {
+		if pathItem := openAPI.Paths[name]; pathItem != nil {
+			if err := walker.walk(pathItem); err != nil {
+				return err
+			}
+		}
+	}
+	return walker.walkComponents(openAPI.Components)
+}
+
+func (walker *refWalker) walkComponents(components *Components) error {
+	var holders []refHolder
+	for _, component := range componentsOf(components) {
+		holders = append(holders, component.holder)
+	}
+	return walker.walkAll(holders)
+}
+
+// component is an entry of Components, the member it belongs to is given by holder.section().
+type component struct {
+	name   string
+	holder refHolder
+}
+
+// componentsOf lists the entries of components in a deterministic order.
+func componentsOf(components *Components) []component {
+	if components == nil {
+		return nil
+	}
+	var entries []component
+	for _, name := range sortedKeys(components.Schema) {
+		entries = append(entries, component{name, components.Schema[name]})
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		entries = append(entries, component{name, components.Responses[name]})
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		entries = append(entries, component{name, components.Parameters[name]})
+	}
+	for _, name := range sortedKeys(components.Examples) {
+		entries = append(entries, component{name, components.Examples[name]})
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		entries = append(entries, component{name, components.RequestBodies[name]})
+	}
+	for _, name := range sortedKeys(components.Headers) {
+		entries = append(entries, component{name, components.Headers[name]})
+	}
+	for _, name := range sortedKeys(components.SecuritySchemes) {
+		entries = append(entries, component{name, components.SecuritySchemes[name]})
+	}
+	for _, name := range sortedKeys(components.Links) {
+		entries = append(entries, component{name, components.Links[name]})
+	}
+	for _, name := range sortedKeys(components.Callbacks) {
+		entries = append(entries, component{name, components.Callbacks[name]})
+	}
+	return entries
+}
+
+// walkAll walks the holders, skipping the nil ones.
+func (walker *refWalker) walkAll(holders []refHolder) error {
+	for _, holder := range holders {
+		if holder == nil || reflect.ValueOf(holder).IsNil() {
+			continue
+		}
+		if err := walker.walk(holder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (walker *refWalker) walkValue(value interface{}) error {
+	var holders []refHolder
+	switch value := value.(type) {
+	case *Schema:
+		for _, schemaRefs := range [][]*SchemaRef{value.AllOf, value.OneOf, value.AnyOf} {
+			for _, schemaRef := range schemaRefs {
+				holders = append(holders, schemaRef)
+			}
+		}
+		holders = append(holders, value.Not, value.Items)
+		for _, name := range sortedKeys(value.Properties) {
+			holders = append(holders, value.Properties[name])
+		}
+		if value.AdditionalProperties != nil {
+			holders = append(holders, value.AdditionalProperties.Schema)
+		}
+		if err := walker.walkAll(holders); err != nil {
+			return err
+		}
+		return walker.walkMapping(value.Discriminator)
+	case *Parameter:
+		holders = append(holders, value.Schema)
+		holders = append(holders, exampleHolders(value.Examples)...)
+		holders = append(holders, contentHolders(value.Content)...)
+	case *RequestBody:
+		holders = append(holders, contentHolders(value.Content)...)
+	case *Response:
+		for _, name := range sortedKeys(value.Headers) {
+			holders = append(holders, value.Headers[name])
+		}
+		holders = append(holders, contentHolders(value.Content)...)
+		for _, name := range sortedKeys(value.Links) {
+			holders = append(holders, value.Links[name])
+		}
+	case *Header:
+		holders = append(holders, value.Schema)
+		holders = append(holders, exampleHolders(value.Examples)...)
+		holders = append(holders, contentHolders(value.Content)...)
+	case *Callback:
+		for _, name := range sortedKeys(value.Paths) {
+			holders = append(holders, value.Paths[name])
+		}
+	case *PathItem:
+		operations := value.Operations()
+		for _, method := range sortedKeys(operations) {
+			holders = append(holders, operationHolders(operations[method])...)
+		}
+		for _, parameterRef := range value.Parameters {
+			holders = append(holders, parameterRef)
+		}
+	}
+	return walker.walkAll(holders)
+}
+
+// walkMapping walks the values of the mapping of discriminator which are a $ref.
+func (walker *refWalker) walkMapping(discriminator *Discriminator) error {
+	if discriminator == nil {
+		return nil
+	}
+	targets := walker.mappings[discriminator]
+	for _, key := range sortedKeys(discriminator.Mapping) {
+		target := targets[key]
+		if target == nil {
+			continue
+		}
+		holder := &mappingRef{SchemaRef{Ref: discriminator.Mapping[key], Value: target}}
+		if err := walker.walk(holder); err != nil {
+			return err
+		}
+		discriminator.Mapping[key] = holder.Ref
+	}
+	return nil
+}
+
+func operationHolders(operation *Operation) []refHolder {
+	var holders []refHolder
+	for _, parameterRef := range operation.Parameters {
+		holders = append(holders, parameterRef)
+	}
+	holders = append(holders, operation.RequestBody)
+	for _, name := range sortedKeys(operation.Responses) {
+		holders = append(holders, operation.Responses[name])
+	}
+	for _, name := range sortedKeys(operation.Callbacks) {
+		holders = append(holders, operation.Callbacks[name])
+	}
+	return holders
+}
+
+func exampleHolders(examples map[string]*ExampleRef) []refHolder {
+	var holders []refHolder
+	for _, name := range sortedKeys(examples) {
+		holders = append(holders, examples[name])
+	}
+	return holders
+}
+
+func contentHolders(content map[string]*MediaType) []refHolder {
+	var holders []refHolder
+	for _, mediaTypeName := range sortedKeys(content) {
+		mediaType := content[mediaTypeName]
+		if mediaType == nil {
+			continue
+		}
+		holders = append(holders, mediaType.Schema)
+		holders = append(holders, exampleHolders(mediaType.Examples)...)
+		for _, encodingName := range sortedKeys(mediaType.Encoding) {
+			if encoding := mediaType.Encoding[encodingName]; encoding != nil {
+				for _, name := range sortedKeys(encoding.Headers) {
+					holders = append(holders, encoding.Headers[name])
+				}
+			}
+		}
+	}
+	return holders
+}
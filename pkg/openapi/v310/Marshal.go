@@ -0,0 +1,95 @@
+package v310
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+)
+
+// MarshalJSON returns the JSON encoding of the OpenAPI, paths keep the order they were decoded in.
+func (openAPI OpenAPI) MarshalJSON() ([]byte, error) {
+	type alias OpenAPI
+	data, err := json.Marshal(alias(openAPI))
+	if err != nil {
+		return nil, err
+	}
+	return codec.Reorder(data, "paths", openAPI.pathsOrder)
+}
+
+// UnmarshalJSON decodes an OpenAPI, recording the order of its paths.
+func (openAPI *OpenAPI) UnmarshalJSON(data []byte) error {
+	type alias OpenAPI
+	if err := json.Unmarshal(data, (*alias)(openAPI)); err != nil {
+		return err
+	}
+	openAPI.pathsOrder = codec.MemberKeys(data, "paths")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the Operation, responses keep the order they were decoded in.
+func (operation Operation) MarshalJSON() ([]byte, error) {
+	type alias Operation
+	data, err := json.Marshal(alias(operation))
+	if err != nil {
+		return nil, err
+	}
+	return codec.Reorder(data, "responses", operation.responsesOrder)
+}
+
+// UnmarshalJSON decodes an Operation, recording the order of its responses.
+func (operation *Operation) UnmarshalJSON(data []byte) error {
+	type alias Operation
+	if err := json.Unmarshal(data, (*alias)(operation)); err != nil {
+		return err
+	}
+	operation.responsesOrder = codec.MemberKeys(data, "responses")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the RequestBody, always emitting the required content map.
+func (requestBody RequestBody) MarshalJSON() ([]byte, error) {
+	type alias RequestBody
+	if requestBody.Content == nil {
+		requestBody.Content = map[string]*MediaType{}
+	}
+	return json.Marshal(alias(requestBody))
+}
+
+// MarshalJSON returns the JSON encoding of the Schema: true or false for a boolean schema, otherwise an object
+// whose properties keep the order they were decoded in.
+func (schema Schema) MarshalJSON() ([]byte, error) {
+	if schema.Boolean != nil {
+		return json.Marshal(*schema.Boolean)
+	}
+	type alias Schema
+	data, err := json.Marshal(alias(schema))
+	if err != nil {
+		return nil, err
+	}
+	return codec.Reorder(data, "properties", schema.propertiesOrder)
+}
+
+// UnmarshalJSON decodes a boolean schema or a schema object, recording the order of its properties.
+func (schema *Schema) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		value := trimmed[0] == 't'
+		*schema = Schema{Boolean: &value}
+		return nil
+	}
+	type alias Schema
+	if err := json.Unmarshal(data, (*alias)(schema)); err != nil {
+		return err
+	}
+	schema.propertiesOrder = codec.MemberKeys(data, "properties")
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the OAuthFlow, always emitting the required scopes map.
+func (oauthFlow OAuthFlow) MarshalJSON() ([]byte, error) {
+	type alias OAuthFlow
+	if oauthFlow.Scopes == nil {
+		oauthFlow.Scopes = map[string]string{}
+	}
+	return json.Marshal(alias(oauthFlow))
+}
@@ -0,0 +1,297 @@
+// https://spec.openapis.org/oas/v3.1.0
+package v310
+
+import "encoding/json"
+
+// DefaultJSONSchemaDialect is the dialect of the Schema Objects of a document which does not declare jsonSchemaDialect.
+// https://spec.openapis.org/oas/v3.1.0#fixed-fields
+const DefaultJSONSchemaDialect = "https://spec.openapis.org/oas/3.1/dialect/base"
+
+// OpenAPI is the root object of the OpenAPI document.
+// https://spec.openapis.org/oas/v3.1.0#openapi-object
+type OpenAPI struct {
+	OpenAPI           string                 `json:"openapi"`
+	Info              *Info                  `json:"info"`
+	JSONSchemaDialect string                 `json:"jsonSchemaDialect,omitempty"`
+	Servers           []*Server              `json:"servers,omitempty"`
+	Paths             map[string]*PathItem   `json:"paths,omitempty"`
+	Webhooks          map[string]*PathItem   `json:"webhooks,omitempty"`
+	Components        *Components            `json:"components,omitempty"`
+	Security          []SecurityRequirement  `json:"security,omitempty"`
+	Tags              []*Tag                 `json:"tags,omitempty"`
+	ExternalDocs      *ExternalDocumentation `json:"externalDocs,omitempty"`
+
+	pathsOrder []string
+}
+
+// Info provides metadata about the API. The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.
+// https://spec.openapis.org/oas/v3.1.0#info-object
+type Info struct {
+	Title          string   `json:"title"`
+	Summary        string   `json:"summary,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
+	Version        string   `json:"version"`
+}
+
+// Contact information for the exposed API.
+// https://spec.openapis.org/oas/v3.1.0#contact-object
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	Url   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License information for the exposed API. Identifier and Url are mutually exclusive.
+// https://spec.openapis.org/oas/v3.1.0#license-object
+type License struct {
+	Name       string `json:"name"`
+	Identifier string `json:"identifier,omitempty"`
+	Url        string `json:"url,omitempty"`
+}
+
+// Server An object representing a Server.
+// https://spec.openapis.org/oas/v3.1.0#server-object
+type Server struct {
+	Url         string                     `json:"url"`
+	Description string                     `json:"description,omitempty"`
+	Variables   map[string]*ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable An object representing a Server Variable for server URL template substitution.
+// https://spec.openapis.org/oas/v3.1.0#server-variable-object
+type ServerVariable struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Components Holds a set of reusable objects for different aspects of the OAS.
+// https://spec.openapis.org/oas/v3.1.0#components-object
+type Components struct {
+	Schemas         map[string]*Schema            `json:"schemas,omitempty"`
+	Responses       map[string]*ResponseRef       `json:"responses,omitempty"`
+	Parameters      map[string]*ParameterRef      `json:"parameters,omitempty"`
+	Examples        map[string]*ExampleRef        `json:"examples,omitempty"`
+	RequestBodies   map[string]*RequestBodyRef    `json:"requestBodies,omitempty"`
+	Headers         map[string]*HeaderRef         `json:"headers,omitempty"`
+	SecuritySchemes map[string]*SecuritySchemeRef `json:"securitySchemes,omitempty"`
+	Links           map[string]*LinkRef           `json:"links,omitempty"`
+	Callbacks       map[string]*CallbackRef       `json:"callbacks,omitempty"`
+	PathItems       map[string]*PathItem          `json:"pathItems,omitempty"`
+}
+
+// PathItem Describes the operations available on a single path. When Ref is set the other fields are merged
+// with the ones of the referenced path item.
+// https://spec.openapis.org/oas/v3.1.0#path-item-object
+type PathItem struct {
+	Ref         string          `json:"$ref,omitempty"`
+	Summary     string          `json:"summary,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Get         *Operation      `json:"get,omitempty"`
+	Put         *Operation      `json:"put,omitempty"`
+	Post        *Operation      `json:"post,omitempty"`
+	Delete      *Operation      `json:"delete,omitempty"`
+	Options     *Operation      `json:"options,omitempty"`
+	Head        *Operation      `json:"head,omitempty"`
+	Patch       *Operation      `json:"patch,omitempty"`
+	Trace       *Operation      `json:"trace,omitempty"`
+	Servers     []*Server       `json:"servers,omitempty"`
+	Parameters  []*ParameterRef `json:"parameters,omitempty"`
+}
+
+// Operations returns the operations defined on the path item keyed by their upper case HTTP method.
+func (pathItem *PathItem) Operations() map[string]*Operation {
+	operations := map[string]*Operation{}
+	for method, operation := range map[string]*Operation{
+		"GET": pathItem.Get, "PUT": pathItem.Put, "POST": pathItem.Post, "DELETE": pathItem.Delete,
+		"OPTIONS": pathItem.Options, "HEAD": pathItem.Head, "PATCH": pathItem.Patch, "TRACE": pathItem.Trace,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
+
+// Operation Describes a single API operation on a path.
+// https://spec.openapis.org/oas/v3.1.0#operation-object
+type Operation struct {
+	Tags         []string                `json:"tags,omitempty"`
+	Summary      string                  `json:"summary,omitempty"`
+	Description  string                  `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation  `json:"externalDocs,omitempty"`
+	OperationID  string                  `json:"operationId,omitempty"`
+	Parameters   []*ParameterRef         `json:"parameters,omitempty"`
+	RequestBody  *RequestBodyRef         `json:"requestBody,omitempty"`
+	Responses    map[string]*ResponseRef `json:"responses,omitempty"`
+	Callbacks    map[string]*CallbackRef `json:"callbacks,omitempty"`
+	Deprecated   bool                    `json:"deprecated,omitempty"`
+	Security     *[]SecurityRequirement  `json:"security,omitempty"`
+	Servers      []*Server               `json:"servers,omitempty"`
+
+	responsesOrder []string
+}
+
+// ExternalDocumentation Allows referencing an external resource for extended documentation.
+// https://spec.openapis.org/oas/v3.1.0#external-documentation-object
+type ExternalDocumentation struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// Parameter Describes a single operation parameter.
+// https://spec.openapis.org/oas/v3.1.0#parameter-object
+type Parameter struct {
+	Name            string                 `json:"name"`
+	In              string                 `json:"in"`
+	Description     string                 `json:"description,omitempty"`
+	Required        bool                   `json:"required,omitempty"`
+	Deprecated      bool                   `json:"deprecated,omitempty"`
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty"`
+	Style           string                 `json:"style,omitempty"`
+	Explode         *bool                  `json:"explode,omitempty"`
+	AllowReserved   bool                   `json:"allowReserved,omitempty"`
+	Schema          *Schema                `json:"schema,omitempty"`
+	Example         json.RawMessage        `json:"example,omitempty"`
+	Examples        map[string]*ExampleRef `json:"examples,omitempty"`
+	Content         map[string]*MediaType  `json:"content,omitempty"`
+}
+
+// RequestBody Describes a single request body.
+// https://spec.openapis.org/oas/v3.1.0#request-body-object
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+	Required    bool                  `json:"required,omitempty"`
+}
+
+// MediaType Each Media Type Object provides schema and examples for the media type identified by its key.
+// https://spec.openapis.org/oas/v3.1.0#media-type-object
+type MediaType struct {
+	Schema   *Schema                `json:"schema,omitempty"`
+	Example  json.RawMessage        `json:"example,omitempty"`
+	Examples map[string]*ExampleRef `json:"examples,omitempty"`
+	Encoding map[string]*Encoding   `json:"encoding,omitempty"`
+}
+
+// Encoding A single encoding definition applied to a single schema property.
+// https://spec.openapis.org/oas/v3.1.0#encoding-object
+type Encoding struct {
+	ContentType   string                `json:"contentType,omitempty"`
+	Headers       map[string]*HeaderRef `json:"headers,omitempty"`
+	Style         string                `json:"style,omitempty"`
+	Explode       *bool                 `json:"explode,omitempty"`
+	AllowReserved bool                  `json:"allowReserved,omitempty"`
+}
+
+// Response Describes a single response from an API Operation.
+// https://spec.openapis.org/oas/v3.1.0#response-object
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*HeaderRef `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+	Links       map[string]*LinkRef   `json:"links,omitempty"`
+}
+
+// Callback A map of possible out-of band callbacks related to the parent operation. Each value is a Path Item Object keyed by a runtime expression.
+// https://spec.openapis.org/oas/v3.1.0#callback-object
+type Callback map[string]*PathItem
+
+// Example holds an example value, Value and ExternalValue are mutually exclusive.
+// https://spec.openapis.org/oas/v3.1.0#example-object
+type Example struct {
+	Summary       string          `json:"summary,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	Value         json.RawMessage `json:"value,omitempty"`
+	ExternalValue string          `json:"externalValue,omitempty"`
+}
+
+// Link represents a possible design-time link for a response.
+// https://spec.openapis.org/oas/v3.1.0#link-object
+type Link struct {
+	OperationRef string                     `json:"operationRef,omitempty"`
+	OperationID  string                     `json:"operationId,omitempty"`
+	Parameters   map[string]json.RawMessage `json:"parameters,omitempty"`
+	RequestBody  json.RawMessage            `json:"requestBody,omitempty"`
+	Description  string                     `json:"description,omitempty"`
+	Server       *Server                    `json:"server,omitempty"`
+}
+
+// Header follows the structure of the Parameter Object, without name and in.
+// https://spec.openapis.org/oas/v3.1.0#header-object
+type Header struct {
+	Description     string                 `json:"description,omitempty"`
+	Required        bool                   `json:"required,omitempty"`
+	Deprecated      bool                   `json:"deprecated,omitempty"`
+	AllowEmptyValue bool                   `json:"allowEmptyValue,omitempty"`
+	Style           string                 `json:"style,omitempty"`
+	Explode         *bool                  `json:"explode,omitempty"`
+	AllowReserved   bool                   `json:"allowReserved,omitempty"`
+	Schema          *Schema                `json:"schema,omitempty"`
+	Example         json.RawMessage        `json:"example,omitempty"`
+	Examples        map[string]*ExampleRef `json:"examples,omitempty"`
+	Content         map[string]*MediaType  `json:"content,omitempty"`
+}
+
+// Tag Adds metadata to a single tag that is used by the Operation Object.
+// https://spec.openapis.org/oas/v3.1.0#tag-object
+type Tag struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+}
+
+// Discriminator When request bodies or response payloads may be one of a number of different schemas, a discriminator object can be used to aid in serialization, deserialization, and validation.
+// https://spec.openapis.org/oas/v3.1.0#discriminator-object
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// XML A metadata object that allows for more fine-tuned XML model definitions.
+// https://spec.openapis.org/oas/v3.1.0#xml-object
+type XML struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty"`
+}
+
+// SecurityScheme Defines a security scheme that can be used by the operations.
+// https://spec.openapis.org/oas/v3.1.0#security-scheme-object
+type SecurityScheme struct {
+	Type             string      `json:"type"`
+	Description      string      `json:"description,omitempty"`
+	Name             string      `json:"name,omitempty"`
+	In               string      `json:"in,omitempty"`
+	Scheme           string      `json:"scheme,omitempty"`
+	BearerFormat     string      `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlows `json:"flows,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"`
+}
+
+// OAuthFlows Allows configuration of the supported OAuth Flows.
+// https://spec.openapis.org/oas/v3.1.0#oauth-flows-object
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow Configuration details for a supported OAuth Flow.
+// https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// SecurityRequirement Lists the required security schemes to execute this operation. The name used for each property MUST correspond to a security scheme declared in the Security Schemes under the Components Object.
+// https://spec.openapis.org/oas/v3.1.0#security-requirement-object
+type SecurityRequirement map[string][]string
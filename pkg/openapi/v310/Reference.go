@@ -0,0 +1,276 @@
+package v310
+
+import (
+	"encoding/json"
+)
+
+// Reference A simple object to allow referencing other components in the OpenAPI document, internally and
+// externally. Summary and Description override the ones of the referenced component.
+// https://spec.openapis.org/oas/v3.1.0#reference-object
+type Reference struct {
+	Ref         string `json:"$ref"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// The *Ref types below hold either a Reference Object or an inline value of the referenced type.
+// When Ref is set the object is a reference and only Ref, Summary and Description are serialized, Value may
+// then carry the resolved target alongside them. When Ref is empty Value is the inline object.
+// Schemas are not wrapped since $ref is a keyword of the Schema Object itself.
+// https://spec.openapis.org/oas/v3.1.0#reference-object
+
+// ParameterRef is either a Reference Object to a Parameter or an inline Parameter.
+type ParameterRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *Parameter
+}
+
+// ResponseRef is either a Reference Object to a Response or an inline Response.
+type ResponseRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *Response
+}
+
+// RequestBodyRef is either a Reference Object to a RequestBody or an inline RequestBody.
+type RequestBodyRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *RequestBody
+}
+
+// HeaderRef is either a Reference Object to a Header or an inline Header.
+type HeaderRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *Header
+}
+
+// ExampleRef is either a Reference Object to an Example or an inline Example.
+type ExampleRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *Example
+}
+
+// LinkRef is either a Reference Object to a Link or an inline Link.
+type LinkRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *Link
+}
+
+// SecuritySchemeRef is either a Reference Object to a SecurityScheme or an inline SecurityScheme.
+type SecuritySchemeRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *SecurityScheme
+}
+
+// CallbackRef is either a Reference Object to a Callback or an inline Callback.
+type CallbackRef struct {
+	Ref         string
+	Summary     string
+	Description string
+	Value       *Callback
+}
+
+// unmarshalRef looks for a "$ref" member in data. It returns nil when data is not a Reference Object.
+func unmarshalRef(data []byte) (*Reference, error) {
+	var probe struct {
+		Ref         *string `json:"$ref"`
+		Summary     string  `json:"summary"`
+		Description string  `json:"description"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	if probe.Ref == nil {
+		return nil, nil
+	}
+	return &Reference{Ref: *probe.Ref, Summary: probe.Summary, Description: probe.Description}, nil
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Parameter.
+func (parameterRef ParameterRef) MarshalJSON() ([]byte, error) {
+	if parameterRef.Ref != "" {
+		return json.Marshal(Reference{parameterRef.Ref, parameterRef.Summary, parameterRef.Description})
+	}
+	return json.Marshal(parameterRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Parameter into Value.
+func (parameterRef *ParameterRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*parameterRef = ParameterRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*parameterRef = ParameterRef{Value: new(Parameter)}
+	return json.Unmarshal(data, parameterRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Response.
+func (responseRef ResponseRef) MarshalJSON() ([]byte, error) {
+	if responseRef.Ref != "" {
+		return json.Marshal(Reference{responseRef.Ref, responseRef.Summary, responseRef.Description})
+	}
+	return json.Marshal(responseRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Response into Value.
+func (responseRef *ResponseRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*responseRef = ResponseRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*responseRef = ResponseRef{Value: new(Response)}
+	return json.Unmarshal(data, responseRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline RequestBody.
+func (requestBodyRef RequestBodyRef) MarshalJSON() ([]byte, error) {
+	if requestBodyRef.Ref != "" {
+		return json.Marshal(Reference{requestBodyRef.Ref, requestBodyRef.Summary, requestBodyRef.Description})
+	}
+	return json.Marshal(requestBodyRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline RequestBody into Value.
+func (requestBodyRef *RequestBodyRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*requestBodyRef = RequestBodyRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*requestBodyRef = RequestBodyRef{Value: new(RequestBody)}
+	return json.Unmarshal(data, requestBodyRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Header.
+func (headerRef HeaderRef) MarshalJSON() ([]byte, error) {
+	if headerRef.Ref != "" {
+		return json.Marshal(Reference{headerRef.Ref, headerRef.Summary, headerRef.Description})
+	}
+	return json.Marshal(headerRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Header into Value.
+func (headerRef *HeaderRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*headerRef = HeaderRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*headerRef = HeaderRef{Value: new(Header)}
+	return json.Unmarshal(data, headerRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Example.
+func (exampleRef ExampleRef) MarshalJSON() ([]byte, error) {
+	if exampleRef.Ref != "" {
+		return json.Marshal(Reference{exampleRef.Ref, exampleRef.Summary, exampleRef.Description})
+	}
+	return json.Marshal(exampleRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Example into Value.
+func (exampleRef *ExampleRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*exampleRef = ExampleRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*exampleRef = ExampleRef{Value: new(Example)}
+	return json.Unmarshal(data, exampleRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Link.
+func (linkRef LinkRef) MarshalJSON() ([]byte, error) {
+	if linkRef.Ref != "" {
+		return json.Marshal(Reference{linkRef.Ref, linkRef.Summary, linkRef.Description})
+	}
+	return json.Marshal(linkRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Link into Value.
+func (linkRef *LinkRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*linkRef = LinkRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*linkRef = LinkRef{Value: new(Link)}
+	return json.Unmarshal(data, linkRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline SecurityScheme.
+func (securitySchemeRef SecuritySchemeRef) MarshalJSON() ([]byte, error) {
+	if securitySchemeRef.Ref != "" {
+		return json.Marshal(Reference{securitySchemeRef.Ref, securitySchemeRef.Summary, securitySchemeRef.Description})
+	}
+	return json.Marshal(securitySchemeRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline SecurityScheme into Value.
+func (securitySchemeRef *SecuritySchemeRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*securitySchemeRef = SecuritySchemeRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*securitySchemeRef = SecuritySchemeRef{Value: new(SecurityScheme)}
+	return json.Unmarshal(data, securitySchemeRef.Value)
+}
+
+// MarshalJSON returns the JSON encoding of the Reference Object when Ref is set, otherwise of the inline Callback.
+func (callbackRef CallbackRef) MarshalJSON() ([]byte, error) {
+	if callbackRef.Ref != "" {
+		return json.Marshal(Reference{callbackRef.Ref, callbackRef.Summary, callbackRef.Description})
+	}
+	return json.Marshal(callbackRef.Value)
+}
+
+// UnmarshalJSON decodes a Reference Object into Ref, or an inline Callback into Value.
+func (callbackRef *CallbackRef) UnmarshalJSON(data []byte) error {
+	ref, err := unmarshalRef(data)
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		*callbackRef = CallbackRef{Ref: ref.Ref, Summary: ref.Summary, Description: ref.Description}
+		return nil
+	}
+	*callbackRef = CallbackRef{Value: new(Callback)}
+	return json.Unmarshal(data, callbackRef.Value)
+}
@@ -0,0 +1,136 @@
+package v310
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Schema is a JSON Schema 2020-12 schema extended with the OpenAPI vocabulary. Schemas are not wrapped into a
+// Reference Object: $ref is a keyword which may sit next to the other ones.
+// Numeric keywords are json.Number so that they keep their exact value, an empty one is absent. Count keywords
+// whose zero value is meaningful, such as maxLength, are pointers.
+// https://spec.openapis.org/oas/v3.1.0#schema-object
+// https://json-schema.org/draft/2020-12/json-schema-core.html
+// https://json-schema.org/draft/2020-12/json-schema-validation.html
+type Schema struct {
+	// Boolean, when set, makes the schema the boolean schema true or false, every other field is then ignored.
+	Boolean *bool `json:"-"`
+
+	// Core vocabulary.
+	Schema        string             `json:"$schema,omitempty"`
+	ID            string             `json:"$id,omitempty"`
+	Ref           string             `json:"$ref,omitempty"`
+	Anchor        string             `json:"$anchor,omitempty"`
+	DynamicRef    string             `json:"$dynamicRef,omitempty"`
+	DynamicAnchor string             `json:"$dynamicAnchor,omitempty"`
+	Vocabulary    map[string]bool    `json:"$vocabulary,omitempty"`
+	Comment       string             `json:"$comment,omitempty"`
+	Defs          map[string]*Schema `json:"$defs,omitempty"`
+
+	// Meta-data vocabulary.
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Default     json.RawMessage   `json:"default,omitempty"`
+	Deprecated  bool              `json:"deprecated,omitempty"`
+	ReadOnly    bool              `json:"readOnly,omitempty"`
+	WriteOnly   bool              `json:"writeOnly,omitempty"`
+	Examples    []json.RawMessage `json:"examples,omitempty"`
+
+	// Validation vocabulary.
+	Type              SchemaType          `json:"type,omitempty"`
+	Const             json.RawMessage     `json:"const,omitempty"`
+	Enum              []json.RawMessage   `json:"enum,omitempty"`
+	MultipleOf        json.Number         `json:"multipleOf,omitempty"`
+	Maximum           json.Number         `json:"maximum,omitempty"`
+	ExclusiveMaximum  json.Number         `json:"exclusiveMaximum,omitempty"`
+	Minimum           json.Number         `json:"minimum,omitempty"`
+	ExclusiveMinimum  json.Number         `json:"exclusiveMinimum,omitempty"`
+	MaxLength         *int                `json:"maxLength,omitempty"`
+	MinLength         int                 `json:"minLength,omitempty"`
+	Pattern           string              `json:"pattern,omitempty"`
+	MaxItems          *int                `json:"maxItems,omitempty"`
+	MinItems          int                 `json:"minItems,omitempty"`
+	UniqueItems       bool                `json:"uniqueItems,omitempty"`
+	MaxContains       *int                `json:"maxContains,omitempty"`
+	MinContains       *int                `json:"minContains,omitempty"`
+	MaxProperties     *int                `json:"maxProperties,omitempty"`
+	MinProperties     int                 `json:"minProperties,omitempty"`
+	Required          []string            `json:"required,omitempty"`
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+
+	// Format and content vocabularies.
+	Format           string  `json:"format,omitempty"`
+	ContentEncoding  string  `json:"contentEncoding,omitempty"`
+	ContentMediaType string  `json:"contentMediaType,omitempty"`
+	ContentSchema    *Schema `json:"contentSchema,omitempty"`
+
+	// Applicator vocabulary.
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+	Else                 *Schema            `json:"else,omitempty"`
+	DependentSchemas     map[string]*Schema `json:"dependentSchemas,omitempty"`
+	PrefixItems          []*Schema          `json:"prefixItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Contains             *Schema            `json:"contains,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+
+	// Unevaluated vocabulary.
+	UnevaluatedItems      *Schema `json:"unevaluatedItems,omitempty"`
+	UnevaluatedProperties *Schema `json:"unevaluatedProperties,omitempty"`
+
+	// OpenAPI vocabulary.
+	Discriminator *Discriminator         `json:"discriminator,omitempty"`
+	Xml           *XML                   `json:"xml,omitempty"`
+	ExternalDocs  *ExternalDocumentation `json:"externalDocs,omitempty"`
+	// Deprecated: use Examples instead.
+	Example json.RawMessage `json:"example,omitempty"`
+
+	propertiesOrder []string
+}
+
+// BoolSchema returns the boolean schema true, which accepts every instance, or false, which accepts none.
+func BoolSchema(value bool) *Schema {
+	return &Schema{Boolean: &value}
+}
+
+// SchemaType is the value of the type keyword, a single type or an array of types.
+// https://json-schema.org/draft/2020-12/json-schema-validation.html#rfc.section.6.1.1
+type SchemaType []string
+
+// Includes reports whether name is one of the types.
+func (schemaType SchemaType) Includes(name string) bool {
+	for _, included := range schemaType {
+		if included == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON returns the JSON encoding of the types, a string when there is a single one.
+func (schemaType SchemaType) MarshalJSON() ([]byte, error) {
+	if len(schemaType) == 1 {
+		return json.Marshal(schemaType[0])
+	}
+	return json.Marshal([]string(schemaType))
+}
+
+// UnmarshalJSON decodes either a single type or an array of types.
+func (schemaType *SchemaType) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		*schemaType = SchemaType{name}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(schemaType))
+}
@@ -0,0 +1,28 @@
+package v310
+
+import (
+	"encoding/json"
+
+	"github.com/newm4n/swaggo/pkg/openapi/internal/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML decodes an OpenAPI from YAML, so that yaml.Unmarshal can be used on a document.
+// Anchors, aliases and merge keys are expanded, and the order of paths, properties and responses is kept.
+func (openAPI *OpenAPI) UnmarshalYAML(value *yaml.Node) error {
+	data, err := codec.NodeToJSON(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, openAPI)
+}
+
+// MarshalYAML encodes the OpenAPI with the same fields and order as its JSON encoding, so that yaml.Marshal
+// produces a stable output.
+func (openAPI OpenAPI) MarshalYAML() (interface{}, error) {
+	data, err := json.Marshal(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	return codec.JSONToNode(data)
+}
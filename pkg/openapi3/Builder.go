@@ -0,0 +1,364 @@
+// Package openapi3 builds OpenAPI 3.0.3 documents in code with chained calls:
+//
+//	openAPI, err := openapi3.New("Pets API", "1.0").
+//		Server("https://pets.example.com", "").
+//		Schema("Pet", schema.Object().Property("id", schema.Int64()).Required("id")).
+//		Path("/pets/{id}").
+//		Get(func(op *openapi3.OperationBuilder) {
+//			op.ID("getPet").PathParam("id", schema.Int64()).Response(200, "ok", schema.Ref("Pet"))
+//		}).
+//		Build()
+package openapi3
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/schema"
+)
+
+// jsonMediaType is the media type of the bodies described by a schema builder alone.
+const jsonMediaType = "application/json"
+
+// Builder builds a document. The zero value is not usable, documents are started with New.
+type Builder struct {
+	openAPI *v303.OpenAPI
+	// err is the first error of the schema builders, which Build returns.
+	err error
+}
+
+// New starts the document of the API title at version.
+func New(title, version string) *Builder {
+	return &Builder{openAPI: &v303.OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    &v303.Info{Title: title, Version: version},
+		Paths:   map[string]*v303.PathItem{},
+	}}
+}
+
+// Description sets the description of the API.
+func (builder *Builder) Description(description string) *Builder {
+	builder.openAPI.Info.Description = description
+	return builder
+}
+
+// Contact sets the contact of the API.
+func (builder *Builder) Contact(name, url, email string) *Builder {
+	builder.openAPI.Info.Contact = &v303.Contact{Name: name, Url: url, Email: email}
+	return builder
+}
+
+// License sets the license of the API.
+func (builder *Builder) License(name, url string) *Builder {
+	builder.openAPI.Info.License = &v303.License{Name: name, Url: url}
+	return builder
+}
+
+// Server adds a server of the API.
+func (builder *Builder) Server(url, description string) *Builder {
+	builder.openAPI.Servers = append(builder.openAPI.Servers, &v303.Server{Url: url, Description: description})
+	return builder
+}
+
+// Tag adds a tag grouping operations.
+func (builder *Builder) Tag(name, description string) *Builder {
+	builder.openAPI.Tags = append(builder.openAPI.Tags, &v303.Tag{Name: name, Description: description})
+	return builder
+}
+
+// components returns the components of the document, creating them when absent.
+func (builder *Builder) components() *v303.Components {
+	if builder.openAPI.Components == nil {
+		builder.openAPI.Components = &v303.Components{}
+	}
+	return builder.openAPI.Components
+}
+
+// Schema adds the schema component name, which schema.Ref(name) refers to.
+func (builder *Builder) Schema(name string, s *schema.Builder) *Builder {
+	components := builder.components()
+	if components.Schema == nil {
+		components.Schema = map[string]*v303.SchemaRef{}
+	}
+	components.Schema[name] = builder.schemaRef(s)
+	return builder
+}
+
+// schemaRef returns the schema built by s, recording its error.
+func (builder *Builder) schemaRef(s *schema.Builder) *v303.SchemaRef {
+	if err := s.Err(); err != nil && builder.err == nil {
+		builder.err = err
+	}
+	return s.SchemaRef()
+}
+
+// SecurityScheme adds the security scheme component name.
+func (builder *Builder) SecurityScheme(name string, securityScheme *v303.SecurityScheme) *Builder {
+	components := builder.components()
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = map[string]*v303.SecuritySchemeRef{}
+	}
+	components.SecuritySchemes[name] = &v303.SecuritySchemeRef{Value: securityScheme}
+	return builder
+}
+
+// Security adds an alternative security requirement of every operation, the security scheme name with scopes.
+func (builder *Builder) Security(name string, scopes ...string) *Builder {
+	builder.openAPI.Security = append(builder.openAPI.Security, requirement(name, scopes))
+	return builder
+}
+
+func requirement(name string, scopes []string) v303.SecurityRequirement {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return v303.SecurityRequirement{name: scopes}
+}
+
+// Path returns the builder of the operations of path, a template such as /pets/{id}.
+func (builder *Builder) Path(path string) *PathBuilder {
+	pathItem := builder.openAPI.Paths[path]
+	if pathItem == nil {
+		pathItem = &v303.PathItem{}
+		builder.openAPI.Paths[path] = pathItem
+	}
+	return &PathBuilder{builder: builder, path: path, pathItem: pathItem}
+}
+
+// Build returns the document, or the ValidationErrors of the document when it violates the specification, such as
+// for duplicate operationIds, path parameters not matching the path template or refs to undeclared components. The
+// error of a schema builder, such as for a default value which cannot be encoded, is returned first.
+func (builder *Builder) Build() (*v303.OpenAPI, error) {
+	if builder.err != nil {
+		return nil, builder.err
+	}
+	if validationErrors := builder.openAPI.Validate(context.Background()); len(validationErrors) > 0 {
+		return nil, ValidationErrors(validationErrors)
+	}
+	return builder.openAPI, nil
+}
+
+// ValidationErrors are the violations of the specification of a built document.
+type ValidationErrors []v303.ValidationError
+
+func (validationErrors ValidationErrors) Error() string {
+	messages := make([]string, len(validationErrors))
+	for i, validationError := range validationErrors {
+		messages[i] = validationError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// PathBuilder builds the operations of a path.
+type PathBuilder struct {
+	builder  *Builder
+	path     string
+	pathItem *v303.PathItem
+}
+
+// Summary sets the summary of the operations of the path.
+func (pathBuilder *PathBuilder) Summary(summary string) *PathBuilder {
+	pathBuilder.pathItem.Summary = summary
+	return pathBuilder
+}
+
+// Get builds the GET operation of the path with build.
+func (pathBuilder *PathBuilder) Get(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Get, build)
+}
+
+// Put builds the PUT operation of the path with build.
+func (pathBuilder *PathBuilder) Put(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Put, build)
+}
+
+// Post builds the POST operation of the path with build.
+func (pathBuilder *PathBuilder) Post(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Post, build)
+}
+
+// Delete builds the DELETE operation of the path with build.
+func (pathBuilder *PathBuilder) Delete(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Delete, build)
+}
+
+// Options builds the OPTIONS operation of the path with build.
+func (pathBuilder *PathBuilder) Options(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Options, build)
+}
+
+// Head builds the HEAD operation of the path with build.
+func (pathBuilder *PathBuilder) Head(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Head, build)
+}
+
+// Patch builds the PATCH operation of the path with build.
+func (pathBuilder *PathBuilder) Patch(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Patch, build)
+}
+
+// Trace builds the TRACE operation of the path with build.
+func (pathBuilder *PathBuilder) Trace(build func(op *OperationBuilder)) *PathBuilder {
+	return pathBuilder.operation(&pathBuilder.pathItem.Trace, build)
+}
+
+// operation builds the operation of a method, replacing any previous one.
+func (pathBuilder *PathBuilder) operation(operation **v303.Operation, build func(op *OperationBuilder)) *PathBuilder {
+	*operation = &v303.Operation{Responses: map[string]*v303.ResponseRef{}}
+	build(&OperationBuilder{builder: pathBuilder.builder, operation: *operation})
+	return pathBuilder
+}
+
+// Path returns the builder of the operations of another path of the document.
+func (pathBuilder *PathBuilder) Path(path string) *PathBuilder {
+	return pathBuilder.builder.Path(path)
+}
+
+// Build returns the document, see Builder.Build.
+func (pathBuilder *PathBuilder) Build() (*v303.OpenAPI, error) {
+	return pathBuilder.builder.Build()
+}
+
+// OperationBuilder builds an operation.
+type OperationBuilder struct {
+	builder   *Builder
+	operation *v303.Operation
+}
+
+// Operation returns the operation built, for the fields the builder does not set.
+func (op *OperationBuilder) Operation() *v303.Operation {
+	return op.operation
+}
+
+// ID sets the operationId of the operation, unique in the document.
+func (op *OperationBuilder) ID(operationID string) *OperationBuilder {
+	op.operation.OperationID = operationID
+	return op
+}
+
+// Summary sets the summary of the operation.
+func (op *OperationBuilder) Summary(summary string) *OperationBuilder {
+	op.operation.Summary = summary
+	return op
+}
+
+// Description sets the description of the operation.
+func (op *OperationBuilder) Description(description string) *OperationBuilder {
+	op.operation.Description = description
+	return op
+}
+
+// Tags adds tags to the operation.
+func (op *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	op.operation.Tags = append(op.operation.Tags, tags...)
+	return op
+}
+
+// Deprecated marks the operation as deprecated.
+func (op *OperationBuilder) Deprecated() *OperationBuilder {
+	op.operation.Deprecated = true
+	return op
+}
+
+// Security adds an alternative security requirement of the operation, overriding those of the document.
+func (op *OperationBuilder) Security(name string, scopes ...string) *OperationBuilder {
+	if op.operation.Security == nil {
+		op.operation.Security = &[]v303.SecurityRequirement{}
+	}
+	*op.operation.Security = append(*op.operation.Security, requirement(name, scopes))
+	return op
+}
+
+// NoSecurity removes the security requirements of the document from the operation.
+func (op *OperationBuilder) NoSecurity() *OperationBuilder {
+	op.operation.Security = &[]v303.SecurityRequirement{}
+	return op
+}
+
+// Parameter adds a parameter to the operation.
+func (op *OperationBuilder) Parameter(parameter *v303.Parameter) *OperationBuilder {
+	op.operation.Parameters = append(op.operation.Parameters, &v303.ParameterRef{Value: parameter})
+	return op
+}
+
+// PathParam adds the path parameter name, which path parameters are always required.
+func (op *OperationBuilder) PathParam(name string, s *schema.Builder) *OperationBuilder {
+	return op.Parameter(&v303.Parameter{Name: name, In: "path", Required: true, Schema: op.builder.schemaRef(s)})
+}
+
+// QueryParam adds the optional query parameter name.
+func (op *OperationBuilder) QueryParam(name string, s *schema.Builder) *OperationBuilder {
+	return op.Parameter(&v303.Parameter{Name: name, In: "query", Schema: op.builder.schemaRef(s)})
+}
+
+// RequiredQueryParam adds the required query parameter name.
+func (op *OperationBuilder) RequiredQueryParam(name string, s *schema.Builder) *OperationBuilder {
+	return op.Parameter(&v303.Parameter{Name: name, In: "query", Required: true, Schema: op.builder.schemaRef(s)})
+}
+
+// HeaderParam adds the optional header parameter name.
+func (op *OperationBuilder) HeaderParam(name string, s *schema.Builder) *OperationBuilder {
+	return op.Parameter(&v303.Parameter{Name: name, In: "header", Schema: op.builder.schemaRef(s)})
+}
+
+// RequiredHeaderParam adds the required header parameter name.
+func (op *OperationBuilder) RequiredHeaderParam(name string, s *schema.Builder) *OperationBuilder {
+	return op.Parameter(&v303.Parameter{Name: name, In: "header", Required: true, Schema: op.builder.schemaRef(s)})
+}
+
+// CookieParam adds the optional cookie parameter name.
+func (op *OperationBuilder) CookieParam(name string, s *schema.Builder) *OperationBuilder {
+	return op.Parameter(&v303.Parameter{Name: name, In: "cookie", Schema: op.builder.schemaRef(s)})
+}
+
+// JSONBody sets the required JSON request body of the operation.
+func (op *OperationBuilder) JSONBody(s *schema.Builder) *OperationBuilder {
+	return op.Body(jsonMediaType, s)
+}
+
+// Body sets the required request body of the operation, of mediaType.
+func (op *OperationBuilder) Body(mediaType string, s *schema.Builder) *OperationBuilder {
+	return op.RequestBody(&v303.RequestBody{Required: true, Content: op.content(mediaType, s)})
+}
+
+// RequestBody sets the request body of the operation.
+func (op *OperationBuilder) RequestBody(requestBody *v303.RequestBody) *OperationBuilder {
+	op.operation.RequestBody = &v303.RequestBodyRef{Value: requestBody}
+	return op
+}
+
+// Response sets the response of the operation for status, with a JSON body of schema s unless s is nil.
+func (op *OperationBuilder) Response(status int, description string, s *schema.Builder) *OperationBuilder {
+	return op.ResponseObject(strconv.Itoa(status), op.response(description, jsonMediaType, s))
+}
+
+// ResponseContent sets the response of the operation for status, with a body of mediaType.
+func (op *OperationBuilder) ResponseContent(status int, description, mediaType string, s *schema.Builder) *OperationBuilder {
+	return op.ResponseObject(strconv.Itoa(status), op.response(description, mediaType, s))
+}
+
+// DefaultResponse sets the response of the operation for the status codes without a response, with a JSON body of
+// schema s unless s is nil.
+func (op *OperationBuilder) DefaultResponse(description string, s *schema.Builder) *OperationBuilder {
+	return op.ResponseObject("default", op.response(description, jsonMediaType, s))
+}
+
+// ResponseObject sets the response of the operation for code, a status code, a range such as 4XX or default.
+func (op *OperationBuilder) ResponseObject(code string, response *v303.Response) *OperationBuilder {
+	op.operation.Responses[code] = &v303.ResponseRef{Value: response}
+	return op
+}
+
+func (op *OperationBuilder) response(description, mediaType string, s *schema.Builder) *v303.Response {
+	response := &v303.Response{Description: description}
+	if s != nil {
+		response.Content = op.content(mediaType, s)
+	}
+	return response
+}
+
+func (op *OperationBuilder) content(mediaType string, s *schema.Builder) map[string]*v303.MediaType {
+	return map[string]*v303.MediaType{mediaType: {Schema: op.builder.schemaRef(s)}}
+}
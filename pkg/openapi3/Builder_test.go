@@ -0,0 +1,130 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/schema"
+)
+
+func TestBuild(t *testing.T) {
+	openAPI, err := New("Pets API", "1.0").
+		Description("The pets.").
+		License("MIT", "").
+		Server("https://pets.example.com", "").
+		Tag("pets", "").
+		Schema("Pet", schema.Object().Property("id", schema.Int64()).Required("id")).
+		SecurityScheme("key", &v303.SecurityScheme{Type: "apiKey", Name: "X-Key", In: "header"}).
+		Security("key").
+		Path("/pets/{id}").
+		Get(func(op *OperationBuilder) {
+			op.ID("getPet").Tags("pets").PathParam("id", schema.Int64()).HeaderParam("X-Trace", schema.String()).
+				Response(200, "The pet", schema.Ref("Pet")).DefaultResponse("An error", nil)
+		}).
+		Path("/pets").
+		Post(func(op *OperationBuilder) {
+			op.ID("addPet").NoSecurity().JSONBody(schema.Ref("Pet")).Response(201, "Created", nil)
+		}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(openAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"openapi": "3.0.3",
+		"info": {"title": "Pets API", "description": "The pets.", "license": {"name": "MIT"}, "version": "1.0"},
+		"servers": [{"url": "https://pets.example.com"}],
+		"paths": {
+			"/pets/{id}": {"get": {"tags": ["pets"], "operationId": "getPet",
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "schema": {"type": "integer", "format": "int64"}},
+					{"name": "X-Trace", "in": "header", "schema": {"type": "string"}}],
+				"responses": {
+					"200": {"description": "The pet", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+					"default": {"description": "An error"}}}},
+			"/pets": {"post": {"operationId": "addPet",
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}, "required": true},
+				"responses": {"201": {"description": "Created"}},
+				"security": []}}},
+		"components": {
+			"schemas": {"Pet": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer", "format": "int64"}}}},
+			"securitySchemes": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}}},
+		"security": [{"key": []}],
+		"tags": [{"name": "pets"}]}`
+	var got, wanted interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wanted); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, wanted) {
+		t.Errorf("Build() = %s, want %s", data, want)
+	}
+}
+
+func TestBuildValidationErrors(t *testing.T) {
+	_, err := New("Pets API", "1.0").
+		Path("/pets/{id}").
+		Get(func(op *OperationBuilder) {
+			op.ID("getPet").Response(200, "The pet", schema.Ref("Pet"))
+		}).
+		Path("/pets").
+		Get(func(op *OperationBuilder) {
+			op.ID("getPet").Response(200, "The pets", nil)
+		}).
+		Build()
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("Build() = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Location: "#/paths/~1pets~1{id}/get", Message: `path parameter "id" of "/pets/{id}" is not declared`},
+		{Location: "#/paths/~1pets~1{id}/get/operationId", Message: `operationId "getPet" is already used at #/paths/~1pets/get/operationId`},
+		{Location: "#/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/$ref", Message: `$ref "#/components/schemas/Pet" cannot be resolved`},
+	}
+	if !reflect.DeepEqual(validationErrors, want) {
+		t.Errorf("Build() = %v, want %v", validationErrors, want)
+	}
+}
+
+func TestBuildSchemaError(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(builder *Builder)
+	}{
+		{"component", func(builder *Builder) {
+			builder.Schema("Pet", schema.Object().Property("kind", schema.String().Enum(make(chan int))))
+		}},
+		{"parameter", func(builder *Builder) {
+			builder.Path("/pets").Get(func(op *OperationBuilder) {
+				op.QueryParam("limit", schema.Integer().Default(make(chan int))).Response(200, "OK", nil)
+			})
+		}},
+		{"request body", func(builder *Builder) {
+			builder.Path("/pets").Post(func(op *OperationBuilder) {
+				op.JSONBody(schema.Object().Example(make(chan int))).Response(201, "Created", nil)
+			})
+		}},
+		{"response", func(builder *Builder) {
+			builder.Path("/pets").Get(func(op *OperationBuilder) {
+				op.Response(200, "OK", schema.Array(schema.String().Example(make(chan int))))
+			})
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := New("Pets API", "1.0")
+			test.build(builder)
+			openAPI, err := builder.Build()
+			if openAPI != nil || err == nil || !strings.HasPrefix(err.Error(), "schema: a value of type chan int cannot be encoded") {
+				t.Errorf("Build() = %v, %v, want the error of the schema", openAPI, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/newm4n/swaggo/pkg/runtime"
+)
+
+// parameterLocations are the tags of the fields of a request struct which are parameters.
+var parameterLocations = []string{"path", "query", "header", "cookie"}
+
+// requestField is a field of a request struct which is a parameter or the body.
+type requestField struct {
+	reflect.StructField
+	// in is the location of the parameter, or body.
+	in   string
+	name string
+}
+
+// requestFields returns the parameters and the body of the struct type of a request.
+func requestFields(t reflect.Type) []requestField {
+	var fields []requestField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if field.Name == "Body" {
+			fields = append(fields, requestField{StructField: field, in: "body"})
+			continue
+		}
+		for _, in := range parameterLocations {
+			if name, ok := field.Tag.Lookup(in); ok && name != "" {
+				fields = append(fields, requestField{StructField: field, in: in, name: name})
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// Bind decodes the parameters and the JSON body of r into dest, a pointer to a struct whose fields tagged path,
+// query, header or cookie with the name of a parameter are parameters, and whose field Body is the body:
+//
+//	type GetPetRequest struct {
+//		ID      int64    `path:"id"`
+//		Fields  []string `query:"fields"`
+//		TraceID *string  `header:"X-Trace-Id"`
+//	}
+//
+// Parameters have the default style of their location, and are required unless they are pointers, as the body is.
+// The path variables are read with runtime.PathParameter, which routes registered by a Router support whatever their
+// router. A parameter which cannot be decoded is reported by a *runtime.ParameterError.
+func Bind(r *http.Request, dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("router: Bind of non struct pointer %T", dest)
+	}
+	value = value.Elem()
+	for _, field := range requestFields(value.Type()) {
+		target := value.FieldByIndex(field.Index).Addr().Interface()
+		required := field.Type.Kind() != reflect.Ptr
+		var err error
+		switch field.in {
+		case "path":
+			err = runtime.BindPath(field.name, "simple", false, runtime.PathParameter(r, field.name), target)
+		case "query":
+			err = runtime.BindQuery(field.name, "form", true, required, r.URL.Query(), target)
+		case "header":
+			err = runtime.BindHeader(field.name, false, required, r.Header, target)
+		case "cookie":
+			err = runtime.BindCookie(field.name, true, required, r, target)
+		case "body":
+			err = runtime.BindJSON(r, required, target)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
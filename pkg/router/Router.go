@@ -0,0 +1,294 @@
+// Package router registers the routes of an API on a router along with a description of their operation, and
+// assembles the OpenAPI 3.0.3 document of the routes registered, so that the document comes from the running code:
+//
+//	mux := http.NewServeMux()
+//	api := router.New(openapi3.New("Pets API", "1.0"), router.ServeMux(mux))
+//	api.HandleFunc("GET", "/pets/{id}", router.Operation{
+//		ID:        "getPet",
+//		Request:   GetPetRequest{},
+//		Responses: []router.Response{{Status: 200, Body: Pet{}}},
+//	}, getPet)
+//	mux.Handle("/openapi.json", api.SpecHandler())
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/openapi3"
+	"github.com/newm4n/swaggo/pkg/runtime"
+	"github.com/newm4n/swaggo/pkg/schema"
+)
+
+// Operation describes the operation of a route.
+type Operation struct {
+	ID          string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	// Request is a value of the struct type of the requests, see Bind. It may be nil for operations without parameters
+	// nor body.
+	Request interface{}
+	// Responses are the responses of the operation, at least one is required.
+	Responses []Response
+	// Configure sets the fields of the operation the descriptor does not have, such as its security requirements.
+	Configure func(op *openapi3.OperationBuilder)
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	// Status is the status code of the response, 0 for the default response.
+	Status int
+	// Description is the description of the response, the text of its status when empty.
+	Description string
+	// Body is a value of the type of the JSON body of the response, nil when the response has no body.
+	Body interface{}
+}
+
+// Register registers a route on a router.
+type Register func(route runtime.Route)
+
+// ServeMux returns the Register of mux. The routes sharing a path are dispatched by their method, their path
+// variables are read with runtime.PathParameter.
+func ServeMux(mux *http.ServeMux) Register {
+	return runtime.NewServeMux(mux).Handle
+}
+
+// ChiRouter is the method registering routes of chi.Router.
+type ChiRouter interface {
+	Method(method, pattern string, handler http.Handler)
+}
+
+// Chi returns the Register of a chi router. chi and OpenAPI share the syntax of path templates.
+func Chi(router ChiRouter) Register {
+	return func(route runtime.Route) {
+		router.Method(route.Method, route.Path, runtime.WithPathParameters(route))
+	}
+}
+
+// Gorilla returns the Register calling handle, which registers a route on a gorilla/mux router, such as:
+//
+//	router.Gorilla(func(method, path string, handler http.Handler) {
+//		r.Handle(path, handler).Methods(method)
+//	})
+//
+// gorilla/mux and OpenAPI share the syntax of path templates.
+func Gorilla(handle func(method, path string, handler http.Handler)) Register {
+	return func(route runtime.Route) {
+		handle(route.Method, route.Path, runtime.WithPathParameters(route))
+	}
+}
+
+// Router registers routes and describes their operation in a document.
+type Router struct {
+	// Options are the options of the description of the Go types of requests and responses, which must be set
+	// before the first route is registered.
+	Options  schema.Options
+	register Register
+	mutex    sync.Mutex
+	builder  *openapi3.Builder
+	// reflector describes the types of all the routes, so that their components have distinct names.
+	reflector *schema.Reflector
+	errors    []string
+}
+
+// New returns the Router describing the routes registered with register in the document of builder, which holds the
+// information, servers and security schemes of the API.
+func New(builder *openapi3.Builder, register Register) *Router {
+	return &Router{register: register, builder: builder}
+}
+
+// HandleFunc registers handler as the route of method and path, a template such as /pets/{id}.
+func (router *Router) HandleFunc(method, path string, operation Operation, handler func(w http.ResponseWriter, r *http.Request)) {
+	router.Handle(method, path, operation, http.HandlerFunc(handler))
+}
+
+// Handle registers handler as the route of method and path, a template such as /pets/{id}. A route whose method is
+// not an HTTP method of OpenAPI or whose request is not a struct is not registered, and Build reports it.
+func (router *Router) Handle(method, path string, operation Operation, handler http.Handler) {
+	router.mutex.Lock()
+	err := router.describe(method, path, operation)
+	if err != nil {
+		router.errors = append(router.errors, fmt.Sprintf("%s %s: %v", method, path, err))
+	}
+	router.mutex.Unlock()
+	if err == nil {
+		router.register(runtime.Route{Method: strings.ToUpper(method), Path: path, Handler: handler})
+	}
+}
+
+// Build returns the document of the routes registered, the routes which could not be registered as an error, or the
+// openapi3.ValidationErrors of the document.
+func (router *Router) Build() (*v303.OpenAPI, error) {
+	router.mutex.Lock()
+	defer router.mutex.Unlock()
+	if len(router.errors) > 0 {
+		return nil, fmt.Errorf("router: %s", strings.Join(router.errors, "; "))
+	}
+	return router.builder.Build()
+}
+
+// SpecHandler returns the handler answering the JSON encoding of the document, or 500 Internal Server Error with its
+// validation errors.
+func (router *Router) SpecHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		openAPI, err := router.Build()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data, err := json.Marshal(openAPI)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+// WriteFile writes the document to the file name, in YAML when its extension is .yaml or .yml and in JSON
+// otherwise, for instance from a test or a go:generate command once the routes are registered.
+func (router *Router) WriteFile(name string) error {
+	openAPI, err := router.Build()
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(openAPI)
+	default:
+		data, err = json.MarshalIndent(openAPI, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, 0644)
+}
+
+// operationMethods are the methods of the PathBuilder building the operations of the HTTP methods of OpenAPI.
+var operationMethods = map[string]func(pathBuilder *openapi3.PathBuilder, build func(op *openapi3.OperationBuilder)) *openapi3.PathBuilder{
+	http.MethodGet:     (*openapi3.PathBuilder).Get,
+	http.MethodPut:     (*openapi3.PathBuilder).Put,
+	http.MethodPost:    (*openapi3.PathBuilder).Post,
+	http.MethodDelete:  (*openapi3.PathBuilder).Delete,
+	http.MethodOptions: (*openapi3.PathBuilder).Options,
+	http.MethodHead:    (*openapi3.PathBuilder).Head,
+	http.MethodPatch:   (*openapi3.PathBuilder).Patch,
+	http.MethodTrace:   (*openapi3.PathBuilder).Trace,
+}
+
+// describe adds the operation of a route to the document.
+func (router *Router) describe(method, path string, operation Operation) error {
+	var requestType reflect.Type
+	if operation.Request != nil {
+		requestType = reflect.TypeOf(operation.Request)
+		for requestType.Kind() == reflect.Ptr {
+			requestType = requestType.Elem()
+		}
+		if requestType.Kind() != reflect.Struct {
+			return fmt.Errorf("the request type %s is not a struct", requestType)
+		}
+	}
+	build := func(op *openapi3.OperationBuilder) {
+		op.ID(operation.ID).Summary(operation.Summary).Description(operation.Description).Tags(operation.Tags...)
+		if operation.Deprecated {
+			op.Deprecated()
+		}
+		if requestType != nil {
+			router.describeRequest(op, requestType)
+		}
+		for _, response := range operation.Responses {
+			router.describeResponse(op, response)
+		}
+		if operation.Configure != nil {
+			operation.Configure(op)
+		}
+	}
+	addOperation, ok := operationMethods[strings.ToUpper(method)]
+	if !ok {
+		return fmt.Errorf("%q is not an HTTP method of OpenAPI", method)
+	}
+	addOperation(router.builder.Path(path), build)
+	router.addComponents()
+	return nil
+}
+
+// describeRequest adds the parameters and the body of the struct type of a request to an operation.
+func (router *Router) describeRequest(op *openapi3.OperationBuilder, t reflect.Type) {
+	for _, field := range requestFields(t) {
+		optional := field.Type.Kind() == reflect.Ptr
+		if optional {
+			field.Type = field.Type.Elem()
+		}
+		fieldSchema, _ := router.typeReflector().Field(field.StructField)
+		required := !optional
+		if field.in == "body" {
+			op.RequestBody(&v303.RequestBody{
+				Required: required,
+				Content:  map[string]*v303.MediaType{"application/json": {Schema: fieldSchema}},
+			})
+			continue
+		}
+		op.Parameter(&v303.Parameter{
+			Name:     field.name,
+			In:       field.in,
+			Required: required || field.in == "path",
+			Schema:   fieldSchema,
+		})
+	}
+}
+
+// describeResponse adds a response to an operation.
+func (router *Router) describeResponse(op *openapi3.OperationBuilder, response Response) {
+	code, description := "default", response.Description
+	if response.Status != 0 {
+		code = strconv.Itoa(response.Status)
+		if description == "" {
+			description = http.StatusText(response.Status)
+		}
+	}
+	if description == "" {
+		description = "Unexpected response"
+	}
+	object := &v303.Response{Description: description}
+	if response.Body != nil {
+		object.Content = map[string]*v303.MediaType{"application/json": {Schema: router.typeSchema(reflect.TypeOf(response.Body))}}
+	}
+	op.ResponseObject(code, object)
+}
+
+// typeSchema returns the schema of t, a reference when t is described by a component.
+func (router *Router) typeSchema(t reflect.Type) *v303.SchemaRef {
+	return router.typeReflector().Type(t)
+}
+
+// typeReflector returns the reflector of the routes, created with the options of the router on first use.
+func (router *Router) typeReflector() *schema.Reflector {
+	if router.reflector == nil {
+		router.reflector = schema.NewReflector(router.Options)
+	}
+	return router.reflector
+}
+
+// addComponents adds the schema components of the types described so far to the document.
+func (router *Router) addComponents() {
+	if router.reflector == nil {
+		return
+	}
+	for name, component := range router.reflector.Components() {
+		router.builder.Schema(name, schema.Wrap(&v303.SchemaRef{Value: component}))
+	}
+}
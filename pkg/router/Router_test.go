@@ -0,0 +1,169 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+	"github.com/newm4n/swaggo/pkg/openapi3"
+	a "github.com/newm4n/swaggo/pkg/router/testdata/a/model"
+	b "github.com/newm4n/swaggo/pkg/router/testdata/b/model"
+)
+
+type getPetRequest struct {
+	ID     int64    `path:"id"`
+	Fields []string `query:"fields"`
+	Trace  *string  `header:"X-Trace-Id"`
+}
+
+type addPetRequest struct {
+	Body a.Pet
+}
+
+func TestRouter(t *testing.T) {
+	mux := http.NewServeMux()
+	api := New(openapi3.New("Pets API", "1.0"), ServeMux(mux))
+	var bound getPetRequest
+	api.HandleFunc("GET", "/pets/{id}", Operation{
+		ID:        "getPet",
+		Request:   getPetRequest{},
+		Responses: []Response{{Status: 200, Body: a.Pet{}}, {Description: "An error"}},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if err := Bind(r, &bound); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	})
+	api.HandleFunc("post", "/pets", Operation{
+		ID:        "addPet",
+		Request:   &addPetRequest{},
+		Responses: []Response{{Status: 201}},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets/42?fields=name&fields=age", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /pets/42: status = %d, body %s", w.Code, w.Body)
+	}
+	if want := (getPetRequest{ID: 42, Fields: []string{"name", "age"}}); !reflect.DeepEqual(bound, want) {
+		t.Errorf("bound %+v, want %+v", bound, want)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name": "rex"}`)))
+	if w.Code != http.StatusCreated {
+		t.Errorf("POST /pets: status = %d, want 201", w.Code)
+	}
+
+	openAPI, err := api.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	getPet := openAPI.Paths["/pets/{id}"].Get
+	var parameters []string
+	for _, parameter := range getPet.Parameters {
+		parameters = append(parameters, parameter.Value.In+" "+parameter.Value.Name+" "+parameter.Value.Schema.Value.Type)
+		if required := parameter.Value.Name != "X-Trace-Id"; parameter.Value.Required != required {
+			t.Errorf("%s required = %t, want %t", parameter.Value.Name, parameter.Value.Required, required)
+		}
+	}
+	if want := []string{"path id integer", "query fields array", "header X-Trace-Id string"}; !reflect.DeepEqual(parameters, want) {
+		t.Errorf("parameters = %q, want %q", parameters, want)
+	}
+	if response := getPet.Responses["200"].Value; response.Description != "OK" ||
+		response.Content["application/json"].Schema.Ref != "#/components/schemas/model.Pet" {
+		t.Errorf("response 200 = %+v", response)
+	}
+	if response := getPet.Responses["default"].Value; response.Description != "An error" || response.Content != nil {
+		t.Errorf("default response = %+v", response)
+	}
+	addPet := openAPI.Paths["/pets"].Post
+	if body := addPet.RequestBody.Value; !body.Required || body.Content["application/json"].Schema.Ref != "#/components/schemas/model.Pet" {
+		t.Errorf("request body = %+v", body)
+	}
+	if len(openAPI.Components.Schema) != 1 {
+		t.Errorf("components = %v, want model.Pet", openAPI.Components.Schema)
+	}
+
+	w = httptest.NewRecorder()
+	api.SpecHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var served v303.OpenAPI
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("SpecHandler: %v, Content-Type %q", err, w.Header().Get("Content-Type"))
+	}
+	if served.Paths["/pets"] == nil || served.Paths["/pets/{id}"] == nil {
+		t.Errorf("SpecHandler served the paths %v", served.Paths)
+	}
+}
+
+// TestRouterNameCollision checks that the types sharing a name in the routes of a router are given distinct
+// components.
+func TestRouterNameCollision(t *testing.T) {
+	api := New(openapi3.New("Pets API", "1.0"), ServeMux(http.NewServeMux()))
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	api.HandleFunc("GET", "/a", Operation{ID: "getA", Responses: []Response{{Status: 200, Body: a.Pet{}}}}, handler)
+	api.HandleFunc("GET", "/b", Operation{ID: "getB", Responses: []Response{{Status: 200, Body: []b.Pet{}}}}, handler)
+	api.HandleFunc("GET", "/c", Operation{ID: "getC", Responses: []Response{{Status: 200, Body: &a.Pet{}}}}, handler)
+	openAPI, err := api.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := openAPI.Components.Schema
+	if len(schemas) != 2 || schemas["model.Pet"].Value.Properties["name"] == nil ||
+		schemas["b.model.Pet"].Value.Properties["age"] == nil {
+		t.Fatalf("components = %v, want model.Pet with a name and b.model.Pet with an age", schemas)
+	}
+	schemaOf := func(path string) *v303.SchemaRef {
+		return openAPI.Paths[path].Get.Responses["200"].Value.Content["application/json"].Schema
+	}
+	if ref := schemaOf("/a").Ref; ref != "#/components/schemas/model.Pet" {
+		t.Errorf("/a refers to %s", ref)
+	}
+	if ref := schemaOf("/b").Value.Items.Ref; ref != "#/components/schemas/b.model.Pet" {
+		t.Errorf("/b refers to %s", ref)
+	}
+	if c := schemaOf("/c").Value; !c.Nullable || c.AllOf[0].Ref != "#/components/schemas/model.Pet" {
+		t.Errorf("/c refers to %s", c.AllOf[0].Ref)
+	}
+}
+
+func TestRouterErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	api := New(openapi3.New("Pets API", "1.0"), ServeMux(mux))
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	api.HandleFunc("FETCH", "/pets", Operation{ID: "fetchPets", Responses: []Response{{Status: 200}}}, handler)
+	api.HandleFunc("GET", "/pets/{id}", Operation{ID: "getPet", Request: 42, Responses: []Response{{Status: 200}}}, handler)
+	_, err := api.Build()
+	want := `router: FETCH /pets: "FETCH" is not an HTTP method of OpenAPI; GET /pets/{id}: the request type int is not a struct`
+	if err == nil || err.Error() != want {
+		t.Errorf("Build() = %v, want %s", err, want)
+	}
+	for _, target := range []string{"/pets", "/pets/1"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("GET %s: status = %d, want the route not registered", target, w.Code)
+		}
+	}
+	w := httptest.NewRecorder()
+	api.SpecHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "is not a struct") {
+		t.Errorf("SpecHandler: %d %s, want the error", w.Code, w.Body)
+	}
+}
+
+func TestRouterValidationErrors(t *testing.T) {
+	api := New(openapi3.New("Pets API", "1.0"), ServeMux(http.NewServeMux()))
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	api.HandleFunc("GET", "/pets", Operation{ID: "getPets", Responses: []Response{{Status: 200}}}, handler)
+	api.HandleFunc("GET", "/pets/{id}", Operation{ID: "getPets", Responses: []Response{{Status: 200}}}, handler)
+	if _, err := api.Build(); err == nil {
+		t.Error("Build() = nil, want the duplicate operationId")
+	} else if _, ok := err.(openapi3.ValidationErrors); !ok {
+		t.Errorf("Build() = %T %v, want openapi3.ValidationErrors", err, err)
+	}
+}
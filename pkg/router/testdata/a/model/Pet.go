@@ -0,0 +1,7 @@
+// Package model is a package of pets, whose type Pet shares its name with the type of another package model.
+package model
+
+// Pet is a pet with a name.
+type Pet struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,418 @@
+// Package runtime is used by the code generated by swaggo gen: it binds and serializes parameters according to their
+// OpenAPI style and routes requests to operations.
+package runtime
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrMissing is wrapped by the ParameterError of a required parameter or body which is absent.
+var ErrMissing = errors.New("required value is missing")
+
+// ParameterError is returned when a parameter or a request body cannot be bound.
+type ParameterError struct {
+	// Name is the name of the parameter, empty for the body.
+	Name string
+	// In is the location of the parameter, or body.
+	In  string
+	Err error
+}
+
+func (parameterError *ParameterError) Error() string {
+	if parameterError.In == "body" {
+		return fmt.Sprintf("request body: %v", parameterError.Err)
+	}
+	return fmt.Sprintf("%s parameter %q: %v", parameterError.In, parameterError.Name, parameterError.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (parameterError *ParameterError) Unwrap() error {
+	return parameterError.Err
+}
+
+// DefaultErrorHandler answers 400 Bad Request to a ParameterError and 500 Internal Server Error to any other error.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var parameterError *ParameterError
+	if errors.As(err, &parameterError) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// BindPath decodes the value of a path parameter serialized with the simple, label or matrix style into dest, which
+// is a pointer.
+// http://spec.openapis.org/oas/v3.0.3#style-values
+func BindPath(name, style string, explode bool, value string, dest interface{}) error {
+	if value == "" {
+		return &ParameterError{name, "path", ErrMissing}
+	}
+	var err error
+	switch style {
+	case "", "simple":
+		err = bindDelimited(value, ",", explode, dest)
+	case "label":
+		if !strings.HasPrefix(value, ".") {
+			err = fmt.Errorf("label style value %q does not start with '.'", value)
+			break
+		}
+		separator := ","
+		if explode {
+			separator = "."
+		}
+		err = bindDelimited(value[1:], separator, explode, dest)
+	case "matrix":
+		err = bindMatrix(name, value, explode, dest)
+	default:
+		err = fmt.Errorf("style %q does not apply to path parameters", style)
+	}
+	if err != nil {
+		return &ParameterError{name, "path", err}
+	}
+	return nil
+}
+
+// BindQuery decodes a query parameter serialized with the form, spaceDelimited, pipeDelimited or deepObject style
+// into dest, which is a pointer. dest is left unchanged when the parameter is absent and not required.
+func BindQuery(name, style string, explode, required bool, query url.Values, dest interface{}) error {
+	err := bindQuery(name, style, explode, query, dest)
+	if errors.Is(err, ErrMissing) && !required {
+		return nil
+	}
+	if err != nil {
+		return &ParameterError{name, "query", err}
+	}
+	return nil
+}
+
+func bindQuery(name, style string, explode bool, query url.Values, dest interface{}) error {
+	target := reflect.ValueOf(dest).Elem()
+	kind := kindOf(target.Type())
+	if style == "deepObject" {
+		if kind != objectKind {
+			return fmt.Errorf("style deepObject only applies to objects")
+		}
+		var members [][2]string
+		for key, values := range query {
+			if strings.HasPrefix(key, name+"[") && strings.HasSuffix(key, "]") && len(values) > 0 {
+				members = append(members, [2]string{key[len(name)+1 : len(key)-1], values[0]})
+			}
+		}
+		if len(members) == 0 {
+			return ErrMissing
+		}
+		return setObject(target, members)
+	}
+	if explode && kind == objectKind {
+		// Every member is a query parameter of its own.
+		var members [][2]string
+		for key, values := range query {
+			if len(values) > 0 && hasMember(target.Type(), key) {
+				members = append(members, [2]string{key, values[0]})
+			}
+		}
+		if len(members) == 0 {
+			return ErrMissing
+		}
+		return setObject(target, members)
+	}
+	values, ok := query[name]
+	if !ok || len(values) == 0 {
+		return ErrMissing
+	}
+	if explode && kind == arrayKind {
+		return setArray(target, values)
+	}
+	separator := ","
+	switch style {
+	case "", "form":
+	case "spaceDelimited":
+		separator = " "
+	case "pipeDelimited":
+		separator = "|"
+	default:
+		return fmt.Errorf("style %q does not apply to query parameters", style)
+	}
+	return bindDelimited(values[0], separator, false, dest)
+}
+
+// BindHeader decodes a header serialized with the simple style into dest, which is a pointer. dest is left unchanged
+// when the header is absent and not required.
+func BindHeader(name string, explode, required bool, header http.Header, dest interface{}) error {
+	values := header.Values(name)
+	if len(values) == 0 {
+		if required {
+			return &ParameterError{name, "header", ErrMissing}
+		}
+		return nil
+	}
+	if err := bindDelimited(strings.Join(values, ","), ",", explode, dest); err != nil {
+		return &ParameterError{name, "header", err}
+	}
+	return nil
+}
+
+// BindCookie decodes a cookie serialized with the form style into dest, which is a pointer. dest is left unchanged
+// when the cookie is absent and not required.
+func BindCookie(name string, explode, required bool, request *http.Request, dest interface{}) error {
+	cookie, err := request.Cookie(name)
+	if err != nil {
+		if required {
+			return &ParameterError{name, "cookie", ErrMissing}
+		}
+		return nil
+	}
+	value, err := url.QueryUnescape(cookie.Value)
+	if err != nil {
+		value = cookie.Value
+	}
+	if err := bindDelimited(value, ",", explode, dest); err != nil {
+		return &ParameterError{name, "cookie", err}
+	}
+	return nil
+}
+
+// BindJSON decodes a JSON request body into dest, which is a pointer. dest is left unchanged when the body is empty
+// and not required.
+func BindJSON(request *http.Request, required bool, dest interface{}) error {
+	if request.Body == nil || request.Body == http.NoBody {
+		if required {
+			return &ParameterError{In: "body", Err: ErrMissing}
+		}
+		return nil
+	}
+	err := json.NewDecoder(request.Body).Decode(dest)
+	if err == io.EOF {
+		if required {
+			return &ParameterError{In: "body", Err: ErrMissing}
+		}
+		return nil
+	}
+	if err != nil {
+		return &ParameterError{In: "body", Err: err}
+	}
+	return nil
+}
+
+// bindMatrix decodes a matrix style value, such as ";id=3,4,5" or ";id=3;id=4".
+func bindMatrix(name, value string, explode bool, dest interface{}) error {
+	if !strings.HasPrefix(value, ";") {
+		return fmt.Errorf("matrix style value %q does not start with ';'", value)
+	}
+	target := reflect.ValueOf(dest).Elem()
+	parts := strings.Split(value[1:], ";")
+	switch kind := kindOf(target.Type()); {
+	case explode && kind == objectKind:
+		return bindDelimited(strings.Join(parts, ","), ",", true, dest)
+	case explode && kind == arrayKind:
+		values := make([]string, len(parts))
+		for i, part := range parts {
+			if !strings.HasPrefix(part, name+"=") {
+				return fmt.Errorf("matrix style value %q does not name %s", value, name)
+			}
+			values[i] = part[len(name)+1:]
+		}
+		return setArray(target, values)
+	}
+	if len(parts) != 1 || !strings.HasPrefix(parts[0], name+"=") {
+		return fmt.Errorf("matrix style value %q does not name %s", value, name)
+	}
+	return bindDelimited(parts[0][len(name)+1:], ",", false, dest)
+}
+
+// bindDelimited decodes a value whose array elements or object members are separated by separator. Exploded object
+// members are key=value pairs, the others alternate keys and values.
+func bindDelimited(value, separator string, explode bool, dest interface{}) error {
+	target := reflect.ValueOf(dest).Elem()
+	switch kindOf(target.Type()) {
+	case arrayKind:
+		if value == "" {
+			return setArray(target, nil)
+		}
+		return setArray(target, strings.Split(value, separator))
+	case objectKind:
+		parts := strings.Split(value, separator)
+		var members [][2]string
+		if explode {
+			for _, part := range parts {
+				keyValue := strings.SplitN(part, "=", 2)
+				if len(keyValue) != 2 {
+					return fmt.Errorf("member %q is not a key=value pair", part)
+				}
+				members = append(members, [2]string{keyValue[0], keyValue[1]})
+			}
+		} else {
+			if len(parts)%2 != 0 {
+				return fmt.Errorf("value %q does not alternate keys and values", value)
+			}
+			for i := 0; i < len(parts); i += 2 {
+				members = append(members, [2]string{parts[i], parts[i+1]})
+			}
+		}
+		return setObject(target, members)
+	}
+	return setValue(target, value)
+}
+
+type valueKind int
+
+const (
+	primitiveKind valueKind = iota
+	arrayKind
+	objectKind
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// kindOf returns how values of t are serialized.
+func kindOf(t reflect.Type) valueKind {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return primitiveKind
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return primitiveKind
+		}
+		return arrayKind
+	case reflect.Map, reflect.Struct:
+		return objectKind
+	}
+	return primitiveKind
+}
+
+// allocate returns the value pointed to by v, allocating the pointers on the way.
+func allocate(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// setValue converts a primitive value to the type of v.
+func setValue(v reflect.Value, value string) error {
+	v = allocate(v)
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		converted, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		v.SetBool(converted)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		converted, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an integer of %d bits", value, v.Type().Bits())
+		}
+		v.SetInt(converted)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		converted, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an unsigned integer of %d bits", value, v.Type().Bits())
+		}
+		v.SetUint(converted)
+	case reflect.Float32, reflect.Float64:
+		converted, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		v.SetFloat(converted)
+	case reflect.Slice:
+		// A byte slice is base64 encoded.
+		converted, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("%q is not base64 encoded", value)
+		}
+		v.SetBytes(converted)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(value))
+	default:
+		return fmt.Errorf("cannot bind a %s", v.Type())
+	}
+	return nil
+}
+
+// setArray converts the elements of an array to the element type of the slice v.
+func setArray(v reflect.Value, values []string) error {
+	v = allocate(v)
+	slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := setValue(slice.Index(i), value); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// setObject sets the members of a map, or the fields of a struct named by their json tag.
+func setObject(v reflect.Value, members [][2]string) error {
+	v = allocate(v)
+	if v.Kind() == reflect.Map {
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for _, member := range members {
+			element := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(element, member[1]); err != nil {
+				return fmt.Errorf("%s: %w", member[0], err)
+			}
+			v.SetMapIndex(reflect.ValueOf(member[0]).Convert(v.Type().Key()), element)
+		}
+		return nil
+	}
+	for _, member := range members {
+		index := fieldIndex(v.Type(), member[0])
+		if index < 0 {
+			return fmt.Errorf("unknown member %q", member[0])
+		}
+		if err := setValue(v.Field(index), member[1]); err != nil {
+			return fmt.Errorf("%s: %w", member[0], err)
+		}
+	}
+	return nil
+}
+
+// hasMember reports whether a key is a member of the objects of type t.
+func hasMember(t reflect.Type, key string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map || fieldIndex(t, key) >= 0
+}
+
+// fieldIndex returns the index of the field of a struct named key by its json tag or its name, -1 if there is none.
+func fieldIndex(t reflect.Type, key string) int {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == key || name == "" && strings.EqualFold(field.Name, key) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,200 @@
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindPath(t *testing.T) {
+	var (
+		id      int64
+		ids     []int32
+		ratio   float32
+		enabled *bool
+		when    time.Time
+		members map[string]int
+		values  color
+	)
+	tests := []struct {
+		style   string
+		explode bool
+		value   string
+		dest    interface{}
+		want    interface{}
+		err     string
+	}{
+		{"simple", false, "42", &id, int64(42), ""},
+		{"", false, "42", &id, int64(42), ""},
+		{"simple", false, "1,2", &ids, []int32{1, 2}, ""},
+		{"simple", false, "0.5", &ratio, float32(0.5), ""},
+		{"simple", false, "true", &enabled, func() *bool { b := true; return &b }(), ""},
+		{"simple", false, "2020-10-20T10:00:00Z", &when, time.Date(2020, 10, 20, 10, 0, 0, 0, time.UTC), ""},
+		{"simple", true, "a=1,b=2", &members, map[string]int{"a": 1, "b": 2}, ""},
+		{"label", true, ".R=1.G=2.B=3", &values, color{1, 2, 3}, ""},
+		{"matrix", false, ";id=7", &id, int64(7), ""},
+		{"simple", false, "", &id, nil, `path parameter "id": required value is missing`},
+		{"simple", false, "x", &id, nil, `path parameter "id": "x" is not an integer of 64 bits`},
+		{"simple", false, "1,x", &ids, nil, `path parameter "id": "x" is not an integer of 32 bits`},
+		{"simple", false, "yes", &enabled, nil, `path parameter "id": "yes" is not a boolean`},
+		{"simple", false, "a,1,b", &members, nil, `path parameter "id": value "a,1,b" does not alternate keys and values`},
+		{"simple", true, "a", &members, nil, `path parameter "id": member "a" is not a key=value pair`},
+		{"simple", true, "R=1,A=2", &values, nil, `path parameter "id": unknown member "A"`},
+		{"simple", true, "R=x", &values, nil, `path parameter "id": R: "x" is not an integer of 64 bits`},
+		{"label", false, "42", &id, nil, `path parameter "id": label style value "42" does not start with '.'`},
+		{"matrix", false, "id=42", &id, nil, `path parameter "id": matrix style value "id=42" does not start with ';'`},
+		{"matrix", false, ";other=42", &id, nil, `path parameter "id": matrix style value ";other=42" does not name id`},
+		{"form", false, "42", &id, nil, `path parameter "id": style "form" does not apply to path parameters`},
+	}
+	for _, test := range tests {
+		reflect.ValueOf(test.dest).Elem().Set(reflect.Zero(reflect.TypeOf(test.dest).Elem()))
+		err := BindPath("id", test.style, test.explode, test.value, test.dest)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("BindPath(%s, %t, %q) = %v, want %s", test.style, test.explode, test.value, err, test.err)
+			}
+			continue
+		}
+		if got := reflect.ValueOf(test.dest).Elem().Interface(); err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("BindPath(%s, %t, %q) = %v, %v, want %v", test.style, test.explode, test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	type filter struct {
+		Name *string `json:"name"`
+		Age  *int    `json:"age"`
+	}
+	name, age := "rex", 3
+	tests := []struct {
+		name     string
+		style    string
+		explode  bool
+		required bool
+		query    string
+		want     interface{}
+		err      string
+	}{
+		{"exploded array", "form", true, false, "tag=a&tag=b", []string{"a", "b"}, ""},
+		{"array", "form", false, false, "tag=a,b", []string{"a", "b"}, ""},
+		{"empty array", "form", false, false, "tag=", []string{}, ""},
+		{"absent", "form", true, false, "other=1", []string(nil), ""},
+		{"absent and required", "form", true, true, "other=1", nil, `query parameter "tag": required value is missing`},
+		{"exploded object", "form", true, false, "name=rex&age=3&other=1", filter{&name, &age}, ""},
+		{"partial object", "form", true, false, "name=rex", filter{Name: &name}, ""},
+		{"absent object", "form", true, false, "other=1", filter{}, ""},
+		{"deepObject", "deepObject", true, false, "tag[name]=rex&tag[age]=3", filter{&name, &age}, ""},
+		{"deepObject unknown member", "deepObject", true, false, "tag[size]=3", filter{}, `query parameter "tag": unknown member "size"`},
+		{"deepObject of an array", "deepObject", true, false, "tag[0]=a", []string(nil), `query parameter "tag": style deepObject only applies to objects`},
+		{"spaceDelimited", "spaceDelimited", false, false, "tag=a%20b", []string{"a", "b"}, ""},
+		{"pipeDelimited", "pipeDelimited", false, false, "tag=a|b", []string{"a", "b"}, ""},
+		{"unknown style", "matrix", false, false, "tag=a", []string(nil), `query parameter "tag": style "matrix" does not apply to query parameters`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			destType := reflect.TypeOf([]string(nil))
+			if test.want != nil {
+				destType = reflect.TypeOf(test.want)
+			}
+			dest := reflect.New(destType)
+			err = BindQuery("tag", test.style, test.explode, test.required, query, dest.Interface())
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Errorf("BindQuery(%q) = %v, want %s", test.query, err, test.err)
+				}
+				return
+			}
+			if got := dest.Elem().Interface(); err != nil || !reflect.DeepEqual(got, test.want) {
+				t.Errorf("BindQuery(%q) = %#v, %v, want %#v", test.query, got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestBindHeaderAndCookieMissing(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	value := 1
+	if err := BindHeader("X-Limit", false, false, request.Header, &value); err != nil || value != 1 {
+		t.Errorf("BindHeader(optional) = %d, %v, want 1 unchanged", value, err)
+	}
+	if err := BindCookie("limit", false, false, request, &value); err != nil || value != 1 {
+		t.Errorf("BindCookie(optional) = %d, %v, want 1 unchanged", value, err)
+	}
+	if err := BindHeader("X-Limit", false, true, request.Header, &value); !errors.Is(err, ErrMissing) {
+		t.Errorf("BindHeader(required) = %v, want ErrMissing", err)
+	}
+	if err := BindCookie("limit", false, true, request, &value); !errors.Is(err, ErrMissing) {
+		t.Errorf("BindCookie(required) = %v, want ErrMissing", err)
+	}
+
+	// The values of repeated headers are joined.
+	request.Header.Add("X-Ids", "1,2")
+	request.Header.Add("X-Ids", "3")
+	var ids []int
+	if err := BindHeader("X-Ids", false, true, request.Header, &ids); err != nil || !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Errorf("BindHeader(X-Ids) = %v, %v, want [1 2 3]", ids, err)
+	}
+}
+
+func TestBindJSON(t *testing.T) {
+	type pet struct {
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		name     string
+		body     string
+		required bool
+		want     pet
+		err      string
+	}{
+		{"body", `{"name": "rex"}`, true, pet{"rex"}, ""},
+		{"empty", ``, false, pet{"unchanged"}, ""},
+		{"empty and required", ``, true, pet{}, "request body: required value is missing"},
+		{"invalid", `{"name": `, false, pet{}, "request body: unexpected EOF"},
+		{"mistyped", `{"name": 1}`, false, pet{}, "request body: json: cannot unmarshal number into Go struct field pet.name of type string"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(test.body))
+			got := pet{"unchanged"}
+			err := BindJSON(request, test.required, &got)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Errorf("BindJSON(%q) = %v, want %s", test.body, err, test.err)
+				}
+				return
+			}
+			if err != nil || got != test.want {
+				t.Errorf("BindJSON(%q) = %v, %v, want %v", test.body, got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestDefaultErrorHandler(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{&ParameterError{"limit", "query", ErrMissing}, http.StatusBadRequest},
+		{&ParameterError{In: "body", Err: ErrMissing}, http.StatusBadRequest},
+		{errors.New("database down"), http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		DefaultErrorHandler(w, httptest.NewRequest(http.MethodGet, "/", nil), test.err)
+		if w.Code != test.status || strings.TrimSpace(w.Body.String()) != test.err.Error() {
+			t.Errorf("DefaultErrorHandler(%v) = %d %q, want %d", test.err, w.Code, w.Body.String(), test.status)
+		}
+	}
+}
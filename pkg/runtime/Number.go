@@ -0,0 +1,20 @@
+package runtime
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// IsMultipleOf reports whether value is a multiple of multipleOf, a JSON number. value is taken as the shortest
+// decimal which parses to it, so that a decimal multipleOf does not drift: 0.29 is a multiple of 0.01 even though
+// neither has an exact binary representation. It reports false for a multipleOf which is not a positive number.
+// http://spec.openapis.org/oas/v3.0.3#properties
+func IsMultipleOf(value float64, multipleOf string) bool {
+	divisor, ok := new(big.Rat).SetString(multipleOf)
+	if !ok || divisor.Sign() <= 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return false
+	}
+	dividend, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	return ok && dividend.Quo(dividend, divisor).IsInt()
+}
@@ -0,0 +1,149 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Route is the handler of an operation, matched by its method and path template such as /pets/{id}.
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.Handler
+}
+
+// pathTemplate is a compiled path template.
+type pathTemplate struct {
+	route   Route
+	pattern *regexp.Regexp
+	names   []string
+}
+
+// templateVariable matches the variables of a path template.
+var templateVariable = regexp.MustCompile(`\{([^{}]+)\}`)
+
+func compileTemplate(route Route) pathTemplate {
+	template := pathTemplate{route: route}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, match := range templateVariable.FindAllStringSubmatchIndex(route.Path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(route.Path[last:match[0]]))
+		pattern.WriteString("([^/]+)")
+		template.names = append(template.names, route.Path[match[2]:match[3]])
+		last = match[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(route.Path[last:]) + "$")
+	template.pattern = regexp.MustCompile(pattern.String())
+	return template
+}
+
+// muxPattern returns the ServeMux pattern of a path template: the path itself without variables, otherwise the
+// subtree of its constant prefix.
+func muxPattern(path string) string {
+	variable := strings.Index(path, "{")
+	if variable < 0 {
+		return path
+	}
+	return path[:strings.LastIndex(path[:variable], "/")+1]
+}
+
+type pathParametersKey struct{}
+
+// RegisterServeMux registers routes on mux. ServeMux only matches constant paths and subtrees, so the routes sharing
+// a pattern share a handler which matches their templates, answering 404 Not Found or 405 Method Not Allowed when
+// none does. The values of the path variables are stored in the request context, where PathParameter reads them.
+func RegisterServeMux(mux *http.ServeMux, routes []Route) {
+	serveMux := NewServeMux(mux)
+	for _, route := range routes {
+		serveMux.Handle(route)
+	}
+}
+
+// ServeMux registers routes on an http.ServeMux one at a time, as RegisterServeMux does.
+type ServeMux struct {
+	mux       *http.ServeMux
+	mutex     sync.RWMutex
+	templates map[string][]pathTemplate
+}
+
+// NewServeMux returns the ServeMux registering routes on mux.
+func NewServeMux(mux *http.ServeMux) *ServeMux {
+	return &ServeMux{mux: mux, templates: map[string][]pathTemplate{}}
+}
+
+// Handle registers route, which may share its ServeMux pattern with the routes registered before.
+func (serveMux *ServeMux) Handle(route Route) {
+	pattern := muxPattern(route.Path)
+	serveMux.mutex.Lock()
+	_, registered := serveMux.templates[pattern]
+	serveMux.templates[pattern] = append(serveMux.templates[pattern], compileTemplate(route))
+	serveMux.mutex.Unlock()
+	if !registered {
+		serveMux.mux.Handle(pattern, serveMux.templateHandler(pattern))
+	}
+}
+
+// templateHandler dispatches requests to the first route of pattern matching their path and method.
+func (serveMux *ServeMux) templateHandler(pattern string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveMux.mutex.RLock()
+		templates := serveMux.templates[pattern]
+		serveMux.mutex.RUnlock()
+		var allowed []string
+		for _, template := range templates {
+			parameters, ok := template.match(r.URL.Path)
+			if !ok {
+				continue
+			}
+			if !strings.EqualFold(template.route.Method, r.Method) {
+				allowed = append(allowed, strings.ToUpper(template.route.Method))
+				continue
+			}
+			template.route.Handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), pathParametersKey{}, parameters)))
+			return
+		}
+		if len(allowed) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	})
+}
+
+// match returns the values of the variables of the template when it matches path.
+func (template pathTemplate) match(path string) (map[string]string, bool) {
+	match := template.pattern.FindStringSubmatch(path)
+	if match == nil {
+		return nil, false
+	}
+	parameters := make(map[string]string, len(template.names))
+	for i, name := range template.names {
+		parameters[name] = match[i+1]
+	}
+	return parameters, true
+}
+
+// WithPathParameters stores the values of the variables of the path template of route in the context of the
+// requests whose path matches it, where PathParameter reads them, for routers other than ServeMux which match path
+// templates themselves.
+func WithPathParameters(route Route) http.Handler {
+	template := compileTemplate(route)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if parameters, ok := template.match(r.URL.Path); ok {
+			r = r.WithContext(context.WithValue(r.Context(), pathParametersKey{}, parameters))
+		}
+		template.route.Handler.ServeHTTP(w, r)
+	})
+}
+
+// PathParameter returns the value of a path variable of a request routed by RegisterServeMux, ServeMux or
+// WithPathParameters.
+func PathParameter(r *http.Request, name string) string {
+	parameters, _ := r.Context().Value(pathParametersKey{}).(map[string]string)
+	return parameters[name]
+}
@@ -0,0 +1,124 @@
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTemplateMatch(t *testing.T) {
+	tests := []struct {
+		template string
+		path     string
+		want     map[string]string
+	}{
+		{"/pets", "/pets", map[string]string{}},
+		{"/pets", "/pets/", nil},
+		{"/pets/{id}", "/pets/42", map[string]string{"id": "42"}},
+		{"/pets/{id}", "/pets/", nil},
+		{"/pets/{id}", "/pets/42/owner", nil},
+		{"/pets/{id}/owner", "/pets/42/owner", map[string]string{"id": "42"}},
+		{"/owners/{owner}/pets/{pet}", "/owners/ann/pets/rex", map[string]string{"owner": "ann", "pet": "rex"}},
+		{"/files/{name}.{ext}", "/files/report.pdf", map[string]string{"name": "report", "ext": "pdf"}},
+		{"/v1.0/{id}", "/v1x0/42", nil},
+		{"/pets/{id}", "/pets/a b", map[string]string{"id": "a b"}},
+	}
+	for _, test := range tests {
+		got, ok := compileTemplate(Route{Path: test.template}).match(test.path)
+		if ok != (test.want != nil) || ok && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s matches %s = %v, %t, want %v", test.template, test.path, got, ok, test.want)
+		}
+	}
+}
+
+func TestMuxPattern(t *testing.T) {
+	tests := map[string]string{
+		"/pets":                      "/pets",
+		"/pets/{id}":                 "/pets/",
+		"/pets/{id}/owner":           "/pets/",
+		"/{id}":                      "/",
+		"/owners/{owner}/pets/{pet}": "/owners/",
+		"/files/report.{ext}":        "/files/",
+	}
+	for path, want := range tests {
+		if got := muxPattern(path); got != want {
+			t.Errorf("muxPattern(%s) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+// echo answers the route it handles and the path parameters of the request.
+func echo(route string, names ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, route)
+		for _, name := range names {
+			fmt.Fprintf(w, " %s=%s", name, PathParameter(r, name))
+		}
+	})
+}
+
+func TestRegisterServeMux(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterServeMux(mux, []Route{
+		{Method: "GET", Path: "/pets", Handler: echo("listPets")},
+		{Method: "POST", Path: "/pets", Handler: echo("addPet")},
+		{Method: "GET", Path: "/pets/{id}", Handler: echo("getPet", "id")},
+		{Method: "delete", Path: "/pets/{id}", Handler: echo("deletePet", "id")},
+		{Method: "GET", Path: "/pets/{id}/owner", Handler: echo("getOwner", "id")},
+	})
+	tests := []struct {
+		method string
+		path   string
+		status int
+		body   string
+		allow  string
+	}{
+		{"GET", "/pets", 200, "listPets", ""},
+		{"POST", "/pets", 200, "addPet", ""},
+		{"GET", "/pets/42", 200, "getPet id=42", ""},
+		{"DELETE", "/pets/42", 200, "deletePet id=42", ""},
+		{"GET", "/pets/42/owner", 200, "getOwner id=42", ""},
+		{"GET", "/pets/a%20b", 200, "getPet id=a b", ""},
+		{"PUT", "/pets", 405, "Method Not Allowed\n", "GET, POST"},
+		{"POST", "/pets/42", 405, "Method Not Allowed\n", "GET, DELETE"},
+		{"GET", "/pets/42/toys", 404, "404 page not found\n", ""},
+		{"GET", "/owners", 404, "404 page not found\n", ""},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+		if w.Code != test.status || w.Body.String() != test.body || w.Header().Get("Allow") != test.allow {
+			t.Errorf("%s %s = %d %q, Allow %q, want %d %q, Allow %q", test.method, test.path,
+				w.Code, w.Body.String(), w.Header().Get("Allow"), test.status, test.body, test.allow)
+		}
+	}
+}
+
+func TestServeMuxHandleLater(t *testing.T) {
+	mux := http.NewServeMux()
+	serveMux := NewServeMux(mux)
+	serveMux.Handle(Route{Method: "GET", Path: "/pets/{id}", Handler: echo("getPet", "id")})
+	serveMux.Handle(Route{Method: "GET", Path: "/pets/{id}/owner", Handler: echo("getOwner", "id")})
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/pets/42/owner", nil))
+	if w.Body.String() != "getOwner id=42" {
+		t.Errorf("GET /pets/42/owner = %q, want getOwner id=42", w.Body.String())
+	}
+}
+
+func TestWithPathParameters(t *testing.T) {
+	handler := WithPathParameters(Route{Method: "GET", Path: "/pets/{id}", Handler: echo("getPet", "id")})
+	tests := map[string]string{
+		"/pets/42": "getPet id=42",
+		"/other":   "getPet id=",
+	}
+	for path, want := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Body.String() != want {
+			t.Errorf("GET %s = %q, want %q", path, w.Body.String(), want)
+		}
+	}
+}
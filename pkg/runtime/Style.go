@@ -0,0 +1,254 @@
+package runtime
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// StylePath serializes a path parameter with the simple, label or matrix style, escaping it for a path segment.
+// http://spec.openapis.org/oas/v3.0.3#style-examples
+func StylePath(name, style string, explode bool, value interface{}) (string, error) {
+	serialized, err := serialize(reflect.ValueOf(value))
+	if err != nil {
+		return "", &ParameterError{name, "path", err}
+	}
+	escape := func(values []string) []string {
+		for i, value := range values {
+			values[i] = url.PathEscape(value)
+		}
+		return values
+	}
+	switch style {
+	case "", "simple":
+		return serialized.join(",", explode, escape), nil
+	case "label":
+		separator := ","
+		if explode {
+			separator = "."
+		}
+		return "." + serialized.join(separator, explode, escape), nil
+	case "matrix":
+		if explode && serialized.kind == objectKind {
+			return ";" + serialized.join(";", true, escape), nil
+		}
+		if explode && serialized.kind == arrayKind {
+			var builder strings.Builder
+			for _, element := range escape(serialized.values) {
+				builder.WriteString(";" + url.PathEscape(name) + "=" + element)
+			}
+			return builder.String(), nil
+		}
+		return ";" + url.PathEscape(name) + "=" + serialized.join(",", false, escape), nil
+	}
+	return "", &ParameterError{name, "path", fmt.Errorf("style %q does not apply to path parameters", style)}
+}
+
+// StyleQuery adds a query parameter serialized with the form, spaceDelimited, pipeDelimited or deepObject style to
+// query. A nil value is omitted.
+func StyleQuery(name, style string, explode bool, value interface{}, query url.Values) error {
+	v := reflect.ValueOf(value)
+	if isNil(v) {
+		return nil
+	}
+	serialized, err := serialize(v)
+	if err != nil {
+		return &ParameterError{name, "query", err}
+	}
+	switch {
+	case style == "deepObject":
+		if serialized.kind != objectKind {
+			return &ParameterError{name, "query", fmt.Errorf("style deepObject only applies to objects")}
+		}
+		for _, member := range serialized.members {
+			query.Add(name+"["+member[0]+"]", member[1])
+		}
+		return nil
+	case explode && serialized.kind == objectKind:
+		for _, member := range serialized.members {
+			query.Add(member[0], member[1])
+		}
+		return nil
+	case explode && serialized.kind == arrayKind:
+		for _, element := range serialized.values {
+			query.Add(name, element)
+		}
+		return nil
+	}
+	separator := ","
+	switch style {
+	case "", "form":
+	case "spaceDelimited":
+		separator = " "
+	case "pipeDelimited":
+		separator = "|"
+	default:
+		return &ParameterError{name, "query", fmt.Errorf("style %q does not apply to query parameters", style)}
+	}
+	query.Add(name, serialized.join(separator, false, nil))
+	return nil
+}
+
+// SetHeader sets a header serialized with the simple style. A nil value is omitted.
+func SetHeader(header http.Header, name string, explode bool, value interface{}) error {
+	v := reflect.ValueOf(value)
+	if isNil(v) {
+		return nil
+	}
+	serialized, err := serialize(v)
+	if err != nil {
+		return &ParameterError{name, "header", err}
+	}
+	header.Set(name, serialized.join(",", explode, nil))
+	return nil
+}
+
+// AddCookie adds a cookie serialized with the form style to a request. A nil value is omitted.
+func AddCookie(request *http.Request, name string, explode bool, value interface{}) error {
+	v := reflect.ValueOf(value)
+	if isNil(v) {
+		return nil
+	}
+	serialized, err := serialize(v)
+	if err != nil {
+		return &ParameterError{name, "cookie", err}
+	}
+	request.AddCookie(&http.Cookie{Name: name, Value: url.QueryEscape(serialized.join(",", explode, nil))})
+	return nil
+}
+
+// serialized is a value turned into strings: a primitive value, the elements of an array or the members of an object.
+type serialized struct {
+	kind    valueKind
+	values  []string
+	members [][2]string
+}
+
+// join returns the serialized value with its elements or members separated by separator, exploded members being
+// key=value pairs. escape, when not nil, escapes the strings before they are joined.
+func (serialized serialized) join(separator string, explode bool, escape func([]string) []string) string {
+	if escape == nil {
+		escape = func(values []string) []string { return values }
+	}
+	if serialized.kind != objectKind {
+		return strings.Join(escape(serialized.values), separator)
+	}
+	parts := make([]string, 0, 2*len(serialized.members))
+	for _, member := range serialized.members {
+		escaped := escape([]string{member[0], member[1]})
+		if explode {
+			parts = append(parts, escaped[0]+"="+escaped[1])
+		} else {
+			parts = append(parts, escaped...)
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+func isNil(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// serialize turns a primitive, a slice, a map or a struct into strings. Struct fields are named by their json tag and
+// nil fields are omitted.
+func serialize(v reflect.Value) (serialized, error) {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return serialized{}, fmt.Errorf("cannot serialize a nil value")
+	}
+	switch kindOf(v.Type()) {
+	case arrayKind:
+		values := make([]string, v.Len())
+		for i := range values {
+			value, err := format(v.Index(i))
+			if err != nil {
+				return serialized{}, err
+			}
+			values[i] = value
+		}
+		return serialized{kind: arrayKind, values: values}, nil
+	case objectKind:
+		var members [][2]string
+		if v.Kind() == reflect.Map {
+			keys := v.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+			for _, key := range keys {
+				value, err := format(v.MapIndex(key))
+				if err != nil {
+					return serialized{}, err
+				}
+				members = append(members, [2]string{fmt.Sprint(key), value})
+			}
+			return serialized{kind: objectKind, members: members}, nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if field.PkgPath != "" || name == "-" || isNil(v.Field(i)) {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			value, err := format(v.Field(i))
+			if err != nil {
+				return serialized{}, err
+			}
+			members = append(members, [2]string{name, value})
+		}
+		return serialized{kind: objectKind, members: members}, nil
+	}
+	value, err := format(v)
+	if err != nil {
+		return serialized{}, err
+	}
+	return serialized{kind: primitiveKind, values: []string{value}}, nil
+}
+
+// format returns the string of a primitive value.
+func format(v reflect.Value) (string, error) {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() && reflect.PtrTo(v.Type()).Implements(textMarshalerType) {
+		v = v.Addr()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+	}
+	return "", fmt.Errorf("cannot serialize a %s", v.Type())
+}
@@ -0,0 +1,156 @@
+package runtime
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// color is the object of the style examples of the specification, its fields serialized in order.
+type color struct {
+	R int `json:"R"`
+	G int `json:"G"`
+	B int `json:"B"`
+}
+
+// styleValues are the values of the style examples: a primitive, an array and an object.
+var styleValues = []interface{}{"blue", []string{"blue", "black", "brown"}, color{100, 200, 150}}
+
+// http://spec.openapis.org/oas/v3.0.3#style-examples
+func TestStylePath(t *testing.T) {
+	tests := []struct {
+		style   string
+		explode bool
+		want    [3]string
+	}{
+		{"matrix", false, [3]string{";color=blue", ";color=blue,black,brown", ";color=R,100,G,200,B,150"}},
+		{"matrix", true, [3]string{";color=blue", ";color=blue;color=black;color=brown", ";R=100;G=200;B=150"}},
+		{"label", false, [3]string{".blue", ".blue,black,brown", ".R,100,G,200,B,150"}},
+		{"label", true, [3]string{".blue", ".blue.black.brown", ".R=100.G=200.B=150"}},
+		{"simple", false, [3]string{"blue", "blue,black,brown", "R,100,G,200,B,150"}},
+		{"simple", true, [3]string{"blue", "blue,black,brown", "R=100,G=200,B=150"}},
+	}
+	for _, test := range tests {
+		for i, value := range styleValues {
+			got, err := StylePath("color", test.style, test.explode, value)
+			if err != nil || got != test.want[i] {
+				t.Errorf("StylePath(%s, %t, %v) = %q, %v, want %q", test.style, test.explode, value, got, err, test.want[i])
+				continue
+			}
+			bound := reflect.New(reflect.TypeOf(value))
+			if err := BindPath("color", test.style, test.explode, got, bound.Interface()); err != nil {
+				t.Errorf("BindPath(%s, %t, %q) = %v", test.style, test.explode, got, err)
+			} else if !reflect.DeepEqual(bound.Elem().Interface(), value) {
+				t.Errorf("BindPath(%s, %t, %q) = %v, want %v", test.style, test.explode, got, bound.Elem(), value)
+			}
+		}
+	}
+}
+
+func TestStylePathEscapes(t *testing.T) {
+	got, err := StylePath("name", "simple", false, []string{"a/b", "c d"})
+	if want := "a%2Fb,c%20d"; err != nil || got != want {
+		t.Errorf("StylePath() = %q, %v, want %q", got, err, want)
+	}
+	if _, err := StylePath("name", "form", false, "a"); err == nil {
+		t.Error("StylePath(form) succeeded, want an error")
+	}
+}
+
+// http://spec.openapis.org/oas/v3.0.3#style-examples
+func TestStyleQuery(t *testing.T) {
+	tests := []struct {
+		style   string
+		explode bool
+		value   interface{}
+		want    url.Values
+	}{
+		{"form", false, "blue", url.Values{"color": {"blue"}}},
+		{"form", false, []string{"blue", "black", "brown"}, url.Values{"color": {"blue,black,brown"}}},
+		{"form", false, color{100, 200, 150}, url.Values{"color": {"R,100,G,200,B,150"}}},
+		{"form", true, "blue", url.Values{"color": {"blue"}}},
+		{"form", true, []string{"blue", "black", "brown"}, url.Values{"color": {"blue", "black", "brown"}}},
+		{"form", true, color{100, 200, 150}, url.Values{"R": {"100"}, "G": {"200"}, "B": {"150"}}},
+		{"spaceDelimited", false, []string{"blue", "black", "brown"}, url.Values{"color": {"blue black brown"}}},
+		{"spaceDelimited", false, color{100, 200, 150}, url.Values{"color": {"R 100 G 200 B 150"}}},
+		{"pipeDelimited", false, []string{"blue", "black", "brown"}, url.Values{"color": {"blue|black|brown"}}},
+		{"pipeDelimited", false, color{100, 200, 150}, url.Values{"color": {"R|100|G|200|B|150"}}},
+		{"deepObject", true, color{100, 200, 150}, url.Values{"color[R]": {"100"}, "color[G]": {"200"}, "color[B]": {"150"}}},
+		{"deepObject", true, map[string]int{"R": 100}, url.Values{"color[R]": {"100"}}},
+		{"form", true, (*int)(nil), url.Values{}},
+	}
+	for _, test := range tests {
+		query := url.Values{}
+		if err := StyleQuery("color", test.style, test.explode, test.value, query); err != nil || !reflect.DeepEqual(query, test.want) {
+			t.Errorf("StyleQuery(%s, %t, %v) = %v, %v, want %v", test.style, test.explode, test.value, query, err, test.want)
+			continue
+		}
+		bound := reflect.New(reflect.TypeOf(test.value))
+		if err := BindQuery("color", test.style, test.explode, false, query, bound.Interface()); err != nil {
+			t.Errorf("BindQuery(%s, %t, %v) = %v", test.style, test.explode, query, err)
+		} else if !reflect.DeepEqual(bound.Elem().Interface(), test.value) {
+			t.Errorf("BindQuery(%s, %t, %v) = %v, want %v", test.style, test.explode, query, bound.Elem(), test.value)
+		}
+	}
+}
+
+func TestStyleQueryErrors(t *testing.T) {
+	tests := []struct {
+		style string
+		value interface{}
+		want  string
+	}{
+		{"deepObject", "blue", `query parameter "color": style deepObject only applies to objects`},
+		{"matrix", "blue", `query parameter "color": style "matrix" does not apply to query parameters`},
+		{"form", []func(){nil}, `query parameter "color": cannot serialize a func()`},
+	}
+	for _, test := range tests {
+		err := StyleQuery("color", test.style, false, test.value, url.Values{})
+		if err == nil || err.Error() != test.want {
+			t.Errorf("StyleQuery(%s, %v) = %v, want %s", test.style, test.value, err, test.want)
+		}
+	}
+}
+
+func TestSetHeaderAndAddCookie(t *testing.T) {
+	tests := []struct {
+		explode bool
+		value   interface{}
+		want    string
+	}{
+		{false, 42, "42"},
+		{false, []int{1, 2}, "1,2"},
+		{false, map[string]string{"b": "2", "a": "1"}, "a,1,b,2"},
+		{true, color{100, 200, 150}, "R=100,G=200,B=150"},
+		{false, []byte("hi"), "aGk="},
+	}
+	for _, test := range tests {
+		header := http.Header{}
+		if err := SetHeader(header, "X-Color", test.explode, test.value); err != nil || header.Get("X-Color") != test.want {
+			t.Errorf("SetHeader(%t, %v) = %q, %v, want %q", test.explode, test.value, header.Get("X-Color"), err, test.want)
+		}
+		bound := reflect.New(reflect.TypeOf(test.value))
+		if err := BindHeader("X-Color", test.explode, true, header, bound.Interface()); err != nil {
+			t.Errorf("BindHeader(%t, %q) = %v", test.explode, header.Get("X-Color"), err)
+		} else if !reflect.DeepEqual(bound.Elem().Interface(), test.value) {
+			t.Errorf("BindHeader(%t, %q) = %v, want %v", test.explode, header.Get("X-Color"), bound.Elem(), test.value)
+		}
+
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		if err := AddCookie(request, "color", test.explode, test.value); err != nil {
+			t.Errorf("AddCookie(%t, %v) = %v", test.explode, test.value, err)
+		}
+		bound = reflect.New(reflect.TypeOf(test.value))
+		if err := BindCookie("color", test.explode, true, request, bound.Interface()); err != nil {
+			t.Errorf("BindCookie(%t, %q) = %v", test.explode, request.Header.Get("Cookie"), err)
+		} else if !reflect.DeepEqual(bound.Elem().Interface(), test.value) {
+			t.Errorf("BindCookie(%t, %q) = %v, want %v", test.explode, request.Header.Get("Cookie"), bound.Elem(), test.value)
+		}
+	}
+
+	header := http.Header{}
+	if err := SetHeader(header, "X-Color", false, (*string)(nil)); err != nil || len(header) != 0 {
+		t.Errorf("SetHeader(nil) = %v, %v, want no header", header, err)
+	}
+}
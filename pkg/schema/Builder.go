@@ -0,0 +1,322 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// Builder builds a schema with chained calls, such as String().Format("email").MaxLength(255). The keywords of a
+// reference apply to an allOf wrapping it, since the siblings of a $ref are ignored. A value which cannot be encoded,
+// such as a default, is reported by Err rather than stopping the chain.
+type Builder struct {
+	ref *v303.SchemaRef
+	err error
+}
+
+func newBuilder(schema *v303.Schema) *Builder {
+	return &Builder{ref: &v303.SchemaRef{Value: schema}}
+}
+
+// String returns the builder of a string schema.
+func String() *Builder { return newBuilder(&v303.Schema{Type: "string"}) }
+
+// Integer returns the builder of an integer schema without format.
+func Integer() *Builder { return newBuilder(&v303.Schema{Type: "integer"}) }
+
+// Int32 returns the builder of an int32 integer schema.
+func Int32() *Builder { return newBuilder(&v303.Schema{Type: "integer", Format: "int32"}) }
+
+// Int64 returns the builder of an int64 integer schema.
+func Int64() *Builder { return newBuilder(&v303.Schema{Type: "integer", Format: "int64"}) }
+
+// Number returns the builder of a number schema without format.
+func Number() *Builder { return newBuilder(&v303.Schema{Type: "number"}) }
+
+// Float returns the builder of a float number schema.
+func Float() *Builder { return newBuilder(&v303.Schema{Type: "number", Format: "float"}) }
+
+// Double returns the builder of a double number schema.
+func Double() *Builder { return newBuilder(&v303.Schema{Type: "number", Format: "double"}) }
+
+// Boolean returns the builder of a boolean schema.
+func Boolean() *Builder { return newBuilder(&v303.Schema{Type: "boolean"}) }
+
+// DateTime returns the builder of a date-time string schema.
+func DateTime() *Builder { return newBuilder(&v303.Schema{Type: "string", Format: "date-time"}) }
+
+// Date returns the builder of a full-date string schema.
+func Date() *Builder { return newBuilder(&v303.Schema{Type: "string", Format: "date"}) }
+
+// Binary returns the builder of a binary string schema, for file contents.
+func Binary() *Builder { return newBuilder(&v303.Schema{Type: "string", Format: "binary"}) }
+
+// Array returns the builder of an array schema of items.
+func Array(items *Builder) *Builder {
+	return newBuilder(&v303.Schema{Type: "array", Items: items.SchemaRef()}).adopt(items)
+}
+
+// Object returns the builder of an object schema, whose properties are added with Property.
+func Object() *Builder { return newBuilder(&v303.Schema{Type: "object"}) }
+
+// Map returns the builder of an object schema whose properties are all values.
+func Map(values *Builder) *Builder {
+	return newBuilder(&v303.Schema{Type: "object", AdditionalProperties: &v303.AdditionalProperties{Allowed: true, Schema: values.SchemaRef()}}).adopt(values)
+}
+
+// Any returns the builder of a schema accepting any value.
+func Any() *Builder { return newBuilder(&v303.Schema{}) }
+
+// Ref returns the builder of a reference to the schema component name.
+func Ref(name string) *Builder {
+	return &Builder{ref: &v303.SchemaRef{Ref: ComponentsPrefix + name}}
+}
+
+// AllOf returns the builder of a schema combining schemas.
+func AllOf(schemas ...*Builder) *Builder {
+	return newBuilder(&v303.Schema{AllOf: refs(schemas)}).adopt(schemas...)
+}
+
+// OneOf returns the builder of a schema matching exactly one of schemas.
+func OneOf(schemas ...*Builder) *Builder {
+	return newBuilder(&v303.Schema{OneOf: refs(schemas)}).adopt(schemas...)
+}
+
+// AnyOf returns the builder of a schema matching at least one of schemas.
+func AnyOf(schemas ...*Builder) *Builder {
+	return newBuilder(&v303.Schema{AnyOf: refs(schemas)}).adopt(schemas...)
+}
+
+// Wrap returns the builder adding keywords to schemaRef, such as a schema returned by FromType.
+func Wrap(schemaRef *v303.SchemaRef) *Builder {
+	return &Builder{ref: schemaRef}
+}
+
+func refs(schemas []*Builder) []*v303.SchemaRef {
+	refs := make([]*v303.SchemaRef, len(schemas))
+	for i, schema := range schemas {
+		refs[i] = schema.SchemaRef()
+	}
+	return refs
+}
+
+// number returns the shortest JSON number of value, which parses back to it. NaN and infinities, which are not JSON
+// numbers, unset the keyword.
+func number(value float64) json.Number {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return json.Number(data)
+}
+
+// raw returns the JSON encoding of value, which json.RawMessage values are kept as, recording the error of a value
+// which cannot be encoded.
+func (builder *Builder) raw(value interface{}) json.RawMessage {
+	data, err := json.Marshal(value)
+	if err != nil && builder.err == nil {
+		builder.err = fmt.Errorf("schema: a value of type %T cannot be encoded: %v", value, err)
+	}
+	return data
+}
+
+// adopt records the first error of the builders schemas the schema is made of.
+func (builder *Builder) adopt(schemas ...*Builder) *Builder {
+	for _, schema := range schemas {
+		if builder.err == nil {
+			builder.err = schema.err
+		}
+	}
+	return builder
+}
+
+// SchemaRef returns the schema built.
+func (builder *Builder) SchemaRef() *v303.SchemaRef {
+	return builder.ref
+}
+
+// Err returns the first error met building the schema or the schemas it is made of, such as a default value
+// encoding/json cannot encode.
+func (builder *Builder) Err() error {
+	return builder.err
+}
+
+// schema returns the schema the keywords apply to, wrapping a reference into allOf.
+func (builder *Builder) schema() *v303.Schema {
+	if builder.ref.Ref != "" {
+		builder.ref = &v303.SchemaRef{Value: &v303.Schema{AllOf: []*v303.SchemaRef{builder.ref}}}
+	}
+	return builder.ref.Value
+}
+
+// Title sets the title of the schema.
+func (builder *Builder) Title(title string) *Builder {
+	builder.schema().Title = title
+	return builder
+}
+
+// Description sets the description of the schema.
+func (builder *Builder) Description(description string) *Builder {
+	builder.schema().Description = description
+	return builder
+}
+
+// Format sets the format of the schema, such as email or uuid.
+func (builder *Builder) Format(format string) *Builder {
+	builder.schema().Format = format
+	return builder
+}
+
+// Pattern sets the regular expression strings match.
+func (builder *Builder) Pattern(pattern string) *Builder {
+	builder.schema().Pattern = pattern
+	return builder
+}
+
+// MinLength sets the minimum length of strings.
+func (builder *Builder) MinLength(minLength int) *Builder {
+	builder.schema().MinLength = &minLength
+	return builder
+}
+
+// MaxLength sets the maximum length of strings.
+func (builder *Builder) MaxLength(maxLength int) *Builder {
+	builder.schema().MaxLength = &maxLength
+	return builder
+}
+
+// Minimum sets the inclusive minimum of numbers.
+func (builder *Builder) Minimum(minimum float64) *Builder {
+	schema := builder.schema()
+	schema.Minimum, schema.ExclusiveMinimum = number(minimum), false
+	return builder
+}
+
+// ExclusiveMinimum sets the exclusive minimum of numbers.
+func (builder *Builder) ExclusiveMinimum(minimum float64) *Builder {
+	schema := builder.schema()
+	schema.Minimum, schema.ExclusiveMinimum = number(minimum), true
+	return builder
+}
+
+// Maximum sets the inclusive maximum of numbers.
+func (builder *Builder) Maximum(maximum float64) *Builder {
+	schema := builder.schema()
+	schema.Maximum, schema.ExclusiveMaximum = number(maximum), false
+	return builder
+}
+
+// ExclusiveMaximum sets the exclusive maximum of numbers.
+func (builder *Builder) ExclusiveMaximum(maximum float64) *Builder {
+	schema := builder.schema()
+	schema.Maximum, schema.ExclusiveMaximum = number(maximum), true
+	return builder
+}
+
+// MultipleOf sets the number numbers are multiples of.
+func (builder *Builder) MultipleOf(multipleOf float64) *Builder {
+	builder.schema().MultipleOf = number(multipleOf)
+	return builder
+}
+
+// MinItems sets the minimum size of arrays.
+func (builder *Builder) MinItems(minItems int) *Builder {
+	builder.schema().MinItems = &minItems
+	return builder
+}
+
+// MaxItems sets the maximum size of arrays.
+func (builder *Builder) MaxItems(maxItems int) *Builder {
+	builder.schema().MaxItems = &maxItems
+	return builder
+}
+
+// UniqueItems requires the items of arrays to be unique.
+func (builder *Builder) UniqueItems() *Builder {
+	builder.schema().UniqueItems = true
+	return builder
+}
+
+// Enum sets the values the schema accepts, as encoded by encoding/json.
+func (builder *Builder) Enum(values ...interface{}) *Builder {
+	schema := builder.schema()
+	schema.Enum = nil
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, builder.raw(value))
+	}
+	return builder
+}
+
+// Default sets the default value of the schema, as encoded by encoding/json.
+func (builder *Builder) Default(value interface{}) *Builder {
+	builder.schema().Default = builder.raw(value)
+	return builder
+}
+
+// Example sets an example of the values of the schema, as encoded by encoding/json.
+func (builder *Builder) Example(value interface{}) *Builder {
+	builder.schema().Example = builder.raw(value)
+	return builder
+}
+
+// Nullable accepts null.
+func (builder *Builder) Nullable() *Builder {
+	builder.schema().Nullable = true
+	return builder
+}
+
+// ReadOnly marks the schema as only sent in responses.
+func (builder *Builder) ReadOnly() *Builder {
+	builder.schema().ReadOnly = true
+	return builder
+}
+
+// WriteOnly marks the schema as only sent in requests.
+func (builder *Builder) WriteOnly() *Builder {
+	builder.schema().WriteOnly = true
+	return builder
+}
+
+// Deprecated marks the schema as deprecated.
+func (builder *Builder) Deprecated() *Builder {
+	builder.schema().Deprecated = true
+	return builder
+}
+
+// Property adds a property to the schema of an object.
+func (builder *Builder) Property(name string, property *Builder) *Builder {
+	schema := builder.schema()
+	if schema.Properties == nil {
+		schema.Properties = map[string]*v303.SchemaRef{}
+	}
+	schema.Properties[name] = property.SchemaRef()
+	return builder.adopt(property)
+}
+
+// NoAdditionalProperties forbids the properties of an object which are not added with Property.
+func (builder *Builder) NoAdditionalProperties() *Builder {
+	builder.schema().AdditionalProperties = &v303.AdditionalProperties{Allowed: false}
+	return builder
+}
+
+// Required adds required properties to the schema of an object.
+func (builder *Builder) Required(names ...string) *Builder {
+	schema := builder.schema()
+	schema.Required = append(schema.Required, names...)
+	return builder
+}
+
+// Discriminator sets the property telling apart the schemas of a oneOf or anyOf schema, with optional mappings of its
+// values to schema component names.
+func (builder *Builder) Discriminator(propertyName string, mapping map[string]string) *Builder {
+	discriminator := &v303.Discriminator{PropertyName: propertyName}
+	for value, name := range mapping {
+		if discriminator.Mapping == nil {
+			discriminator.Mapping = map[string]string{}
+		}
+		discriminator.Mapping[value] = ComponentsPrefix + name
+	}
+	builder.schema().Discriminator = discriminator
+	return builder
+}
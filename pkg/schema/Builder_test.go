@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    string
+	}{
+		{"string", String().Format("email").MinLength(0).MaxLength(255).Pattern("^.+@.+$"),
+			`{"type": "string", "format": "email", "minLength": 0, "maxLength": 255, "pattern": "^.+@.+$"}`},
+		{"integer", Int64().Minimum(1).ExclusiveMaximum(100).MultipleOf(2),
+			`{"type": "integer", "format": "int64", "minimum": 1, "maximum": 100, "exclusiveMaximum": true, "multipleOf": 2}`},
+		{"number", Double().ExclusiveMinimum(0.5).Maximum(99.99),
+			`{"type": "number", "format": "double", "minimum": 0.5, "exclusiveMinimum": true, "maximum": 99.99}`},
+		{"NaN unsets the keyword", Number().Minimum(math.NaN()), `{"type": "number"}`},
+		{"array", Array(String()).MinItems(1).MaxItems(3).UniqueItems(),
+			`{"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3, "uniqueItems": true}`},
+		{"object", Object().Property("id", Int64().ReadOnly()).Property("password", String().WriteOnly()).Required("id").NoAdditionalProperties(),
+			`{"type": "object", "required": ["id"], "additionalProperties": false, "properties": {
+				"id": {"type": "integer", "format": "int64", "readOnly": true},
+				"password": {"type": "string", "writeOnly": true}}}`},
+		{"map", Map(Boolean()), `{"type": "object", "additionalProperties": {"type": "boolean"}}`},
+		{"values", String().Enum("cat", "dog").Default("dog").Example("cat").Nullable().Deprecated(),
+			`{"type": "string", "enum": ["cat", "dog"], "default": "dog", "example": "cat", "nullable": true, "deprecated": true}`},
+		{"reference", Ref("Pet"), `{"$ref": "#/components/schemas/Pet"}`},
+		{"keywords of a reference", Ref("Pet").Description("The pet.").Nullable(),
+			`{"allOf": [{"$ref": "#/components/schemas/Pet"}], "description": "The pet.", "nullable": true}`},
+		{"oneOf", OneOf(Ref("Cat"), Ref("Dog")).Discriminator("kind", map[string]string{"cat": "Cat"}),
+			`{"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}],
+				"discriminator": {"propertyName": "kind", "mapping": {"cat": "#/components/schemas/Cat"}}}`},
+		{"any", Any().Title("Anything"), `{"title": "Anything"}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.builder.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+			assertJSON(t, "schema", test.builder.SchemaRef(), test.want)
+		})
+	}
+}
+
+func TestBuilderErr(t *testing.T) {
+	const want = "schema: a value of type chan int cannot be encoded: json: unsupported type: chan int"
+	tests := []struct {
+		name    string
+		builder *Builder
+	}{
+		{"enum", String().Enum("a", make(chan int))},
+		{"default", String().Default(make(chan int))},
+		{"example", String().Example(make(chan int)).Example("a")},
+		{"items", Array(String().Default(make(chan int)))},
+		{"values", Map(String().Default(make(chan int)))},
+		{"property", Object().Property("name", String().Default(make(chan int)))},
+		{"allOf", AllOf(Ref("Pet"), Object().Default(make(chan int)))},
+		{"oneOf", OneOf(Ref("Pet"), Object().Default(make(chan int)))},
+		{"anyOf", AnyOf(Ref("Pet"), Object().Default(make(chan int)))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.builder.Err(); err == nil || err.Error() != want {
+				t.Errorf("Err() = %v, want %s", err, want)
+			}
+		})
+	}
+}
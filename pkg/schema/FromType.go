@@ -0,0 +1,400 @@
+// Package schema describes Go types with OpenAPI 3.0.3 schemas.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/newm4n/swaggo/pkg/openapi/v303"
+)
+
+// ComponentsPrefix is the location of the schema components which the refs of FromType point to.
+const ComponentsPrefix = "#/components/schemas/"
+
+// Options configures FromType.
+type Options struct {
+	// Name returns the component name of a named type, the package name and the type name such as "model.Pet"
+	// when nil, prefixed with the parent directories of the package such as "b.model.Pet" when another type has
+	// that name. A name Name returns for several types is numbered, such as "Pet2".
+	Name func(reflect.Type) string
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// FromType returns the schema of the JSON encoding of values of type t, along with the schema components of the
+// named types it uses, keyed by component name. Named types are referred to with a $ref, so that recursive types are
+// described. t itself is registered as a component when it is named.
+//
+// Struct fields follow their json tag, embedded structs being combined with allOf, and are required unless tagged
+// omitempty or pointers. Pointers are nullable, time.Time is a date-time string and maps are objects. The tags
+// description, format, default and example and the validate rules required, min, max, gte, lte, gt, lt, len, oneof,
+// email, url, uri and uuid add the corresponding keywords.
+func FromType(t reflect.Type, options Options) (*v303.Schema, map[string]*v303.Schema) {
+	reflector := NewReflector(options)
+	schema := reflector.Type(t)
+	if schema.Ref != "" {
+		return reflector.components[strings.TrimPrefix(schema.Ref, ComponentsPrefix)], reflector.components
+	}
+	return schema.Value, reflector.components
+}
+
+// FromField returns the schema of the struct field, with the keywords of its description, format, default, example
+// and validate tags as FromType describes them, along with the schema components of the named types it uses and
+// whether it is required.
+func FromField(field reflect.StructField, options Options) (*v303.SchemaRef, map[string]*v303.Schema, bool) {
+	reflector := NewReflector(options)
+	schema, required := reflector.Field(field)
+	return schema, reflector.components, required
+}
+
+// Reflector describes several Go types as FromType does, with schema components shared between them: a type is
+// described by a single component, and types sharing a name are given distinct component names.
+type Reflector struct {
+	reflector
+}
+
+// NewReflector returns the Reflector naming components with options.
+func NewReflector(options Options) *Reflector {
+	return &Reflector{reflector{options: options, components: map[string]*v303.Schema{}, names: map[reflect.Type]string{}}}
+}
+
+// Type returns the schema of t, a reference to its component when t is named.
+func (reflector *Reflector) Type(t reflect.Type) *v303.SchemaRef {
+	return reflector.schema(t)
+}
+
+// Field returns the schema of the struct field as FromField does, and whether it is required.
+func (reflector *Reflector) Field(field reflect.StructField) (*v303.SchemaRef, bool) {
+	required := field.Type.Kind() != reflect.Ptr && !hasOption(strings.Split(field.Tag.Get("json"), ",")[1:], "omitempty")
+	return ApplyTags(reflector.schema(field.Type), field.Tag, required)
+}
+
+// Components returns the schema components of the types described so far, keyed by component name.
+func (reflector *Reflector) Components() map[string]*v303.Schema {
+	return reflector.components
+}
+
+type reflector struct {
+	options    Options
+	components map[string]*v303.Schema
+	names      map[reflect.Type]string
+}
+
+// name returns the component name of a named type, which no other type of the reflector uses: a name taken by another
+// type is prefixed with the parent directories of the package of t, such as "b.model.Pet", then numbered.
+func (reflector *reflector) name(t reflect.Type) string {
+	var name string
+	if reflector.options.Name != nil {
+		name = reflector.options.Name(t)
+	} else {
+		name = path.Base(t.PkgPath()) + "." + t.Name()
+		directories := strings.Split(path.Dir(t.PkgPath()), "/")
+		for i := len(directories) - 1; i >= 0 && reflector.components[name] != nil && directories[i] != "."; i-- {
+			name = directories[i] + "." + name
+		}
+	}
+	unique := name
+	for i := 2; reflector.components[unique] != nil; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	return unique
+}
+
+// schema returns a reference to the component of a named type, or the schema of an unnamed or predeclared type.
+func (reflector *reflector) schema(t reflect.Type) *v303.SchemaRef {
+	if t.Kind() == reflect.Ptr {
+		return nullable(reflector.schema(t.Elem()))
+	}
+	if t.Name() == "" || t.PkgPath() == "" || t == timeType || t == rawMessageType {
+		return &v303.SchemaRef{Value: reflector.typeSchema(t)}
+	}
+	if name, ok := reflector.names[t]; ok {
+		return &v303.SchemaRef{Ref: ComponentsPrefix + name}
+	}
+	name := reflector.name(t)
+	reflector.names[t] = name
+	// The component is registered before its type is described so that recursive types refer to themselves.
+	component := &v303.Schema{}
+	reflector.components[name] = component
+	*component = *reflector.typeSchema(t)
+	return &v303.SchemaRef{Ref: ComponentsPrefix + name}
+}
+
+// typeSchema returns the schema of the values of t.
+func (reflector *reflector) typeSchema(t reflect.Type) *v303.Schema {
+	switch {
+	case t == timeType:
+		return &v303.Schema{Type: "string", Format: "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// The encoding is unknown.
+		return &v303.Schema{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return &v303.Schema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &v303.Schema{Type: "boolean"}
+	case reflect.Int, reflect.Uint:
+		return &v303.Schema{Type: "integer"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &v303.Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint32, reflect.Uint64:
+		return &v303.Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &v303.Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &v303.Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &v303.Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &v303.Schema{Type: "string", Format: "byte"}
+		}
+		return &v303.Schema{Type: "array", Items: reflector.schema(t.Elem())}
+	case reflect.Array:
+		minItems, maxItems := t.Len(), t.Len()
+		return &v303.Schema{Type: "array", Items: reflector.schema(t.Elem()), MinItems: &minItems, MaxItems: &maxItems}
+	case reflect.Map:
+		return &v303.Schema{Type: "object", AdditionalProperties: &v303.AdditionalProperties{Allowed: true, Schema: reflector.schema(t.Elem())}}
+	case reflect.Struct:
+		return reflector.structSchema(t)
+	}
+	// Interfaces accept any value, channels, functions and complex numbers have no JSON encoding.
+	return &v303.Schema{}
+}
+
+// structSchema returns the object schema of a struct, combined with the schemas of its embedded structs.
+func (reflector *reflector) structSchema(t reflect.Type) *v303.Schema {
+	object := &v303.Schema{Type: "object"}
+	var allOf []*v303.SchemaRef
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := strings.Split(field.Tag.Get("json"), ",")
+		name, options := jsonTag[0], jsonTag[1:]
+		if name == "-" && len(options) == 0 {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			allOf = append(allOf, reflector.schema(fieldType))
+			continue
+		}
+		// As in encoding/json, embedded structs of unexported types are encoded.
+		if field.PkgPath != "" && !(field.Anonymous && fieldType.Kind() == reflect.Struct) || !supported(fieldType) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property := reflector.schema(field.Type)
+		if hasOption(options, "string") && property.Value != nil && property.Value.Type != "" {
+			property.Value.Type, property.Value.Format = "string", ""
+		}
+		required := field.Type.Kind() != reflect.Ptr && !hasOption(options, "omitempty")
+		property, required = ApplyTags(property, field.Tag, required)
+		if object.Properties == nil {
+			object.Properties = map[string]*v303.SchemaRef{}
+		}
+		object.Properties[name] = property
+		if required {
+			object.Required = append(object.Required, name)
+		}
+	}
+	if len(allOf) == 0 {
+		return object
+	}
+	if len(object.Properties) > 0 {
+		allOf = append(allOf, &v303.SchemaRef{Value: object})
+	}
+	return &v303.Schema{AllOf: allOf}
+}
+
+// supported reports whether the values of t have a JSON encoding.
+func supported(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return false
+	}
+	return true
+}
+
+// nullable returns a nullable copy of schema, wrapping a reference into allOf since the siblings of a $ref are
+// ignored.
+func nullable(schema *v303.SchemaRef) *v303.SchemaRef {
+	if schema.Ref != "" {
+		return &v303.SchemaRef{Value: &v303.Schema{AllOf: []*v303.SchemaRef{schema}, Nullable: true}}
+	}
+	value := *schema.Value
+	value.Nullable = true
+	return &v303.SchemaRef{Value: &value}
+}
+
+// ApplyTags returns a copy of the schema of a struct field with the keywords of its description, format, default,
+// example and validate tags, as FromType describes them, and whether the field is required, which it is unless the
+// validate tag says otherwise. Keywords of a reference apply to an allOf wrapping it, and property is returned
+// unchanged when the tags add no keyword.
+func ApplyTags(property *v303.SchemaRef, tag reflect.StructTag, required bool) (*v303.SchemaRef, bool) {
+	rules := strings.Split(tag.Get("validate"), ",")
+	description, format, defaultValue, example := tag.Get("description"), tag.Get("format"), tag.Get("default"), tag.Get("example")
+	constrained := description != "" || format != "" || defaultValue != "" || example != ""
+	for _, rule := range rules {
+		switch key := strings.SplitN(rule, "=", 2)[0]; key {
+		case "required":
+			required = true
+		case "omitempty":
+			required = false
+		case "":
+		default:
+			constrained = true
+		}
+	}
+	if !constrained {
+		return property, required
+	}
+	var schema *v303.Schema
+	if property.Ref != "" {
+		schema = &v303.Schema{AllOf: []*v303.SchemaRef{property}}
+	} else {
+		copied := *property.Value
+		schema = &copied
+	}
+	if description != "" {
+		schema.Description = description
+	}
+	if format != "" {
+		schema.Format = format
+	}
+	if defaultValue != "" {
+		schema.Default = convertValue(defaultValue, schema.Type)
+	}
+	if example != "" {
+		schema.Example = convertValue(example, schema.Type)
+	}
+	for _, rule := range rules {
+		keyValue := strings.SplitN(rule, "=", 2)
+		key, value := keyValue[0], ""
+		if len(keyValue) == 2 {
+			value = keyValue[1]
+		}
+		applyRule(schema, key, value)
+	}
+	return &v303.SchemaRef{Value: schema}, required
+}
+
+// applyRule adds the keywords of a validate rule to schema. Bounds apply to the value of numbers, to the length of
+// strings and to the size of arrays and objects.
+func applyRule(schema *v303.Schema, key, value string) {
+	switch key {
+	case "email":
+		schema.Format = "email"
+	case "url", "uri":
+		schema.Format = "uri"
+	case "uuid", "uuid4":
+		schema.Format = "uuid"
+	case "oneof":
+		for _, enum := range strings.Fields(value) {
+			schema.Enum = append(schema.Enum, convertValue(enum, schema.Type))
+		}
+	case "min", "gte", "max", "lte", "gt", "lt", "len":
+		minimum := key == "min" || key == "gte" || key == "gt" || key == "len"
+		maximum := key == "max" || key == "lte" || key == "lt" || key == "len"
+		if schema.Type == "integer" || schema.Type == "number" {
+			// The bound is kept as written, such as 99.99, when it is a JSON number.
+			var bound json.Number
+			if err := json.Unmarshal([]byte(value), &bound); err != nil || strings.HasPrefix(value, `"`) {
+				return
+			}
+			if minimum {
+				schema.Minimum, schema.ExclusiveMinimum = bound, key == "gt"
+			}
+			if maximum {
+				schema.Maximum, schema.ExclusiveMaximum = bound, key == "lt"
+			}
+			return
+		}
+		bound, err := strconv.Atoi(value)
+		if err != nil {
+			return
+		}
+		var minField, maxField **int
+		switch schema.Type {
+		case "string":
+			minField, maxField = &schema.MinLength, &schema.MaxLength
+		case "array":
+			minField, maxField = &schema.MinItems, &schema.MaxItems
+		case "object":
+			minField, maxField = &schema.MinProperties, &schema.MaxProperties
+		default:
+			return
+		}
+		// Lengths and sizes are integers, exclusive bounds become inclusive ones.
+		if key == "gt" {
+			bound++
+		} else if key == "lt" {
+			bound--
+		}
+		if minimum {
+			minBound := bound
+			*minField = &minBound
+		}
+		if maximum {
+			maxBound := bound
+			*maxField = &maxBound
+		}
+	}
+}
+
+// convertValue returns the JSON value of a value written in a tag for a schema of schemaType: numbers are kept as
+// written, and values which do not parse as the type are strings.
+func convertValue(value, schemaType string) json.RawMessage {
+	switch schemaType {
+	case "integer", "number", "array", "object":
+		var raw json.RawMessage
+		if json.Unmarshal([]byte(value), &raw) == nil && isJSONType(raw, schemaType) {
+			return raw
+		}
+	case "boolean":
+		if converted, err := strconv.ParseBool(value); err == nil {
+			return json.RawMessage(strconv.FormatBool(converted))
+		}
+	}
+	data, _ := json.Marshal(value)
+	return data
+}
+
+// isJSONType reports whether raw is a value of schemaType.
+func isJSONType(raw json.RawMessage, schemaType string) bool {
+	switch raw[0] {
+	case '[':
+		return schemaType == "array"
+	case '{':
+		return schemaType == "object"
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		var number json.Number
+		return json.Unmarshal(raw, &number) == nil && (schemaType == "number" ||
+			schemaType == "integer" && !strings.ContainsAny(string(number), ".eE"))
+	}
+	return false
+}
+
+func hasOption(options []string, option string) bool {
+	for _, candidate := range options {
+		if candidate == option {
+			return true
+		}
+	}
+	return false
+}
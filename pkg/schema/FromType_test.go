@@ -0,0 +1,210 @@
+package schema
+
+import (
+	"encoding/json"
+	goscanner "go/scanner"
+	"reflect"
+	"testing"
+	textscanner "text/scanner"
+	"time"
+)
+
+func TestFromTypeNameCollision(t *testing.T) {
+	type scanners struct {
+		Go   goscanner.Scanner   `json:"go"`
+		Text textscanner.Scanner `json:"text"`
+	}
+	schema, components := FromType(reflect.TypeOf(scanners{}), Options{})
+	if components["scanner.Scanner"] == nil || components["text.scanner.Scanner"] == nil {
+		t.Fatalf("components = %v, want scanner.Scanner and text.scanner.Scanner", components)
+	}
+	if ref := schema.Properties["go"].Ref; ref != ComponentsPrefix+"scanner.Scanner" {
+		t.Errorf("go refers to %s, want scanner.Scanner", ref)
+	}
+	if ref := schema.Properties["text"].Ref; ref != ComponentsPrefix+"text.scanner.Scanner" {
+		t.Errorf("text refers to %s, want text.scanner.Scanner", ref)
+	}
+	if _, ok := components["scanner.Scanner"].Properties["ErrorCount"]; !ok {
+		t.Errorf("scanner.Scanner = %+v, want the schema of go/scanner.Scanner", components["scanner.Scanner"])
+	}
+}
+
+func TestFromTypeNameCollisionCustomName(t *testing.T) {
+	type dog struct {
+		Name string `json:"name"`
+	}
+	type cat struct {
+		Lives int `json:"lives"`
+	}
+	type pets struct {
+		Dog dog `json:"dog"`
+		Cat cat `json:"cat"`
+	}
+	name := func(t reflect.Type) string {
+		if t.Name() == "pets" {
+			return "Pets"
+		}
+		return "Pet"
+	}
+	schema, components := FromType(reflect.TypeOf(pets{}), Options{Name: name})
+	if len(components) != 3 {
+		t.Fatalf("components = %v, want Pets, Pet and Pet2", components)
+	}
+	if ref := schema.Properties["dog"].Ref; ref != ComponentsPrefix+"Pet" {
+		t.Errorf("dog refers to %s, want Pet", ref)
+	}
+	if ref := schema.Properties["cat"].Ref; ref != ComponentsPrefix+"Pet2" {
+		t.Errorf("cat refers to %s, want Pet2", ref)
+	}
+	if _, ok := components["Pet2"].Properties["lives"]; !ok {
+		t.Errorf("Pet2 = %+v, want the schema of cat", components["Pet2"])
+	}
+}
+
+func TestFromType(t *testing.T) {
+	type base struct {
+		ID int64 `json:"id"`
+	}
+	type labels map[string]string
+	tests := []struct {
+		name       string
+		value      interface{}
+		want       string
+		components string
+	}{
+		{
+			"required and omitempty",
+			struct {
+				Name     string `json:"name"`
+				Nickname string `json:"nickname,omitempty"`
+				Hidden   string `json:"-"`
+				Dash     string `json:"-,"`
+				Default  bool
+				private  int
+			}{},
+			`{"type": "object", "required": ["name", "-", "Default"], "properties": {
+				"name": {"type": "string"}, "nickname": {"type": "string"}, "-": {"type": "string"},
+				"Default": {"type": "boolean"}}}`,
+			`{}`,
+		},
+		{
+			"pointers are nullable",
+			struct {
+				Age  *int  `json:"age"`
+				Base *base `json:"base"`
+			}{},
+			`{"type": "object", "properties": {
+				"age": {"type": "integer", "nullable": true},
+				"base": {"allOf": [{"$ref": "#/components/schemas/schema.base"}], "nullable": true}}}`,
+			`{"schema.base": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer", "format": "int64"}}}}`,
+		},
+		{
+			"embedded structs are combined with allOf",
+			struct {
+				base
+				Name string `json:"name"`
+			}{},
+			`{"allOf": [{"$ref": "#/components/schemas/schema.base"},
+				{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}]}`,
+			`{"schema.base": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer", "format": "int64"}}}}`,
+		},
+		{
+			"embedded struct with a name is a property",
+			struct {
+				base `json:"base"`
+			}{},
+			`{"type": "object", "required": ["base"], "properties": {"base": {"$ref": "#/components/schemas/schema.base"}}}`,
+			`{"schema.base": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer", "format": "int64"}}}}`,
+		},
+		{
+			"time.Time is a date-time",
+			struct {
+				Born    time.Time  `json:"born"`
+				Updated *time.Time `json:"updated,omitempty"`
+			}{},
+			`{"type": "object", "required": ["born"], "properties": {
+				"born": {"type": "string", "format": "date-time"},
+				"updated": {"type": "string", "format": "date-time", "nullable": true}}}`,
+			`{}`,
+		},
+		{
+			"maps are additionalProperties",
+			struct {
+				Counts map[string]int `json:"counts"`
+				Labels labels         `json:"labels"`
+				Any    map[string]interface{}
+			}{},
+			`{"type": "object", "required": ["counts", "labels", "Any"], "properties": {
+				"counts": {"type": "object", "additionalProperties": {"type": "integer"}},
+				"labels": {"$ref": "#/components/schemas/schema.labels"},
+				"Any": {"type": "object", "additionalProperties": {}}}}`,
+			`{"schema.labels": {"type": "object", "additionalProperties": {"type": "string"}}}`,
+		},
+		{
+			"validate tags",
+			struct {
+				Name   string   `json:"name,omitempty" validate:"required,min=1,max=20"`
+				Kind   string   `json:"kind" validate:"omitempty,oneof=cat dog"`
+				Age    int      `json:"age" validate:"gte=0,lt=30"`
+				Weight float64  `json:"weight" validate:"gt=0.5,lte=99.99"`
+				Tags   []string `json:"tags" validate:"len=3"`
+				Email  string   `json:"email" validate:"email"`
+				Site   string   `json:"site" validate:"url"`
+				ID     string   `json:"id" validate:"uuid"`
+			}{},
+			`{"type": "object", "required": ["name", "age", "weight", "tags", "email", "site", "id"], "properties": {
+				"name": {"type": "string", "minLength": 1, "maxLength": 20},
+				"kind": {"type": "string", "enum": ["cat", "dog"]},
+				"age": {"type": "integer", "minimum": 0, "maximum": 30, "exclusiveMaximum": true},
+				"weight": {"type": "number", "format": "double", "minimum": 0.5, "exclusiveMinimum": true, "maximum": 99.99},
+				"tags": {"type": "array", "items": {"type": "string"}, "minItems": 3, "maxItems": 3},
+				"email": {"type": "string", "format": "email"},
+				"site": {"type": "string", "format": "uri"},
+				"id": {"type": "string", "format": "uuid"}}}`,
+			`{}`,
+		},
+		{
+			"example, default, format and description tags",
+			struct {
+				Name  string  `json:"name" example:"rex" description:"The name."`
+				Age   int     `json:"age" example:"3" default:"1"`
+				Price float64 `json:"price" format:"float" example:"9.5"`
+				Good  bool    `json:"good" example:"true"`
+				Base  base    `json:"base" description:"The base."`
+			}{},
+			`{"type": "object", "required": ["name", "age", "price", "good", "base"], "properties": {
+				"name": {"type": "string", "description": "The name.", "example": "rex"},
+				"age": {"type": "integer", "default": 1, "example": 3},
+				"price": {"type": "number", "format": "float", "example": 9.5},
+				"good": {"type": "boolean", "example": true},
+				"base": {"allOf": [{"$ref": "#/components/schemas/schema.base"}], "description": "The base."}}}`,
+			`{"schema.base": {"type": "object", "required": ["id"], "properties": {"id": {"type": "integer", "format": "int64"}}}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema, components := FromType(reflect.TypeOf(test.value), Options{})
+			assertJSON(t, "schema", schema, test.want)
+			assertJSON(t, "components", components, test.components)
+		})
+	}
+}
+
+// assertJSON reports an error when the JSON encoding of got differs from the JSON document want.
+func assertJSON(t *testing.T, name string, got interface{}, want string) {
+	t.Helper()
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(data, &gotValue); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("%s: %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s = %s, want %s", name, data, want)
+	}
+}